@@ -0,0 +1,7 @@
+# Renew on demand with: terraform apply -invoke action.certmgr_renew.web
+action "certmgr_renew" "web" {
+  config {
+    certificate_id = certmgr_certificate.web.id
+    hostname       = certmgr_certificate.web.hostname
+  }
+}
@@ -0,0 +1,7 @@
+data "certmgr_certificate" "frontend" {
+  hostname = "frontend.example.cern.ch"
+}
+
+output "frontend_expiry" {
+  value = data.certmgr_certificate.frontend.end
+}
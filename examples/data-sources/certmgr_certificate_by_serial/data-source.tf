@@ -0,0 +1,7 @@
+data "certmgr_certificate_by_serial" "suspicious" {
+  serial = "4f1a6c2e9b03d7a8"
+}
+
+output "suspicious_host" {
+  value = data.certmgr_certificate_by_serial.suspicious.hostname
+}
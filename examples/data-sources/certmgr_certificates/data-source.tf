@@ -0,0 +1,8 @@
+data "certmgr_certificates" "db_this_year" {
+  hostname_prefix = "db-"
+  start_after     = "2025-01-01T00:00:00Z"
+}
+
+output "db_serials" {
+  value = data.certmgr_certificates.db_this_year.certificates[*].serial
+}
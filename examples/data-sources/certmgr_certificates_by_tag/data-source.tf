@@ -0,0 +1,9 @@
+data "certmgr_certificates_by_tag" "db" {
+  tags = {
+    team = "db"
+  }
+}
+
+output "db_hostnames" {
+  value = data.certmgr_certificates_by_tag.db.certificates[*].hostname
+}
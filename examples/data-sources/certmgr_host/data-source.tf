@@ -0,0 +1,14 @@
+data "certmgr_host" "frontend" {
+  hostname = "frontend.example.cern.ch"
+}
+
+resource "certmgr_certificate" "frontend" {
+  hostname = data.certmgr_host.frontend.hostname
+
+  lifecycle {
+    precondition {
+      condition     = data.certmgr_host.frontend.responsible_egroup == "it-web-admins"
+      error_message = "frontend.example.cern.ch is not managed by it-web-admins."
+    }
+  }
+}
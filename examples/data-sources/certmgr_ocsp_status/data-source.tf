@@ -0,0 +1,14 @@
+data "certmgr_certificate" "frontend" {
+  hostname = "frontend.example.cern.ch"
+}
+
+check "frontend_not_revoked" {
+  data "certmgr_ocsp_status" "frontend" {
+    certificate_pem = data.certmgr_certificate.frontend.certificate_pem
+  }
+
+  assert {
+    condition     = data.certmgr_ocsp_status.frontend.status == "good"
+    error_message = "The frontend certificate is ${data.certmgr_ocsp_status.frontend.status} according to OCSP."
+  }
+}
@@ -0,0 +1,7 @@
+data "certmgr_statistics" "frontend" {
+  hostgroup = "webservices/frontend"
+}
+
+output "frontend_expiring" {
+  value = data.certmgr_statistics.frontend.expiring_in_30_days
+}
@@ -0,0 +1,10 @@
+ephemeral "certmgr_api_token" "ci" {
+  lifetime_seconds = 900
+}
+
+provider "restapi" {
+  uri = "https://hector.cern.ch:8008/certmgr/"
+  headers = {
+    Authorization = "Bearer ${ephemeral.certmgr_api_token.ci.token}"
+  }
+}
@@ -0,0 +1,6 @@
+check "frontend_expiry" {
+  assert {
+    condition     = provider::certmgr::days_until_expiry(data.certmgr_certificate.frontend.end) > 14
+    error_message = "The frontend certificate expires in less than two weeks."
+  }
+}
@@ -0,0 +1,3 @@
+output "frontend_sha256" {
+  value = provider::certmgr::fingerprint(data.certmgr_certificate.frontend.certificate_pem, "sha256")
+}
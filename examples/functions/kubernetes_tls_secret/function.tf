@@ -0,0 +1,9 @@
+resource "kubernetes_secret" "tls" {
+  metadata {
+    name      = "web-tls"
+    namespace = "web"
+  }
+
+  type = "kubernetes.io/tls"
+  data = provider::certmgr::kubernetes_tls_secret(var.certificate_pem, var.private_key_pem)
+}
@@ -0,0 +1,7 @@
+locals {
+  frontend = provider::certmgr::parse_certificate(data.certmgr_certificate.frontend.certificate_pem)
+}
+
+output "frontend_sans" {
+  value = local.frontend.dns_names
+}
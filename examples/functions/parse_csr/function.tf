@@ -0,0 +1,8 @@
+variable "csr_pem" {
+  type = string
+
+  validation {
+    condition     = contains(provider::certmgr::parse_csr(var.csr_pem).dns_names, "frontend.example.cern.ch")
+    error_message = "The CSR must include frontend.example.cern.ch as a subject alternative name."
+  }
+}
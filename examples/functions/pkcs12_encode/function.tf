@@ -0,0 +1,9 @@
+resource "local_sensitive_file" "keystore" {
+  filename = "${path.module}/frontend.p12"
+  content_base64 = provider::certmgr::pkcs12_encode(
+    data.certmgr_certificate.frontend.certificate_pem,
+    certmgr_trust_bundle.cern.pem,
+    certmgr_private_key.frontend.private_key_pem,
+    var.keystore_password,
+  )
+}
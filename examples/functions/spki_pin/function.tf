@@ -0,0 +1,3 @@
+output "frontend_pin" {
+  value = "pin-sha256=\"${provider::certmgr::spki_pin(certmgr_private_key.frontend.public_key_pem)}\""
+}
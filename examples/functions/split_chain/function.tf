@@ -0,0 +1,7 @@
+locals {
+  chain = provider::certmgr::split_chain(var.vendor_bundle_pem)
+}
+
+output "leaf_pem" {
+  value = local.chain[0]
+}
@@ -0,0 +1,3 @@
+output "frontend_tlsa" {
+  value = provider::certmgr::tlsa_record(data.certmgr_certificate.frontend.certificate_pem, 3, 1, 1)
+}
@@ -0,0 +1,8 @@
+variable "csr_pem" {
+  type = string
+
+  validation {
+    condition     = provider::certmgr::validate_pem(var.csr_pem, "CERTIFICATE REQUEST")
+    error_message = "csr_pem must be a PEM-encoded certificate signing request."
+  }
+}
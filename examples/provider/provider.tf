@@ -5,6 +5,12 @@ terraform {
       version = "1.0.0"
     }
   }
+
+  # Optional: attribute the certificates of this module in certMgr.
+  provider_meta "certmgr" {
+    module_name    = "web-frontend"
+    module_version = "1.2.0"
+  }
 }
 
 provider "certmgr" {
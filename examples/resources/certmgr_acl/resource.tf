@@ -0,0 +1,6 @@
+resource "certmgr_acl" "web_admins" {
+  hostgroup      = "webservices/frontend"
+  principal      = "it-web-admins"
+  principal_type = "egroup"
+  permissions    = ["request", "renew"]
+}
@@ -0,0 +1,5 @@
+resource "certmgr_auto_renewal_policy" "frontends" {
+  hostnames           = ["web01.cern.ch", "web02.cern.ch"]
+  lead_time_days      = 21
+  notification_target = "it-web-admins@cern.ch"
+}
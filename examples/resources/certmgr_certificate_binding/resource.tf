@@ -0,0 +1,6 @@
+resource "certmgr_certificate_binding" "frontend_https" {
+  certificate_id    = certmgr_certificate.frontend.id
+  service_name      = "nginx"
+  port              = 443
+  deployment_target = "webservices/frontend"
+}
@@ -0,0 +1,3 @@
+resource "certmgr_certificate_set" "cluster" {
+  hostnames = [for i in range(1, 25) : format("node%02d.cluster.cern.ch", i)]
+}
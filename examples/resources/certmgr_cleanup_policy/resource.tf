@@ -0,0 +1,8 @@
+resource "certmgr_cleanup_policy" "ci" {
+  requestor       = "ci-robot"
+  older_than_days = 90
+
+  triggers = {
+    weekly = formatdate("YYYY-ww", timestamp())
+  }
+}
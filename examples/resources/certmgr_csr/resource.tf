@@ -0,0 +1,17 @@
+resource "certmgr_private_key" "web" {
+  algorithm = "RSA"
+  rsa_bits  = 3072
+}
+
+resource "certmgr_csr" "web" {
+  private_key_pem = certmgr_private_key.web.private_key_pem
+  common_name     = "myhostname.cern.ch"
+  organization    = "CERN"
+  country         = "CH"
+  dns_names       = ["myhostname.cern.ch", "myalias.cern.ch"]
+}
+
+resource "certmgr_certificate" "web" {
+  hostname = "myhostname.cern.ch"
+  csr_pem  = certmgr_csr.web.csr_pem
+}
@@ -0,0 +1,4 @@
+resource "certmgr_dns_alias" "www" {
+  hostname = certmgr_host.frontend.hostname
+  alias    = "www.example.cern.ch"
+}
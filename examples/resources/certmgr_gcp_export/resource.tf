@@ -0,0 +1,5 @@
+resource "certmgr_gcp_export" "web" {
+  certificate_id = certmgr_certificate.my_cert.id
+  project        = "my-project"
+  name           = "web-cern-ch"
+}
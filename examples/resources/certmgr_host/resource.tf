@@ -0,0 +1,5 @@
+resource "certmgr_host" "web" {
+  hostname           = "myhostname.cern.ch"
+  owner              = "jdoe"
+  responsible_egroup = "it-web-admins"
+}
@@ -0,0 +1,9 @@
+resource "certmgr_keyvault_export" "web" {
+  certificate_id = certmgr_certificate.my_cert.id
+  vault_url      = "https://example.vault.azure.net"
+  name           = "web-cern-ch"
+
+  tags = {
+    team = "web"
+  }
+}
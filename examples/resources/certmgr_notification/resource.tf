@@ -0,0 +1,6 @@
+resource "certmgr_notification" "frontend_expiry" {
+  hostgroup   = "webservices/frontend"
+  email       = "it-web-admins@cern.ch"
+  webhook_url = "https://mattermost.example.cern.ch/hooks/abc123"
+  lead_days   = [30, 14, 3]
+}
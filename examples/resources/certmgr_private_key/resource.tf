@@ -0,0 +1,4 @@
+resource "certmgr_private_key" "web" {
+  algorithm   = "ECDSA"
+  ecdsa_curve = "P384"
+}
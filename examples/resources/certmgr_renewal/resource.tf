@@ -0,0 +1,8 @@
+resource "certmgr_renewal" "campaign" {
+  certificate_id = certmgr_certificate.my_cert.id
+  hostname       = certmgr_certificate.my_cert.hostname
+
+  triggers = {
+    campaign = "2025-q3"
+  }
+}
@@ -0,0 +1,4 @@
+resource "certmgr_revocation" "compromised" {
+  certificate_id = certmgr_certificate.my_cert.id
+  reason         = "keyCompromise"
+}
@@ -0,0 +1,8 @@
+resource "certmgr_service_identity" "backup_robot" {
+  name         = "backup-robot"
+  owner_egroup = "it-backup-admins"
+
+  rotation_triggers = {
+    quarter = "2025-Q3"
+  }
+}
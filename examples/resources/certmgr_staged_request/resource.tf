@@ -0,0 +1,3 @@
+resource "certmgr_staged_request" "example" {
+  hostname = "myhostname.cern.ch"
+}
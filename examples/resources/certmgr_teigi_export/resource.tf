@@ -0,0 +1,7 @@
+resource "certmgr_teigi_export" "web" {
+  certificate_id     = certmgr_certificate.my_cert.id
+  hostgroup          = "it/web/frontend"
+  certificate_secret = "frontend_tls_crt"
+  chain_secret       = "frontend_tls_chain"
+  private_key_secret = "frontend_tls_key"
+}
@@ -0,0 +1,8 @@
+resource "certmgr_template" "web" {
+  name                 = "web-server"
+  description          = "TLS server certificates for web frontends"
+  key_algorithm        = "RSA"
+  min_key_bits         = 3072
+  max_validity_days    = 397
+  allowed_san_patterns = ["*.cern.ch", "*.web.cern.ch"]
+}
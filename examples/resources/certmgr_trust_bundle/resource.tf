@@ -0,0 +1,4 @@
+resource "certmgr_trust_bundle" "grid" {
+  name        = "grid-hosts"
+  authorities = ["CERN Root Certification Authority 2", "CERN Grid Certification Authority"]
+}
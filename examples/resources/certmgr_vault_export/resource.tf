@@ -0,0 +1,6 @@
+resource "certmgr_vault_export" "web" {
+  certificate_id = certmgr_certificate.my_cert.id
+  address        = "https://vault.cern.ch:8200"
+  mount          = "secret"
+  path           = "tls/${certmgr_certificate.my_cert.hostname}"
+}
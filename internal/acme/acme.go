@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package acme issues certificates from an ACME directory, such as Let's
+// Encrypt or an internal ACME CA, for hostnames certMgr cannot issue for.
+// Only the http-01 challenge is supported, answered by writing the key
+// authorization below the webroot served for the hostname.
+package acme
+
+import (
+	"context"
+	"crypto"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+
+	"certMgr/internal/pki"
+)
+
+// LetsEncryptURL is the directory URL of the Let's Encrypt production CA.
+const LetsEncryptURL = acme.LetsEncryptURL
+
+// challengeDir is the directory below the webroot from which ACME servers
+// fetch http-01 challenge responses.
+const challengeDir = ".well-known/acme-challenge"
+
+// Issuer requests certificates from an ACME directory.
+type Issuer struct {
+	HTTPClient   *http.Client
+	DirectoryURL string
+	// Email is registered as the contact of the account, if set.
+	Email string
+	// AccountKey identifies the ACME account. Accounts are registered on
+	// first use.
+	AccountKey crypto.Signer
+	// Webroot is the directory served at http://<hostname>/.
+	Webroot string
+}
+
+// NewIssuer returns an issuer for the directory at directoryURL. When
+// accountKey is nil, a new account is registered with a fresh key.
+func NewIssuer(directoryURL, email, webroot string, accountKey crypto.Signer) (*Issuer, error) {
+	if directoryURL == "" {
+		return nil, errors.New("ACME directory URL is required")
+	}
+	if webroot == "" {
+		return nil, errors.New("webroot is required to answer http-01 challenges")
+	}
+	if accountKey == nil {
+		var err error
+		accountKey, err = pki.GenerateKey("ECDSA", 0, "P256")
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ACME account key: %w", err)
+		}
+	}
+	return &Issuer{
+		HTTPClient:   http.DefaultClient,
+		DirectoryURL: directoryURL,
+		Email:        email,
+		AccountKey:   accountKey,
+		Webroot:      webroot,
+	}, nil
+}
+
+// Issue orders a certificate for hostname with the PEM encoded certificate
+// signing request csrPEM and returns the issued certificate followed by its
+// chain, PEM encoded.
+func (i *Issuer) Issue(ctx context.Context, hostname, csrPEM string) (string, error) {
+	csr, err := pki.ParseCSRPEM(csrPEM)
+	if err != nil {
+		return "", err
+	}
+
+	client := &acme.Client{
+		Key:          i.AccountKey,
+		HTTPClient:   i.HTTPClient,
+		DirectoryURL: i.DirectoryURL,
+		UserAgent:    "terraform-provider-certmgr",
+	}
+
+	account := &acme.Account{}
+	if i.Email != "" {
+		account.Contact = []string{"mailto:" + i.Email}
+	}
+	if _, err := client.Register(ctx, account, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return "", fmt.Errorf("failed to register ACME account: %w", err)
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(hostname))
+	if err != nil {
+		return "", fmt.Errorf("failed to create ACME order for %s: %w", hostname, err)
+	}
+	for _, authzURL := range order.AuthzURLs {
+		if err := i.authorize(ctx, client, authzURL); err != nil {
+			return "", err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return "", fmt.Errorf("ACME order for %s failed: %w", hostname, err)
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr.Raw, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to finalize ACME order for %s: %w", hostname, err)
+	}
+
+	var certificates strings.Builder
+	for _, der := range chain {
+		certificates.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+	return certificates.String(), nil
+}
+
+// authorize answers the http-01 challenge of a pending authorization and
+// waits for the ACME server to validate it. The challenge response is
+// removed from the webroot afterwards.
+func (i *Issuer) authorize(ctx context.Context, client *acme.Client, authzURL string) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("failed to get ACME authorization: %w", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "http-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("ACME server offers no http-01 challenge for %s", authz.Identifier.Value)
+	}
+
+	response, err := client.HTTP01ChallengeResponse(challenge.Token)
+	if err != nil {
+		return fmt.Errorf("failed to compute http-01 challenge response: %w", err)
+	}
+	dir := filepath.Join(i.Webroot, filepath.FromSlash(challengeDir))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create challenge directory: %w", err)
+	}
+	file := filepath.Join(dir, challenge.Token)
+	if err := os.WriteFile(file, []byte(response), 0o644); err != nil {
+		return fmt.Errorf("failed to write challenge response: %w", err)
+	}
+	defer os.Remove(file)
+
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("failed to accept http-01 challenge for %s: %w", authz.Identifier.Value, err)
+	}
+	if _, err := client.WaitAuthorization(ctx, authzURL); err != nil {
+		return fmt.Errorf("http-01 challenge for %s failed: %w", authz.Identifier.Value, err)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package acme_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"certMgr/internal/acme"
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeACME is a minimal ACME server issuing a single order. Challenges are
+// validated by reading the response from the webroot directly, and JWS
+// signatures are not verified.
+type fakeACME struct {
+	t       *testing.T
+	webroot string
+	token   string
+
+	mu        sync.Mutex
+	validated bool
+	csr       *x509.CertificateRequest
+}
+
+func (f *fakeACME) handler(url func() string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"newNonce":   url() + "/nonce",
+			"newAccount": url() + "/account",
+			"newOrder":   url() + "/order",
+		})
+	})
+	mux.HandleFunc("/nonce", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce")
+	})
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", url()+"/account/1")
+		writeJSON(w, http.StatusCreated, map[string]string{"status": "valid"})
+	})
+	mux.HandleFunc("/order", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", url()+"/order/1")
+		writeJSON(w, http.StatusCreated, f.order(url()))
+	})
+	mux.HandleFunc("/order/1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, f.order(url()))
+	})
+	mux.HandleFunc("/authz/1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, f.authorization(url()))
+	})
+	mux.HandleFunc("/challenge/1", func(w http.ResponseWriter, r *http.Request) {
+		response, err := os.ReadFile(filepath.Join(f.webroot, ".well-known", "acme-challenge", f.token))
+		require.NoError(f.t, err)
+		require.True(f.t, strings.HasPrefix(string(response), f.token+"."))
+
+		f.mu.Lock()
+		f.validated = true
+		f.mu.Unlock()
+		writeJSON(w, http.StatusOK, f.challenge(url()))
+	})
+	mux.HandleFunc("/finalize/1", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			CSR string `json:"csr"`
+		}
+		decodeJWSPayload(f.t, r, &payload)
+		der, err := base64.RawURLEncoding.DecodeString(payload.CSR)
+		require.NoError(f.t, err)
+		csr, err := x509.ParseCertificateRequest(der)
+		require.NoError(f.t, err)
+
+		f.mu.Lock()
+		f.csr = csr
+		f.mu.Unlock()
+		writeJSON(w, http.StatusOK, f.order(url()))
+	})
+	mux.HandleFunc("/certificate/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write([]byte(f.certificate()))
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce")
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (f *fakeACME) order(url string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	order := map[string]any{
+		"status":         "pending",
+		"identifiers":    []map[string]string{{"type": "dns", "value": "web.example.org"}},
+		"authorizations": []string{url + "/authz/1"},
+		"finalize":       url + "/finalize/1",
+	}
+	switch {
+	case f.csr != nil:
+		order["status"] = "valid"
+		order["certificate"] = url + "/certificate/1"
+	case f.validated:
+		order["status"] = "ready"
+	}
+	return order
+}
+
+func (f *fakeACME) authorization(url string) map[string]any {
+	f.mu.Lock()
+	status := "pending"
+	if f.validated {
+		status = "valid"
+	}
+	f.mu.Unlock()
+
+	return map[string]any{
+		"status":     status,
+		"identifier": map[string]string{"type": "dns", "value": "web.example.org"},
+		"challenges": []map[string]string{f.challenge(url)},
+	}
+}
+
+func (f *fakeACME) challenge(url string) map[string]string {
+	return map[string]string{"type": "http-01", "url": url + "/challenge/1", "token": f.token, "status": "pending"}
+}
+
+// certificate returns the certificate for the finalized CSR followed by the
+// self-signed CA certificate that issued it.
+func (f *fakeACME) certificate() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(f.t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fake ACME CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, caKey.Public(), caKey)
+	require.NoError(f.t, err)
+
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      f.csr.Subject,
+		DNSNames:     f.csr.DNSNames,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leaf, ca, f.csr.PublicKey, caKey)
+	require.NoError(f.t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})) +
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func decodeJWSPayload(t *testing.T, r *http.Request, v any) {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&jws))
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(payload, v))
+}
+
+func TestIssue(t *testing.T) {
+	webroot := t.TempDir()
+	fake := &fakeACME{t: t, webroot: webroot, token: "token-1"}
+	var server *httptest.Server
+	server = httptest.NewServer(fake.handler(func() string { return server.URL }))
+	defer server.Close()
+
+	issuer, err := acme.NewIssuer(server.URL+"/directory", "admin@example.org", webroot, nil)
+	require.NoError(t, err)
+
+	key, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	csr, err := pki.CreateCSR(key, pki.CSRSubject{CommonName: "web.example.org", DNSNames: []string{"web.example.org"}})
+	require.NoError(t, err)
+
+	chain, err := issuer.Issue(context.Background(), "web.example.org", csr)
+	require.NoError(t, err)
+
+	certificates, err := pki.ParseCertificatesPEM(chain)
+	require.NoError(t, err)
+	require.Len(t, certificates, 2)
+	require.Equal(t, []string{"web.example.org"}, certificates[0].DNSNames)
+	require.True(t, fake.validated)
+
+	// The challenge response is cleaned up once validated.
+	_, err = os.Stat(filepath.Join(webroot, ".well-known", "acme-challenge", "token-1"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestNewIssuerRequiresWebroot(t *testing.T) {
+	_, err := acme.NewIssuer(acme.LetsEncryptURL, "", "", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ACL grants a user or e-group permission to request or renew certificates
+// for a hostname or for every host of a hostgroup.
+type ACL struct {
+	ID            int      `json:"id,omitempty"`
+	Hostname      string   `json:"hostname,omitempty"`
+	Hostgroup     string   `json:"hostgroup,omitempty"`
+	Principal     string   `json:"principal"`
+	PrincipalType string   `json:"principal_type"`
+	Permissions   []string `json:"permissions"`
+}
+
+// ACLPrincipalTypes and ACLPermissions are the values certMgr accepts for
+// ACL.PrincipalType and ACL.Permissions.
+var (
+	ACLPrincipalTypes = []string{"user", "egroup"}
+	ACLPermissions    = []string{"request", "renew"}
+)
+
+var ErrNoACL = errors.New("no acl found")
+
+func (c *Client) CreateACL(ctx context.Context, acl ACL) (*ACL, error) {
+	if acl.Hostname != "" {
+		hostname, err := NormalizeHostname(acl.Hostname)
+		if err != nil {
+			return nil, err
+		}
+		acl.Hostname = hostname
+	}
+
+	return createObject[ACL](ctx, c, "acl/", acl)
+}
+
+func (c *Client) GetACL(ctx context.Context, id int) (*ACL, error) {
+	return getObject[ACL](ctx, c, fmt.Sprintf("acl/%d/", id), ErrNoACL)
+}
+
+func (c *Client) UpdateACL(ctx context.Context, acl ACL) error {
+	return c.putObject(ctx, fmt.Sprintf("acl/%d/", acl.ID), acl)
+}
+
+func (c *Client) DeleteACL(ctx context.Context, id int) error {
+	url := c.endpoint("acl/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for acl %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DNSAlias is an additional DNS name registered for a host. Aliases are
+// added as subject alternative names to certificates issued for the host.
+type DNSAlias struct {
+	ID       int    `json:"id,omitempty"`
+	Hostname string `json:"hostname"`
+	Alias    string `json:"alias"`
+}
+
+var ErrNoDNSAlias = errors.New("no DNS alias found")
+
+func (c *Client) CreateDNSAlias(ctx context.Context, alias DNSAlias) (*DNSAlias, error) {
+	names := []string{alias.Hostname, alias.Alias}
+	if err := normalizeHostnames(names); err != nil {
+		return nil, err
+	}
+	alias.Hostname, alias.Alias = names[0], names[1]
+
+	return createObject[DNSAlias](ctx, c, "alias/", alias)
+}
+
+func (c *Client) GetDNSAlias(ctx context.Context, id int) (*DNSAlias, error) {
+	return getObject[DNSAlias](ctx, c, fmt.Sprintf("alias/%d/", id), ErrNoDNSAlias)
+}
+
+// ListDNSAliases returns every alias registered for hostname.
+func (c *Client) ListDNSAliases(ctx context.Context, hostname string) ([]DNSAlias, error) {
+	hostname, err := NormalizeHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.queryEndpoint("alias/", url.Values{"hostname": {hostname}})
+	return listAll[DNSAlias](ctx, c, endpoint)
+}
+
+func (c *Client) DeleteDNSAlias(ctx context.Context, id int) error {
+	url := c.endpoint("alias/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for alias %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ClientAPI is the set of certMgr operations used by the provider. Client
+// implements it against the live service; clientmock provides a stand-in for
+// unit tests.
+type ClientAPI interface {
+	ServerVersion(ctx context.Context) (APIVersion, error)
+	FIPSMode() bool
+	ExternalClient() *http.Client
+	ReadErrorsAsWarnings() bool
+
+	CreateCertificate(ctx context.Context, request CertificateRequest) (*Certificate, error)
+	ListStaged(ctx context.Context, hostname string) ([]Certificate, error)
+	ListCertificates(ctx context.Context, filter CertificateFilter) ([]Certificate, error)
+	ListStagedByRequestor(ctx context.Context, requestor string) ([]Certificate, error)
+	ListStagedByTags(ctx context.Context, selector map[string]string) ([]Certificate, error)
+	GetCertificate(ctx context.Context, hostname string) (*Certificate, error)
+	GetStaged(ctx context.Context, hostname string, id int) (*Certificate, error)
+	GetCertificateByID(ctx context.Context, id int) (*Certificate, error)
+	CertificateUnchanged(ctx context.Context, id int, etag string) (bool, error)
+	GetCertificateBySerial(ctx context.Context, serial string) (*Certificate, error)
+	GetIssuedCertificate(ctx context.Context, hostname, serial string) (*Certificate, error)
+	UpdateCertificate(ctx context.Context, update CertificateUpdate) error
+	RenewCertificate(ctx context.Context, id int) (*Certificate, error)
+	DeleteCertificate(ctx context.Context, hostname string) error
+	DeleteStaged(ctx context.Context, id int) error
+
+	CreateCertificates(ctx context.Context, hostnames []string) ([]Certificate, error)
+	ListStagedForHostnames(ctx context.Context, hostnames []string) ([]Certificate, error)
+	DeleteStagedEntries(ctx context.Context, ids []int) error
+
+	CreateHost(ctx context.Context, host Host) (*Host, error)
+	GetHost(ctx context.Context, hostname string) (*Host, error)
+	UpdateHost(ctx context.Context, host Host) error
+	DeleteHost(ctx context.Context, id int) error
+
+	CreateACL(ctx context.Context, acl ACL) (*ACL, error)
+	GetACL(ctx context.Context, id int) (*ACL, error)
+	UpdateACL(ctx context.Context, acl ACL) error
+	DeleteACL(ctx context.Context, id int) error
+
+	CreateTemplate(ctx context.Context, template Template) (*Template, error)
+	GetTemplate(ctx context.Context, id int) (*Template, error)
+	UpdateTemplate(ctx context.Context, template Template) error
+	DeleteTemplate(ctx context.Context, id int) error
+
+	CreateAutoRenewalPolicy(ctx context.Context, policy AutoRenewalPolicy) (*AutoRenewalPolicy, error)
+	GetAutoRenewalPolicy(ctx context.Context, id int) (*AutoRenewalPolicy, error)
+	UpdateAutoRenewalPolicy(ctx context.Context, policy AutoRenewalPolicy) error
+	DeleteAutoRenewalPolicy(ctx context.Context, id int) error
+
+	CreateNotification(ctx context.Context, notification Notification) (*Notification, error)
+	GetNotification(ctx context.Context, id int) (*Notification, error)
+	UpdateNotification(ctx context.Context, notification Notification) error
+	DeleteNotification(ctx context.Context, id int) error
+
+	CreateRevocation(ctx context.Context, revocation Revocation) (*Revocation, error)
+	GetRevocation(ctx context.Context, id int) (*Revocation, error)
+	RevokeCertificate(ctx context.Context, serial, reason string) (*Revocation, error)
+	ListRevocations(ctx context.Context, serial string) ([]Revocation, error)
+	CertificateRevoked(ctx context.Context, serial string) (bool, error)
+
+	GetCertificateAuthority(ctx context.Context, name string) (*CertificateAuthority, error)
+
+	CreateBinding(ctx context.Context, binding Binding) (*Binding, error)
+	GetBinding(ctx context.Context, id int) (*Binding, error)
+	UpdateBinding(ctx context.Context, binding Binding) error
+	DeleteBinding(ctx context.Context, id int) error
+
+	CreateDNSAlias(ctx context.Context, alias DNSAlias) (*DNSAlias, error)
+	GetDNSAlias(ctx context.Context, id int) (*DNSAlias, error)
+	ListDNSAliases(ctx context.Context, hostname string) ([]DNSAlias, error)
+	DeleteDNSAlias(ctx context.Context, id int) error
+
+	CreateServiceIdentity(ctx context.Context, identity ServiceIdentity) (*ServiceIdentity, error)
+	GetServiceIdentity(ctx context.Context, id int) (*ServiceIdentity, error)
+	UpdateServiceIdentity(ctx context.Context, identity ServiceIdentity) error
+	DeleteServiceIdentity(ctx context.Context, id int) error
+	IssueIdentityCertificate(ctx context.Context, id int, csr string) (*Certificate, error)
+
+	GetStatistics(ctx context.Context, filter StatisticsFilter) (*Statistics, error)
+
+	CreateAPIToken(ctx context.Context, lifetime time.Duration) (*APIToken, error)
+}
+
+var _ ClientAPI = &Client{}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry is a line of the audit log, recording one request that modified
+// certMgr, including all of its retries.
+type AuditEntry struct {
+	Time         time.Time `json:"time"`
+	Principal    string    `json:"principal,omitempty"`
+	Organization string    `json:"organization,omitempty"`
+	Workspace    string    `json:"workspace,omitempty"`
+	RunID        string    `json:"run_id,omitempty"`
+	Method       string    `json:"method"`
+	URL          string    `json:"url"`
+	Status       int       `json:"status,omitempty"`
+	Outcome      string    `json:"outcome"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// Audit outcomes.
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+type auditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetAuditLog appends an AuditEntry for every POST, PUT, PATCH and DELETE to
+// the JSON lines file at path, creating it if needed. It must be called
+// before the client is used.
+func (c *Client) SetAuditLog(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	c.audit.w = file
+	return nil
+}
+
+// record appends an entry for a finished request, if auditing is enabled.
+// A failed write is reported as an error of the request, so that changes
+// never silently go unrecorded.
+func (a *auditLog) record(entry AuditEntry) error {
+	if a.w == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
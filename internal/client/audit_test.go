@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	cli.Principal = "jdoe@CERN.CH"
+	cli.Organization = "atlas"
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, cli.SetAuditLog(path))
+	ctx := context.Background()
+
+	cert, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	_, err = cli.GetCertificateByID(ctx, cert.ID)
+	require.NoError(t, err)
+	require.NoError(t, cli.DeleteStaged(ctx, cert.ID))
+	require.Error(t, cli.DeleteStaged(certMgr.WithOrganization(ctx, "cms"), cert.ID))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []certMgr.AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry certMgr.AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, entries, 3)
+	require.Equal(t, "POST", entries[0].Method)
+	require.Equal(t, certMgr.AuditSuccess, entries[0].Outcome)
+	require.Equal(t, "jdoe@CERN.CH", entries[0].Principal)
+	require.Equal(t, "atlas", entries[0].Organization)
+	require.Equal(t, "DELETE", entries[1].Method)
+	require.Equal(t, 204, entries[1].Status)
+	require.Equal(t, certMgr.AuditFailure, entries[2].Outcome)
+	require.Equal(t, 404, entries[2].Status)
+	require.Equal(t, "cms", entries[2].Organization)
+	require.NotEmpty(t, entries[2].Error)
+	require.False(t, entries[2].Time.IsZero())
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// AutoRenewalPolicy configures server-side renewal of the certificates of a
+// set of hostnames.
+type AutoRenewalPolicy struct {
+	ID                 int      `json:"id,omitempty"`
+	Hostnames          []string `json:"hostnames"`
+	Enabled            bool     `json:"enabled"`
+	LeadTimeDays       int      `json:"lead_time_days"`
+	NotificationTarget string   `json:"notification_target,omitempty"`
+}
+
+var ErrNoAutoRenewalPolicy = errors.New("no auto-renewal policy found")
+
+func (c *Client) CreateAutoRenewalPolicy(ctx context.Context, policy AutoRenewalPolicy) (*AutoRenewalPolicy, error) {
+	if err := normalizeHostnames(policy.Hostnames); err != nil {
+		return nil, err
+	}
+
+	return createObject[AutoRenewalPolicy](ctx, c, "autorenewal/", policy)
+}
+
+func (c *Client) GetAutoRenewalPolicy(ctx context.Context, id int) (*AutoRenewalPolicy, error) {
+	return getObject[AutoRenewalPolicy](ctx, c, fmt.Sprintf("autorenewal/%d/", id), ErrNoAutoRenewalPolicy)
+}
+
+func (c *Client) UpdateAutoRenewalPolicy(ctx context.Context, policy AutoRenewalPolicy) error {
+	if err := normalizeHostnames(policy.Hostnames); err != nil {
+		return err
+	}
+
+	return c.putObject(ctx, fmt.Sprintf("autorenewal/%d/", policy.ID), policy)
+}
+
+func (c *Client) DeleteAutoRenewalPolicy(ctx context.Context, id int) error {
+	url := c.endpoint("autorenewal/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for auto-renewal policy %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MaxCreateBatch is the largest number of requests CreateCertificate sends in
+// one bulk request.
+const MaxCreateBatch = 100
+
+// createBatchers holds a createBatcher per organization, as a bulk request
+// is scoped to a single one.
+type createBatchers struct {
+	mu             sync.Mutex
+	byOrganization map[string]*createBatcher
+}
+
+func (b *createBatchers) get(org string) *createBatcher {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	batcher, ok := b.byOrganization[org]
+	if !ok {
+		if b.byOrganization == nil {
+			b.byOrganization = map[string]*createBatcher{}
+		}
+		batcher = &createBatcher{}
+		b.byOrganization[org] = batcher
+	}
+	return batcher
+}
+
+// createBatcher collects the CreateCertificate calls of a batch window.
+type createBatcher struct {
+	mu      sync.Mutex
+	pending []*pendingCreate
+	timer   *time.Timer
+}
+
+type pendingCreate struct {
+	request CertificateRequest
+	done    chan createResult
+}
+
+type createResult struct {
+	cert *Certificate
+	err  error
+}
+
+// take returns the open batch and starts a new one. b.mu must be held.
+func (b *createBatcher) take() []*pendingCreate {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	return batch
+}
+
+// createBatched adds request to the open batch and waits for the batch to be
+// sent, CreateBatchWindow after its first request or once it is full. A
+// hostname already in the open batch is staged on its own, as the entries of
+// one bulk request cannot be told apart by hostname.
+func (c *Client) createBatched(ctx context.Context, request CertificateRequest) (*Certificate, error) {
+	p := &pendingCreate{request: request, done: make(chan createResult, 1)}
+	// The batch outlives the caller that opened it, but keeps the values of
+	// its context.
+	batchCtx := context.WithoutCancel(ctx)
+
+	b := c.creates.get(c.organization(ctx))
+	b.mu.Lock()
+	for _, q := range b.pending {
+		if q.request.Hostname == request.Hostname {
+			b.mu.Unlock()
+			return c.createStaged(ctx, request)
+		}
+	}
+	b.pending = append(b.pending, p)
+	switch {
+	case len(b.pending) >= MaxCreateBatch:
+		go c.sendCreates(batchCtx, b.take())
+	case len(b.pending) == 1:
+		b.timer = time.AfterFunc(c.CreateBatchWindow, func() {
+			b.mu.Lock()
+			batch := b.take()
+			b.mu.Unlock()
+			c.sendCreates(batchCtx, batch)
+		})
+	}
+	b.mu.Unlock()
+
+	select {
+	case result := <-p.done:
+		return result.cert, result.err
+	case <-ctx.Done():
+		// A request still waiting for its batch is withdrawn, so that
+		// nothing is staged that the caller will not record. Once the batch
+		// was sent, the entry is staged regardless.
+		b.mu.Lock()
+		b.pending = slices.DeleteFunc(b.pending, func(q *pendingCreate) bool { return q == p })
+		if len(b.pending) == 0 {
+			b.take()
+		}
+		b.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// sendCreates stages batch with a single bulk request and hands each caller
+// the entry stageBulk matched to its request. Requests the bulk request did
+// not visibly stage yet are awaited like asynchronously accepted POSTs.
+func (c *Client) sendCreates(ctx context.Context, batch []*pendingCreate) {
+	switch len(batch) {
+	case 0:
+		return
+	case 1:
+		cert, err := c.createStaged(ctx, batch[0].request)
+		batch[0].done <- createResult{cert, err}
+		return
+	}
+
+	requests := make([]CertificateRequest, len(batch))
+	for i, p := range batch {
+		requests[i] = p.request
+		defer c.certificates.invalidate(p.request.Hostname)
+	}
+
+	before, created, err := c.stageBulk(ctx, requests)
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode < http.StatusInternalServerError {
+		// certMgr rejected the batch as a whole; stage each request on its
+		// own so that one invalid request does not fail the others.
+		var wg sync.WaitGroup
+		for _, p := range batch {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cert, err := c.createStaged(ctx, p.request)
+				p.done <- createResult{cert, err}
+			}()
+		}
+		wg.Wait()
+		return
+	}
+	if err != nil {
+		for _, p := range batch {
+			p.done <- createResult{err: err}
+		}
+		return
+	}
+
+	existing := make(map[string][]Certificate)
+	for _, cert := range before {
+		existing[cert.Hostname] = append(existing[cert.Hostname], cert)
+	}
+
+	var wg sync.WaitGroup
+	for i, p := range batch {
+		if created[i] != nil {
+			p.done <- createResult{cert: created[i]}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cert, err := c.awaitStaged(ctx, p.request.Hostname, existing[p.request.Hostname])
+			p.done <- createResult{cert, err}
+		}()
+	}
+	wg.Wait()
+}
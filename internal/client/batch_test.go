@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createConcurrently creates a certificate for each request at once and
+// counts the requests cli sends per method.
+func createConcurrently(t *testing.T, cli *certMgr.Client, requests []certMgr.CertificateRequest) ([]*certMgr.Certificate, []error, map[string]int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	methods := map[string]int{}
+	cli.OnRequest(func(req *http.Request) {
+		mu.Lock()
+		methods[req.Method]++
+		mu.Unlock()
+	})
+
+	certs := make([]*certMgr.Certificate, len(requests))
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			certs[i], errs[i] = cli.CreateCertificate(context.Background(), request)
+		}()
+	}
+	wg.Wait()
+	return certs, errs, methods
+}
+
+func TestCreateCertificateBatched(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	cli.CreateBatchWindow = 100 * time.Millisecond
+
+	requests := make([]certMgr.CertificateRequest, 20)
+	for i := range requests {
+		requests[i].Hostname = fmt.Sprintf("TF-batch-%d.cern.ch", i)
+	}
+	certs, errs, methods := createConcurrently(t, cli, requests)
+
+	ids := map[int]bool{}
+	for i, cert := range certs {
+		require.NoError(t, errs[i])
+		require.Equal(t, fmt.Sprintf("tf-batch-%d.cern.ch", i), cert.Hostname)
+		ids[cert.ID] = true
+	}
+	require.Len(t, ids, len(requests))
+	require.Equal(t, 1, methods[http.MethodPatch])
+	require.Zero(t, methods[http.MethodPost])
+	require.Len(t, server.Certificates(), len(requests))
+}
+
+func TestCreateCertificateBatchRejected(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	cli.CreateBatchWindow = 100 * time.Millisecond
+
+	requests := []certMgr.CertificateRequest{
+		{Hostname: "tf-batch-0.cern.ch"},
+		{Hostname: "tf-batch-1.cern.ch", CSR: "not a CSR"},
+		{Hostname: "tf-batch-2.cern.ch"},
+		{Hostname: "tf-batch-2.cern.ch"},
+	}
+	certs, errs, methods := createConcurrently(t, cli, requests)
+
+	require.NoError(t, errs[0])
+	require.Equal(t, "tf-batch-0.cern.ch", certs[0].Hostname)
+	require.Error(t, errs[1])
+	require.NoError(t, errs[2])
+	require.NoError(t, errs[3])
+	require.NotEqual(t, certs[2].ID, certs[3].ID)
+	require.Equal(t, 1, methods[http.MethodPatch])
+	require.Equal(t, 4, methods[http.MethodPost])
+	require.Len(t, server.Certificates(), 3)
+}
+
+// createRacingAnotherRun creates two certificates in one batch while another
+// client stages an entry for the first hostname right after the bulk request.
+func createRacingAnotherRun(t *testing.T, server *fakecertmgr.Server) ([]*certMgr.Certificate, *certMgr.Certificate) {
+	t.Helper()
+
+	cli := server.NewClient()
+	cli.CreateBatchWindow = 100 * time.Millisecond
+	var other *certMgr.Certificate
+	cli.OnResponse(func(resp *http.Response) {
+		if resp.Request.Method != http.MethodPatch {
+			return
+		}
+		var err error
+		other, err = server.NewClient().CreateCertificate(context.Background(), certMgr.CertificateRequest{Hostname: "tf-batch-0.cern.ch"})
+		require.NoError(t, err)
+	})
+
+	certs, errs, _ := createConcurrently(t, cli, []certMgr.CertificateRequest{
+		{Hostname: "tf-batch-0.cern.ch"},
+		{Hostname: "tf-batch-1.cern.ch"},
+	})
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.NotNil(t, other)
+	return certs, other
+}
+
+func TestCreateCertificateBatchedConcurrentCreator(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	server.AlwaysReturnData = true
+
+	certs, other := createRacingAnotherRun(t, server)
+	require.NotEqual(t, other.ID, certs[0].ID)
+	require.Equal(t, "tf-batch-0.cern.ch", certs[0].Hostname)
+	require.Len(t, server.Certificates(), 3)
+}
+
+func TestCreateCertificateBatchedConcurrentCreatorWithoutData(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+
+	// Without the created objects in the bulk response, the entry the other
+	// client staged is the newest one of its hostname and is handed out in
+	// place of the batch's own.
+	certs, other := createRacingAnotherRun(t, server)
+	require.Equal(t, other.ID, certs[0].ID)
+	require.Len(t, server.Certificates(), 3)
+}
+
+func TestCreateCertificateBatchedCancelled(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	cli.CreateBatchWindow = 200 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var wg sync.WaitGroup
+	var cert *certMgr.Certificate
+	var err error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cert, err = cli.CreateCertificate(context.Background(), certMgr.CertificateRequest{Hostname: "tf-batch-0.cern.ch"})
+	}()
+	_, cancelledErr := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-batch-1.cern.ch"})
+	wg.Wait()
+
+	require.ErrorIs(t, cancelledErr, context.DeadlineExceeded)
+	require.NoError(t, err)
+	require.Equal(t, []certMgr.Certificate{*cert}, server.Certificates())
+}
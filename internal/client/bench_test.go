@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/stretchr/testify/require"
+)
+
+// benchCertificates is the number of certificates the benchmarks refresh,
+// the size of a large Terraform configuration.
+const benchCertificates = 1000
+
+// refreshParallelism matches the default parallelism of Terraform.
+const refreshParallelism = 10
+
+// newLoadServer starts a fake server holding n certificates and returns it
+// with their IDs.
+func newLoadServer(tb testing.TB, n int) (*fakecertmgr.Server, []int) {
+	tb.Helper()
+
+	server := fakecertmgr.NewServer(tb)
+	hostnames := make([]string, n)
+	for i := range hostnames {
+		hostnames[i] = fmt.Sprintf("tf-load-%d.cern.ch", i)
+	}
+	created, err := server.NewClient().CreateCertificates(context.Background(), hostnames)
+	require.NoError(tb, err)
+	require.Len(tb, created, n)
+
+	ids := make([]int, n)
+	for i, cert := range created {
+		ids[i] = cert.ID
+	}
+	return server, ids
+}
+
+// refresh reads every certificate by ID with a fresh client, as a Terraform
+// refresh does, with parallelism reads in flight.
+func refresh(ctx context.Context, cli *certMgr.Client, ids []int, parallelism int) error {
+	work := make(chan int)
+	errs := make(chan error, parallelism)
+	var wg sync.WaitGroup
+	for range parallelism {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range work {
+				if _, err := cli.GetCertificateByID(ctx, id); err != nil {
+					errs <- fmt.Errorf("reading %d: %w", id, err)
+					return
+				}
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for _, id := range ids {
+		select {
+		case work <- id:
+		case err = <-errs:
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	select {
+	case err = <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+func BenchmarkRefresh(b *testing.B) {
+	server, ids := newLoadServer(b, benchCertificates)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for range b.N {
+		require.NoError(b, refresh(ctx, server.NewClient(), ids, refreshParallelism))
+	}
+	b.ReportMetric(float64(len(ids)*b.N)/b.Elapsed().Seconds(), "certs/s")
+}
+
+func BenchmarkListCertificates(b *testing.B) {
+	server, ids := newLoadServer(b, benchCertificates)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for range b.N {
+		certs, err := server.NewClient().ListCertificates(ctx, certMgr.CertificateFilter{HostnamePrefix: "tf-load-"})
+		require.NoError(b, err)
+		require.Len(b, certs, len(ids))
+	}
+}
+
+// TestLoadRefresh refreshes CERTMGR_LOAD certificates, with
+// CERTMGR_LOAD_PARALLELISM reads in flight (default 10), and reports the
+// throughput. With CERTMGR_LOAD_MIN_RATE set, it fails below that many
+// certificates per second.
+func TestLoadRefresh(t *testing.T) {
+	count, err := strconv.Atoi(os.Getenv("CERTMGR_LOAD"))
+	if err != nil || count <= 0 {
+		t.Skip("set CERTMGR_LOAD to the number of certificates to run the load test")
+	}
+	parallelism := refreshParallelism
+	if value := os.Getenv("CERTMGR_LOAD_PARALLELISM"); value != "" {
+		parallelism, err = strconv.Atoi(value)
+		require.NoError(t, err, "CERTMGR_LOAD_PARALLELISM")
+	}
+
+	server, ids := newLoadServer(t, count)
+	start := time.Now()
+	require.NoError(t, refresh(context.Background(), server.NewClient(), ids, parallelism))
+	elapsed := time.Since(start)
+
+	rate := float64(count) / elapsed.Seconds()
+	t.Logf("refreshed %d certificates in %s with parallelism %d: %.0f certs/s", count, elapsed, parallelism, rate)
+
+	if value := os.Getenv("CERTMGR_LOAD_MIN_RATE"); value != "" {
+		minRate, err := strconv.ParseFloat(value, 64)
+		require.NoError(t, err, "CERTMGR_LOAD_MIN_RATE")
+		require.GreaterOrEqual(t, rate, minRate, "refresh throughput regressed")
+	}
+}
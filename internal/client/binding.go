@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Binding records where an issued certificate is deployed, so the certMgr
+// inventory reflects which services actually use it.
+type Binding struct {
+	ID               int    `json:"id,omitempty"`
+	CertificateID    int    `json:"certificate_id"`
+	ServiceName      string `json:"service_name"`
+	Port             int    `json:"port,omitempty"`
+	DeploymentTarget string `json:"deployment_target,omitempty"`
+}
+
+var ErrNoBinding = errors.New("no binding found")
+
+func (c *Client) CreateBinding(ctx context.Context, binding Binding) (*Binding, error) {
+	return createObject[Binding](ctx, c, "binding/", binding)
+}
+
+func (c *Client) GetBinding(ctx context.Context, id int) (*Binding, error) {
+	return getObject[Binding](ctx, c, fmt.Sprintf("binding/%d/", id), ErrNoBinding)
+}
+
+func (c *Client) UpdateBinding(ctx context.Context, binding Binding) error {
+	return c.putObject(ctx, fmt.Sprintf("binding/%d/", binding.ID), binding)
+}
+
+func (c *Client) DeleteBinding(ctx context.Context, id int) error {
+	url := c.endpoint("binding/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for binding %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrUnreachable is returned without contacting certMgr while the circuit
+// breaker is open after repeated connection failures or server errors.
+var ErrUnreachable = errors.New("certMgr unreachable")
+
+// DefaultBreakerThreshold is the BreakerThreshold of clients created by
+// NewClient.
+const DefaultBreakerThreshold = 5
+
+// DefaultBreakerCooldown is how long the breaker stays open when
+// BreakerCooldown is zero.
+const DefaultBreakerCooldown = 30 * time.Second
+
+// circuitBreaker opens after threshold consecutive failures of certMgr,
+// connection failures and server errors. Once
+// the cooldown passed it is half-open: a single request is let through to
+// probe whether certMgr recovered, closing the breaker on success and
+// re-opening it on failure, while the others keep failing fast.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+	lastErr   error
+}
+
+// allow returns ErrUnreachable while the breaker is open or another request
+// probes it. probe reports whether the request is let through as the probe,
+// which must be passed on to record.
+func (b *circuitBreaker) allow(threshold int, now time.Time) (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if threshold <= 0 || b.failures < threshold {
+		return false, nil
+	}
+	if !now.Before(b.openUntil) && !b.probing {
+		b.probing = true
+		return true, nil
+	}
+	return false, fmt.Errorf("%w after %d consecutive failures, last: %w", ErrUnreachable, b.failures, b.lastErr)
+}
+
+// record updates the breaker with the outcome of a request, err being nil
+// for a success and a failure as classified by breakerFailure otherwise. A
+// probe abandoned by its caller lets the next request probe.
+func (b *circuitBreaker) record(ctx context.Context, threshold int, cooldown time.Duration, probe bool, err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err == nil {
+		b.failures = 0
+		b.lastErr = nil
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+	if threshold > 0 && b.failures >= threshold {
+		if cooldown <= 0 {
+			cooldown = DefaultBreakerCooldown
+		}
+		b.openUntil = now.Add(cooldown)
+	}
+}
+
+// release lets the next request probe after a probe whose outcome says
+// nothing about certMgr.
+func (b *circuitBreaker) release(probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+// breakerFailure classifies the outcome of http.Client.Do for the breaker.
+// counted is false for errors raised on the client's side, such as a
+// Kerberos ticket that cannot be obtained or a TLS configuration or
+// certificate certMgr and the client disagree on: certMgr is not failing,
+// and opening the breaker would fail every other resource too. Otherwise
+// failure is the transport failure or server error (5xx) the request ended
+// with, or nil when certMgr answered.
+func breakerFailure(method, url string, resp *http.Response, err error) (failure error, counted bool) {
+	if err == nil {
+		if resp.StatusCode >= 500 {
+			return newStatusError(method, url, resp.StatusCode, nil), true
+		}
+		return nil, true
+	}
+
+	var alert tls.AlertError
+	var verification *tls.CertificateVerificationError
+	var record tls.RecordHeaderError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	if errors.As(err, &alert) || errors.As(err, &verification) || errors.As(err, &record) ||
+		errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid) {
+		return nil, false
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if isTimeout(err) || errors.As(err, &opErr) || errors.As(err, &dnsErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return err, true
+	}
+	return nil, false
+}
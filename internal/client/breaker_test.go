@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	cli := newTestClient(t, http.NotFoundHandler())
+	cli.BreakerThreshold = 2
+
+	// Point the client at a port nobody listens on.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cli.Port = listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	for range 2 {
+		_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, certMgr.ErrUnreachable)
+	}
+
+	_, err = cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.ErrorIs(t, err, certMgr.ErrUnreachable)
+}
+
+func TestCircuitBreakerCountsServerErrors(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	cli.BreakerThreshold = 2
+
+	for range 2 {
+		_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, certMgr.ErrUnreachable)
+	}
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.ErrorIs(t, err, certMgr.ErrUnreachable)
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	cli.BreakerThreshold = 1
+	// A client that does not trust the server's certificate fails every
+	// handshake on its own side.
+	trusting := cli.HTTPClient
+	cli.HTTPClient = spnego.NewClient(nil, &http.Client{}, "")
+
+	for range 3 {
+		_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+		require.ErrorContains(t, err, "certificate")
+		require.NotErrorIs(t, err, certMgr.ErrUnreachable)
+	}
+
+	// Neither do responses certMgr gave deliberately.
+	cli.HTTPClient = trusting
+	for range 3 {
+		_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+		require.NotErrorIs(t, err, certMgr.ErrUnreachable)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	var failing atomic.Bool
+	var served atomic.Int32
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			// Drop the connection, which the client sees as a connection
+			// failure rather than a response.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+			return
+		}
+		served.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{"total": 1}`)
+	}))
+	cli.BreakerThreshold = 1
+	cli.BreakerCooldown = 100 * time.Millisecond
+	ctx := context.Background()
+	stats := func() error {
+		_, err := cli.GetStatistics(ctx, certMgr.StatisticsFilter{})
+		return err
+	}
+
+	failing.Store(true)
+	require.NotErrorIs(t, stats(), certMgr.ErrUnreachable)
+	require.ErrorIs(t, stats(), certMgr.ErrUnreachable)
+
+	// After the cooldown, only one of the concurrent callers probes certMgr.
+	time.Sleep(150 * time.Millisecond)
+	failing.Store(false)
+	errs := make([]error, 5)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = stats()
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), served.Load())
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, certMgr.ErrUnreachable)
+	}
+	require.Equal(t, 1, succeeded)
+
+	// The successful probe closed the breaker.
+	require.NoError(t, stats())
+
+	// A failed probe opens it again.
+	failing.Store(true)
+	require.NotErrorIs(t, stats(), certMgr.ErrUnreachable)
+	time.Sleep(150 * time.Millisecond)
+	require.NotErrorIs(t, stats(), certMgr.ErrUnreachable)
+	require.ErrorIs(t, stats(), certMgr.ErrUnreachable)
+}
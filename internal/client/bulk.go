@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// CreateCertificates stages requests for several hostnames with a single bulk
+// PATCH and returns the newly created entries. Entries that do not show up
+// right away are awaited like asynchronously accepted POSTs; the hostnames
+// of those that never do are named in the error.
+func (c *Client) CreateCertificates(ctx context.Context, hostnames []string) ([]Certificate, error) {
+	if len(hostnames) == 0 {
+		return nil, nil
+	}
+
+	normalized := append([]string(nil), hostnames...)
+	if err := normalizeHostnames(normalized); err != nil {
+		return nil, err
+	}
+	defer c.certificates.invalidateAll()
+
+	objects := make([]CertificateRequest, 0, len(normalized))
+	for _, hostname := range normalized {
+		objects = append(objects, CertificateRequest{Hostname: hostname})
+	}
+	before, staged, err := c.stageBulk(ctx, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string][]Certificate)
+	for _, cert := range before {
+		existing[cert.Hostname] = append(existing[cert.Hostname], cert)
+	}
+
+	errs := make([]error, len(staged))
+	var wg sync.WaitGroup
+	for i, cert := range staged {
+		if cert != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			staged[i], errs[i] = c.awaitStaged(ctx, normalized[i], existing[normalized[i]])
+		}()
+	}
+	wg.Wait()
+
+	var missing []string
+	created := make([]Certificate, 0, len(staged))
+	for i, cert := range staged {
+		if errs[i] != nil {
+			missing = append(missing, normalized[i])
+			continue
+		}
+		created = append(created, *cert)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no staged entry appeared for %s: %w", strings.Join(missing, ", "), errors.Join(errs...))
+	}
+	return created, nil
+}
+
+// stageBulk stages requests, whose hostnames are normalized, with a single
+// bulk PATCH. It returns the staged entries of their hostnames from before
+// the call and the entry created for each request, or nil where none has
+// shown up yet.
+//
+// certMgr instances whose staged resource sets always_return_data answer
+// with the created objects. Others return no body, and the new entries are
+// told apart by listing the hostnames again: each request is handed the
+// newest entry that was not there before. An entry another client stages for
+// the same hostname in the meantime cannot be told apart from the one the
+// request created, and may be handed out in its place.
+func (c *Client) stageBulk(ctx context.Context, requests []CertificateRequest) (before []Certificate, created []*Certificate, err error) {
+	hostnames := make([]string, 0, len(requests))
+	for _, request := range requests {
+		hostnames = append(hostnames, request.Hostname)
+	}
+
+	before, err = c.ListStagedForHostnames(ctx, hostnames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload, err := json.Marshal(map[string]any{"objects": requests})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal failed: %w", err)
+	}
+
+	url := c.endpoint("staged/")
+	body, _, err := c.doRequest(withCreatesEntries(ctx), http.MethodPatch, url, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created = make([]*Certificate, len(requests))
+	if len(bytes.TrimSpace(body)) > 0 {
+		var response struct {
+			Objects []Certificate `json:"objects"`
+		}
+		if err := c.decodeJSON(body, &response); err != nil {
+			return nil, nil, err
+		}
+		if len(response.Objects) == len(requests) {
+			for i := range response.Objects {
+				created[i] = &response.Objects[i]
+			}
+			return before, created, nil
+		}
+	}
+
+	after, err := c.ListStagedForHostnames(ctx, hostnames)
+	if err != nil {
+		return nil, nil, err
+	}
+	known := make(map[int]bool, len(before))
+	for _, cert := range before {
+		known[cert.ID] = true
+	}
+	// after lists the entries of a hostname oldest first, so the newest ones
+	// are handed out first.
+	fresh := make(map[string][]Certificate)
+	for _, cert := range after {
+		if !known[cert.ID] {
+			fresh[cert.Hostname] = append(fresh[cert.Hostname], cert)
+		}
+	}
+	for i := len(requests) - 1; i >= 0; i-- {
+		candidates := fresh[requests[i].Hostname]
+		if len(candidates) == 0 {
+			continue
+		}
+		created[i] = &candidates[len(candidates)-1]
+		fresh[requests[i].Hostname] = candidates[:len(candidates)-1]
+	}
+	return before, created, nil
+}
+
+type createsEntriesKey struct{}
+
+// withCreatesEntries marks the requests made with ctx as creating entries,
+// so that they are not retried like other writes: certMgr may have applied
+// a request whose response timed out.
+func withCreatesEntries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, createsEntriesKey{}, true)
+}
+
+// createsEntries reports whether ctx was marked by withCreatesEntries.
+func createsEntries(ctx context.Context) bool {
+	creates, _ := ctx.Value(createsEntriesKey{}).(bool)
+	return creates
+}
+
+// ListStagedForHostnames returns the staged entries of several hostnames with
+// a single request.
+func (c *Client) ListStagedForHostnames(ctx context.Context, hostnames []string) ([]Certificate, error) {
+	if len(hostnames) == 0 {
+		return nil, nil
+	}
+
+	normalized := append([]string(nil), hostnames...)
+	if err := normalizeHostnames(normalized); err != nil {
+		return nil, err
+	}
+
+	return c.listStaged(ctx, url.Values{"hostname__in": {strings.Join(normalized, ",")}})
+}
+
+// DeleteStagedEntries removes several staged entries with a single bulk
+// PATCH. Entries already deleted outside of Terraform, for example in the
+// certMgr UI, fail the bulk request as a whole, and servers without bulk
+// operations on the staged endpoint refuse it; the entries are then deleted
+// one by one, ignoring those that are gone.
+func (c *Client) DeleteStagedEntries(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := c.deleteStagedBulk(ctx, ids)
+	var statusErr *StatusError
+	if errors.Is(err, ErrNotFound) || errors.As(err, &statusErr) &&
+		(statusErr.StatusCode == http.StatusMethodNotAllowed || statusErr.StatusCode == http.StatusNotImplemented) {
+		return c.deleteStagedParallel(ctx, ids)
+	}
+	return err
+}
+
+// deleteStagedBulk removes the staged entries with a single bulk PATCH.
+func (c *Client) deleteStagedBulk(ctx context.Context, ids []int) error {
+	defer c.certificates.invalidateAll()
+
+	uris := make([]string, 0, len(ids))
+	for _, id := range ids {
+		uris = append(uris, fmt.Sprintf("%sstaged/%d/", c.apiRoot(), id))
+	}
+	payload, err := json.Marshal(map[string]any{
+		"objects":         []any{},
+		"deleted_objects": uris,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+
+	url := c.endpoint("staged/")
+	if _, _, err := c.doRequest(ctx, http.MethodPatch, url, payload); err != nil {
+		return fmt.Errorf("bulk delete failed: %w", err)
+	}
+	return nil
+}
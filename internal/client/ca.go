@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// CertificateAuthority is a root or intermediate CA known to certMgr.
+type CertificateAuthority struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	PEM  string `json:"pem"`
+}
+
+var ErrNoCertificateAuthority = errors.New("no certificate authority found")
+
+// authorityCache remembers certificate authorities by organization and name
+// for the lifetime of the client, one Terraform operation, so that the
+// resources sharing a CA fetch it once. Concurrent lookups of the same CA
+// share one request.
+type authorityCache struct {
+	mu     sync.Mutex
+	byName map[string]*authorityLookup
+}
+
+type authorityLookup struct {
+	done      chan struct{}
+	authority CertificateAuthority
+	err       error
+}
+
+// GetCertificateAuthority returns the CA called name. Successful lookups are
+// cached; failed ones are retried by the next call.
+func (c *Client) GetCertificateAuthority(ctx context.Context, name string) (*CertificateAuthority, error) {
+	key := c.organization(ctx) + "/" + name
+	cache := &c.authorities
+	cache.mu.Lock()
+	lookup, ok := cache.byName[key]
+	if !ok {
+		lookup = &authorityLookup{done: make(chan struct{})}
+		if cache.byName == nil {
+			cache.byName = map[string]*authorityLookup{}
+		}
+		cache.byName[key] = lookup
+	}
+	cache.mu.Unlock()
+
+	if !ok {
+		authority, err := c.fetchCertificateAuthority(ctx, name)
+		if err != nil {
+			cache.mu.Lock()
+			delete(cache.byName, key)
+			cache.mu.Unlock()
+			lookup.err = err
+		} else {
+			lookup.authority = *authority
+		}
+		close(lookup.done)
+	}
+
+	select {
+	case <-lookup.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if lookup.err != nil {
+		return nil, lookup.err
+	}
+	authority := lookup.authority
+	return &authority, nil
+}
+
+func (c *Client) fetchCertificateAuthority(ctx context.Context, name string) (*CertificateAuthority, error) {
+	endpoint := c.queryEndpoint("ca/", url.Values{"name": {name}})
+	authorities, err := listAll[CertificateAuthority](ctx, c, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing certificate authorities: %w", err)
+	}
+
+	if len(authorities) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoCertificateAuthority, name)
+	}
+	return &authorities[0], nil
+}
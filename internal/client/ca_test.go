@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCertificateAuthorityCache(t *testing.T) {
+	var requests atomic.Int32
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		name := r.URL.Query().Get("name")
+		if name != "CERN Root Certification Authority 2" {
+			fmt.Fprint(w, `{"objects": []}`)
+			return
+		}
+		fmt.Fprintf(w, `{"objects": [{"name": %q, "kind": "root", "pem": "root"}]}`, name)
+	}))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			authority, err := cli.GetCertificateAuthority(ctx, "CERN Root Certification Authority 2")
+			require.NoError(t, err)
+			require.Equal(t, "root", authority.PEM)
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, requests.Load())
+
+	// Failed lookups are not cached.
+	for range 2 {
+		_, err := cli.GetCertificateAuthority(ctx, "unknown")
+		require.ErrorIs(t, err, certMgr.ErrNoCertificateAuthority)
+	}
+	require.EqualValues(t, 3, requests.Load())
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import "sync"
+
+// certificateCache remembers certificate reads for the lifetime of the
+// client, which is one Terraform operation, so that the same entry is not
+// fetched repeatedly. Writes invalidate the entries of the affected hostname.
+type certificateCache struct {
+	mu         sync.Mutex
+	byHostname map[string]Certificate
+	byID       map[int]Certificate
+}
+
+func (c *certificateCache) latest(hostname string) (*Certificate, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cert, ok := c.byHostname[hostname]
+	return &cert, ok
+}
+
+func (c *certificateCache) entry(id int) (*Certificate, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cert, ok := c.byID[id]
+	return &cert, ok
+}
+
+// storeLatest records cert as the newest entry of its hostname.
+func (c *certificateCache) storeLatest(cert Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byHostname == nil {
+		c.byHostname = map[string]Certificate{}
+	}
+	c.byHostname[cert.Hostname] = cert
+	c.storeEntryLocked(cert)
+}
+
+func (c *certificateCache) storeEntry(cert Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.storeEntryLocked(cert)
+}
+
+func (c *certificateCache) storeEntryLocked(cert Certificate) {
+	if c.byID == nil {
+		c.byID = map[int]Certificate{}
+	}
+	c.byID[cert.ID] = cert
+}
+
+// invalidate forgets every entry of hostname.
+func (c *certificateCache) invalidate(hostname string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.byHostname, hostname)
+	for id, cert := range c.byID {
+		if cert.Hostname == hostname {
+			delete(c.byID, id)
+		}
+	}
+}
+
+// invalidateAll forgets everything, for writes whose hostname is unknown.
+func (c *certificateCache) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byHostname = nil
+	c.byID = nil
+}
@@ -5,100 +5,522 @@
 package certMgr
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 type Certificate struct {
-	ID        int    `json:"id"`
-	Hostname  string `json:"hostname"`
-	Requestor string `json:"requestor"`
-	Start     string `json:"start"`
-	End       string `json:"end"`
+	ID             int               `json:"id"`
+	Hostname       string            `json:"hostname"`
+	Requestor      string            `json:"requestor"`
+	Start          string            `json:"start"`
+	End            string            `json:"end"`
+	Serial         string            `json:"serial,omitempty"`
+	CertificatePEM string            `json:"certificate,omitempty"`
+	PrivateKeyPEM  string            `json:"private_key,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	// ETag is the entity tag certMgr sent with the entry, when it was read
+	// from the detail endpoint. It changes whenever the entry does.
+	ETag string `json:"-"`
 }
 
-var ErrNoCertificates = errors.New("no certificates found")
+// CertificateRequest describes a new staged request. When CSR is empty,
+// certMgr generates the key pair itself.
+type CertificateRequest struct {
+	Hostname string `json:"hostname"`
+	CSR      string `json:"csr,omitempty"`
+}
+
+// CertificateUpdate describes a change to an existing certificate. Only the
+// mutable fields that are set (non-nil) are sent to certMgr.
+type CertificateUpdate struct {
+	ID        int                `json:"id"`
+	Hostname  string             `json:"hostname"`
+	Requestor *string            `json:"requestor,omitempty"`
+	Tags      *map[string]string `json:"tags,omitempty"`
+}
+
+var (
+	ErrNoCertificates = errors.New("no certificates found")
+	ErrNotIssued      = errors.New("certificate not issued")
+)
 
-func (c *Client) CreateCertificate(hostname string) (*Certificate, error) {
-	url := fmt.Sprintf("https://%s:%d/krb/certmgr/staged/", c.Host, c.Port)
-	payload, _ := json.Marshal(map[string]string{"hostname": hostname})
+// DefaultCreateTimeout is the CreateTimeout used when none is set.
+const DefaultCreateTimeout = 5 * time.Minute
 
-	body, _, err := c.doRequest(http.MethodPost, url, payload)
+// maxCreatePollInterval caps the backoff between listings while waiting for
+// an asynchronously created entry.
+const maxCreatePollInterval = 5 * time.Second
+
+// CreateCertificate stages a new request. Busy certMgr instances accept the
+// request asynchronously, answering 202 without the new entry, which then
+// only shows up in the list of staged entries; CreateCertificate waits for
+// it for up to CreateTimeout. With CreateBatchWindow set, concurrent calls
+// are coalesced into bulk requests.
+func (c *Client) CreateCertificate(ctx context.Context, request CertificateRequest) (*Certificate, error) {
+	hostname, err := NormalizeHostname(request.Hostname)
 	if err != nil {
 		return nil, err
 	}
+	request.Hostname = hostname
+	if c.CreateBatchWindow > 0 {
+		return c.createBatched(ctx, request)
+	}
+	return c.createStaged(ctx, request)
+}
 
-	var cert Certificate
-	if err := json.Unmarshal(body, &cert); err != nil {
-		return nil, fmt.Errorf("unmarshal failed: %w", err)
+// createStaged stages request, whose hostname is normalized, with a POST of
+// its own.
+func (c *Client) createStaged(ctx context.Context, request CertificateRequest) (*Certificate, error) {
+	hostname := request.Hostname
+	defer c.certificates.invalidate(hostname)
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshal failed: %w", err)
 	}
-	return &cert, nil
+	body, status, header, err := c.doRequestHeader(ctx, http.MethodPost, c.endpoint("staged/"), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var created Certificate
+	if status != http.StatusAccepted && len(bytes.TrimSpace(body)) > 0 {
+		if err := c.decodeJSON(body, &created); err != nil {
+			return nil, err
+		}
+	}
+	if created.ID != 0 {
+		return &created, nil
+	}
+
+	// Without an ID, the new entry must be told apart from those staged
+	// before, for example the entry a create_before_destroy replacement is
+	// about to supersede. Entries that started well before certMgr accepted
+	// the request, by its own clock, are not it.
+	accepted, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		accepted = time.Now()
+	}
+	staged, err := c.ListStaged(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing staged events: %w", err)
+	}
+	existing := make([]Certificate, 0, len(staged))
+	for _, cert := range staged {
+		if start, err := ParseTimestamp(cert.Start); err == nil && start.Before(accepted.Add(-acceptedStartSkew)) {
+			existing = append(existing, cert)
+		}
+	}
+	return c.awaitStaged(ctx, hostname, existing)
 }
 
-func (c *Client) GetCertificate(hostname string) (*Certificate, error) {
-	url := fmt.Sprintf("https://%s:%d/krb/certmgr/staged/?hostname=%s", c.Host, c.Port, hostname)
-	body, _, err := c.doRequest(http.MethodGet, url, nil)
+// acceptedStartSkew is how long before certMgr accepted a request the start
+// of its entry may lie, allowing for certificates whose validity is
+// backdated. Entries without a start, not signed yet, always count as new.
+const acceptedStartSkew = 5 * time.Minute
+
+// awaitStaged lists the staged entries of hostname until one that is not in
+// existing shows up, and returns the newest such entry.
+func (c *Client) awaitStaged(ctx context.Context, hostname string, existing []Certificate) (*Certificate, error) {
+	known := make(map[int]bool, len(existing))
+	for _, cert := range existing {
+		known[cert.ID] = true
+	}
+
+	timeout := c.CreateTimeout
+	if timeout <= 0 {
+		timeout = DefaultCreateTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := 250 * time.Millisecond
+	for {
+		staged, err := c.ListStaged(ctx, hostname)
+		if err != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("failed listing staged events: %w", err)
+		}
+
+		var created *Certificate
+		for i, cert := range staged {
+			if !known[cert.ID] && (created == nil || cert.ID > created.ID) {
+				created = &staged[i]
+			}
+		}
+		if created != nil {
+			return created, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("certMgr accepted the request for %s, but no new staged entry appeared within %s: %w", hostname, timeout, ctx.Err())
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, maxCreatePollInterval)
+	}
+}
+
+// ListStaged returns every staged entry for hostname in the order certMgr
+// reports them, oldest first. Entries of other hostnames that certMgr may
+// return for a prefix or substring match are dropped.
+func (c *Client) ListStaged(ctx context.Context, hostname string) ([]Certificate, error) {
+	hostname, err := NormalizeHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := c.listStaged(ctx, url.Values{"hostname": {hostname}})
 	if err != nil {
 		return nil, err
 	}
 
-	type stagedResponse struct {
-		Meta    map[string]interface{} `json:"meta"`
-		Objects []Certificate          `json:"objects"`
+	exact := staged[:0]
+	for _, cert := range staged {
+		if strings.EqualFold(strings.TrimSuffix(cert.Hostname, "."), hostname) {
+			exact = append(exact, cert)
+		}
+	}
+	return exact, nil
+}
+
+// CertificateFilter selects staged entries server-side. Zero fields do not
+// restrict the result.
+type CertificateFilter struct {
+	HostnamePrefix string
+	Requestor      string
+	// Tags lists tags an entry must all carry.
+	Tags map[string]string
+	// StartAfter and StartBefore bound the start of validity, inclusively.
+	StartAfter  time.Time
+	StartBefore time.Time
+}
+
+func (f CertificateFilter) query() url.Values {
+	query := url.Values{}
+	if f.HostnamePrefix != "" {
+		query.Set("hostname__startswith", strings.ToLower(f.HostnamePrefix))
+	}
+	if f.Requestor != "" {
+		query.Set("requestor", f.Requestor)
+	}
+	for key, value := range f.Tags {
+		query.Set("tags__"+key, value)
+	}
+	if !f.StartAfter.IsZero() {
+		query.Set("start__gte", f.StartAfter.UTC().Format(time.RFC3339))
+	}
+	if !f.StartBefore.IsZero() {
+		query.Set("start__lte", f.StartBefore.UTC().Format(time.RFC3339))
+	}
+	return query
+}
+
+// ListCertificates returns every staged entry matching filter.
+func (c *Client) ListCertificates(ctx context.Context, filter CertificateFilter) ([]Certificate, error) {
+	return c.listStaged(ctx, filter.query())
+}
+
+// ListStagedByRequestor returns every staged entry requested by requestor.
+func (c *Client) ListStagedByRequestor(ctx context.Context, requestor string) ([]Certificate, error) {
+	return c.ListCertificates(ctx, CertificateFilter{Requestor: requestor})
+}
+
+// ListStagedByTags returns every staged entry carrying all of the given tags.
+func (c *Client) ListStagedByTags(ctx context.Context, selector map[string]string) ([]Certificate, error) {
+	return c.ListCertificates(ctx, CertificateFilter{Tags: selector})
+}
+
+func (c *Client) listStaged(ctx context.Context, query url.Values) ([]Certificate, error) {
+	staged, err := listAll[Certificate](ctx, c, c.queryEndpoint("staged/", query))
+	if err != nil {
+		return nil, fmt.Errorf("failed listing staged certs: %w", err)
+	}
+	return staged, nil
+}
+
+// GetCertificate returns the most recent staged entry for hostname. Results
+// are cached until the next write for hostname through this client, unless
+// ctx scopes the read to another organization than the client's.
+func (c *Client) GetCertificate(ctx context.Context, hostname string) (*Certificate, error) {
+	hostname, err := NormalizeHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+	cache := c.ownOrganization(ctx)
+	if cached, ok := c.certificates.latest(hostname); ok && cache {
+		return cached, nil
 	}
 
-	var staged stagedResponse
-	if err := json.Unmarshal(body, &staged); err != nil {
-		return nil, fmt.Errorf("failed unmarshaling staged certs: %w", err)
+	staged, err := c.ListStaged(ctx, hostname)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(staged.Objects) == 0 {
+	if len(staged) == 0 {
 		return nil, ErrNoCertificates
 	}
 
-	latestCert := staged.Objects[len(staged.Objects)-1]
+	latestCert := newest(staged)
+	if cache {
+		c.certificates.storeLatest(latestCert)
+	}
 
 	return &latestCert, nil
 }
 
-func (c *Client) UpdateCertificate(cert Certificate) error {
-	data, err := json.Marshal(cert)
+// newest returns the entry with the latest start. Entries with unparsable
+// starts are only chosen when no start parses, in which case the last listed
+// entry is returned.
+func newest(staged []Certificate) Certificate {
+	latest := staged[len(staged)-1]
+	latestStart, latestErr := ParseTimestamp(latest.Start)
+	for _, cert := range staged[:len(staged)-1] {
+		start, err := ParseTimestamp(cert.Start)
+		if err == nil && (latestErr != nil || start.After(latestStart)) {
+			latest, latestStart, latestErr = cert, start, nil
+		}
+	}
+	return latest
+}
+
+// GetStaged returns the staged entry with the given ID for hostname, so that
+// callers owning a specific entry are not confused by newer ones staged for
+// the same host.
+func (c *Client) GetStaged(ctx context.Context, hostname string, id int) (*Certificate, error) {
+	staged, err := c.ListStaged(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cert := range staged {
+		if cert.ID == id {
+			return &cert, nil
+		}
+	}
+	return nil, ErrNoCertificates
+}
+
+// GetCertificateByID returns the staged entry with the given ID from the
+// detail endpoint, without knowing its hostname. Results are cached like
+// those of GetCertificate.
+func (c *Client) GetCertificateByID(ctx context.Context, id int) (*Certificate, error) {
+	cache := c.ownOrganization(ctx)
+	if cached, ok := c.certificates.entry(id); ok && cache {
+		return cached, nil
+	}
+
+	body, _, header, err := c.doRequestHeader(ctx, http.MethodGet, c.endpoint("staged/%d/", id), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNoCertificates
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cert := &Certificate{}
+	if err := c.decodeJSON(body, cert); err != nil {
+		return nil, err
+	}
+	// The ETag is taken from the response rather than the validatorCache,
+	// whose entry is keyed by the negotiated URL.
+	cert.ETag = header.Get("ETag")
+	if cache {
+		c.certificates.storeEntry(*cert)
+	}
+	return cert, nil
+}
+
+// CertificateUnchanged reports whether the staged entry id still carries etag,
+// the ETag of an earlier GetCertificateByID, with a conditional HEAD request
+// that transfers no body. It returns ErrNoCertificates when the entry was
+// deleted, and false without asking when etag is empty or certMgr does not
+// answer HEAD requests, in which case the entry must be read in full.
+func (c *Client) CertificateUnchanged(ctx context.Context, id int, etag string) (bool, error) {
+	if etag == "" || c.noHead.Load() {
+		return false, nil
+	}
+
+	url := c.endpoint("staged/%d/", id)
+	ctx = withIfNoneMatch(ctx, etag)
+	_, status, err := c.doRequest(ctx, http.MethodHead, url, nil)
+	switch {
+	case status == http.StatusNotModified:
+		return true, nil
+	case status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented:
+		c.noHead.Store(true)
+		return false, nil
+	case errors.Is(err, ErrNotFound):
+		return false, ErrNoCertificates
+	case err != nil:
+		return false, err
+	}
+	return false, nil
+}
+
+// GetCertificateBySerial returns the staged entry whose certificate has the
+// given serial number. Serials are compared case-insensitively, so that a
+// server ignoring or loosely matching the filter never yields another
+// certificate.
+func (c *Client) GetCertificateBySerial(ctx context.Context, serial string) (*Certificate, error) {
+	staged, err := c.listStaged(ctx, url.Values{"serial": {serial}})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range staged {
+		if strings.EqualFold(staged[i].Serial, serial) {
+			return &staged[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w with serial %s: %w", ErrNoCertificates, serial, ErrNotFound)
+}
+
+// GetIssuedCertificate returns a certificate certMgr has signed for hostname,
+// as opposed to a staged request that may still be pending. With a serial,
+// that certificate is returned; without, the newest one. ErrNotIssued is
+// returned when nothing matching has been issued yet.
+func (c *Client) GetIssuedCertificate(ctx context.Context, hostname, serial string) (*Certificate, error) {
+	hostname, err := NormalizeHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"hostname": {hostname}}
+	if serial != "" {
+		query.Set("serial", serial)
+	}
+	issued, err := listAll[Certificate](ctx, c, c.queryEndpoint("certificate/", query))
+	if err != nil {
+		return nil, fmt.Errorf("failed listing issued certs: %w", err)
+	}
+
+	exact := issued[:0]
+	for _, cert := range issued {
+		if strings.EqualFold(strings.TrimSuffix(cert.Hostname, "."), hostname) &&
+			(serial == "" || strings.EqualFold(cert.Serial, serial)) {
+			exact = append(exact, cert)
+		}
+	}
+	if len(exact) == 0 {
+		return nil, ErrNotIssued
+	}
+
+	latest := newest(exact)
+	return &latest, nil
+}
+
+func (c *Client) UpdateCertificate(ctx context.Context, update CertificateUpdate) error {
+	hostname, err := NormalizeHostname(update.Hostname)
+	if err != nil {
+		return err
+	}
+	update.Hostname = hostname
+	defer c.certificates.invalidate(hostname)
+
+	data, err := json.Marshal(update)
 	if err != nil {
 		return fmt.Errorf("marshal failed: %w", err)
 	}
 
-	url := fmt.Sprintf("https://%s:%d/krb/certmgr/certificate/", c.Host, c.Port)
-	if _, _, err := c.doRequest(http.MethodPost, url, data); err != nil {
+	url := c.endpoint("certificate/")
+	if _, _, err := c.doRequest(ctx, http.MethodPost, url, data); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *Client) DeleteCertificate(hostname string) error {
-	urlList := fmt.Sprintf("https://%s:%d/krb/certmgr/staged/?hostname=%s", c.Host, c.Port, hostname)
-	body, _, err := c.doRequest(http.MethodGet, urlList, nil)
+// RenewCertificate asks certMgr to renew the staged entry with the given ID
+// and returns the entry of the renewed certificate, which carries the new
+// serial and validity.
+func (c *Client) RenewCertificate(ctx context.Context, id int) (*Certificate, error) {
+	defer c.certificates.invalidateAll()
+
+	renewed, err := createObject[Certificate](ctx, c, fmt.Sprintf("staged/%d/renew/", id), struct{}{})
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNoCertificates
+	}
+	if err != nil {
+		return nil, fmt.Errorf("renewal failed for event %d: %w", id, err)
+	}
+	return renewed, nil
+}
+
+// DeleteCertificate removes every staged entry for hostname.
+func (c *Client) DeleteCertificate(ctx context.Context, hostname string) error {
+	if normalized, err := NormalizeHostname(hostname); err == nil {
+		defer c.certificates.invalidate(normalized)
+	}
+
+	staged, err := c.ListStaged(ctx, hostname)
 	if err != nil {
 		return fmt.Errorf("failed listing staged events: %w", err)
 	}
 
-	var events struct {
-		Objects []struct {
-			ID int `json:"id"`
-		} `json:"objects"`
+	ids := make([]int, 0, len(staged))
+	for _, event := range staged {
+		ids = append(ids, event.ID)
+	}
+	if len(ids) <= 1 {
+		return c.deleteStagedParallel(ctx, ids)
+	}
+	return c.DeleteStagedEntries(ctx, ids)
+}
+
+// deleteConcurrency bounds the number of DELETE requests in flight when
+// removing staged entries one by one.
+const deleteConcurrency = 8
+
+// deleteStagedParallel removes the staged entries with a bounded number of
+// concurrent requests and returns the errors of all failed deletes. Entries
+// that are already gone are not an error.
+func (c *Client) deleteStagedParallel(ctx context.Context, ids []int) error {
+	work := make(chan int)
+	errs := make(chan error, len(ids))
+
+	var wg sync.WaitGroup
+	for range min(deleteConcurrency, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range work {
+				errs <- c.DeleteStaged(ctx, id)
+			}
+		}()
 	}
 
-	if err := json.Unmarshal(body, &events); err != nil {
-		return fmt.Errorf("json parse error: %w", err)
+	for _, id := range ids {
+		work <- id
 	}
+	close(work)
+	wg.Wait()
+	close(errs)
 
-	for _, event := range events.Objects {
-		urlDel := fmt.Sprintf("https://%s:%d/krb/certmgr/staged/%d/", c.Host, c.Port, event.ID)
-		if _, _, err := c.doRequest(http.MethodDelete, urlDel, nil); err != nil {
-			return fmt.Errorf("delete failed for event %d: %w", event.ID, err)
+	var all []error
+	for err := range errs {
+		if !errors.Is(err, ErrNotFound) {
+			all = append(all, err)
 		}
 	}
+	return errors.Join(all...)
+}
+
+// DeleteStaged removes a single staged entry by ID.
+func (c *Client) DeleteStaged(ctx context.Context, id int) error {
+	defer c.certificates.invalidateAll()
+
+	url := c.endpoint("staged/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for event %d: %w", id, err)
+	}
 	return nil
 }
@@ -5,50 +5,316 @@
 package certMgr_test
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
 
 	"github.com/stretchr/testify/require"
 )
 
 func TestCertificateCRUD(t *testing.T) {
-	host := "hector.cern.ch"
-	port := 8008
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
 
-	cli, err := certMgr.NewClient(host, port)
-	require.NoError(t, err)
-
-	timestamp := fmt.Sprintf("%d", time.Now().UnixNano())
-	last5 := timestamp[len(timestamp)-5:]
-
-	hostname := fmt.Sprintf("tf-test-cert-%s.cern.ch", last5)
+	hostname := "tf-test-cert.cern.ch"
 
 	t.Logf("Creating certificate for hostname: %s", hostname)
-	createdCert, err := cli.CreateCertificate(hostname)
+	createdCert, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: hostname})
 	require.NoError(t, err)
 	require.Equal(t, hostname, createdCert.Hostname)
 
 	t.Log("Reading certificate...")
-	readCert, err := cli.GetCertificate(hostname)
+	readCert, err := cli.GetCertificate(ctx, hostname)
 	require.NoError(t, err)
 	require.Equal(t, createdCert.Hostname, readCert.Hostname)
 
-	defer func() {
-		t.Logf("Deleting certificate for hostname: %s", hostname)
-		err := cli.DeleteCertificate(hostname)
-		require.NoError(t, err)
-	}()
-
 	t.Log("Updating certificate...")
-	readCert.Requestor = "terraform-test"
-	err = cli.UpdateCertificate(*readCert)
+	requestor := "terraform-test"
+	err = cli.UpdateCertificate(ctx, certMgr.CertificateUpdate{
+		ID:        readCert.ID,
+		Hostname:  readCert.Hostname,
+		Requestor: &requestor,
+	})
 	require.NoError(t, err)
 
 	t.Log("Final read to confirm update...")
-	finalCert, err := cli.GetCertificate(hostname)
+	finalCert, err := cli.GetCertificate(ctx, hostname)
 	require.NoError(t, err)
 	require.Equal(t, "terraform-test", finalCert.Requestor)
+
+	t.Logf("Deleting certificate for hostname: %s", hostname)
+	require.NoError(t, cli.DeleteCertificate(ctx, hostname))
+	require.Empty(t, server.Certificates())
+}
+
+func TestGetCertificateByID(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/krb/certmgr/staged/42/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"id": 42, "hostname": "tf-test.cern.ch"}`)
+	}))
+
+	cert, err := cli.GetCertificateByID(context.Background(), 42)
+	require.NoError(t, err)
+	require.Equal(t, "tf-test.cern.ch", cert.Hostname)
+
+	_, err = cli.GetCertificateByID(context.Background(), 7)
+	require.ErrorIs(t, err, certMgr.ErrNoCertificates)
+}
+
+func TestDeleteCertificateFallsBackToParallelDeletes(t *testing.T) {
+	var mu sync.Mutex
+	deleted := map[string]bool{}
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"meta": {}, "objects": [
+				{"id": 1, "hostname": "tf-test.cern.ch"},
+				{"id": 2, "hostname": "tf-test.cern.ch"},
+				{"id": 3, "hostname": "tf-test.cern.ch"}]}`)
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case http.MethodDelete:
+			mu.Lock()
+			deleted[r.URL.Path] = true
+			mu.Unlock()
+			if r.URL.Path == "/krb/certmgr/staged/2/" {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	}))
+
+	err := cli.DeleteCertificate(context.Background(), "tf-test.cern.ch")
+	require.ErrorContains(t, err, "delete failed for event 2")
+	require.Len(t, deleted, 3)
+}
+
+func TestDeleteStagedEntriesFallsBack(t *testing.T) {
+	for name, bulkStatus := range map[string]int{
+		"missing entry":   http.StatusNotFound,
+		"no bulk support": http.StatusMethodNotAllowed,
+		"not implemented": http.StatusNotImplemented,
+		"server error":    http.StatusInternalServerError,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var deleted []string
+			cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPatch:
+					w.WriteHeader(bulkStatus)
+				case http.MethodDelete:
+					if r.URL.Path == "/krb/certmgr/staged/2/" {
+						http.NotFound(w, r)
+						return
+					}
+					mu.Lock()
+					deleted = append(deleted, r.URL.Path)
+					mu.Unlock()
+				}
+			}))
+
+			err := cli.DeleteStagedEntries(context.Background(), []int{1, 2, 3})
+			if bulkStatus == http.StatusInternalServerError {
+				require.Error(t, err)
+				require.Empty(t, deleted)
+				return
+			}
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{"/krb/certmgr/staged/1/", "/krb/certmgr/staged/3/"}, deleted)
+		})
+	}
+}
+
+func TestGetCertificateCache(t *testing.T) {
+	lists := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			lists++
+			fmt.Fprintf(w, `{"meta": {}, "objects": [{"id": %d, "hostname": "tf-test.cern.ch"}]}`, lists)
+		case http.MethodPost:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	ctx := context.Background()
+
+	for range 2 {
+		cert, err := cli.GetCertificate(ctx, "TF-test.cern.ch")
+		require.NoError(t, err)
+		require.Equal(t, 1, cert.ID)
+	}
+	require.Equal(t, 1, lists)
+
+	requestor := "terraform-test"
+	require.NoError(t, cli.UpdateCertificate(ctx, certMgr.CertificateUpdate{Hostname: "tf-test.cern.ch", Requestor: &requestor}))
+
+	cert, err := cli.GetCertificate(ctx, "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Equal(t, 2, cert.ID)
+}
+
+func TestGetCertificateExactHostname(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"meta": {}, "objects": [
+			{"id": 1, "hostname": "tf-test.cern.ch", "start": "2025-03-01T00:00:00"},
+			{"id": 2, "hostname": "tf-test.cern.ch", "start": "2025-01-01T00:00:00"},
+			{"id": 3, "hostname": "tf-test.cern.ch.example.org", "start": "2025-06-01T00:00:00"}]}`)
+	}))
+
+	staged, err := cli.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Len(t, staged, 2)
+
+	cert, err := cli.GetCertificate(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Equal(t, 1, cert.ID)
+}
+
+func TestGetCertificateBySerialExactSerial(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A server ignoring the filter lists every entry.
+		fmt.Fprint(w, `{"meta": {}, "objects": [
+			{"id": 1, "hostname": "a.cern.ch", "serial": "0a"},
+			{"id": 2, "hostname": "b.cern.ch", "serial": "0B"}]}`)
+	}))
+
+	cert, err := cli.GetCertificateBySerial(context.Background(), "0b")
+	require.NoError(t, err)
+	require.Equal(t, 2, cert.ID)
+
+	_, err = cli.GetCertificateBySerial(context.Background(), "0c")
+	require.ErrorIs(t, err, certMgr.ErrNoCertificates)
+	require.ErrorIs(t, err, certMgr.ErrNotFound)
+}
+
+func TestGetIssuedCertificate(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
+
+	_, err := cli.GetIssuedCertificate(ctx, "tf-test.cern.ch", "")
+	require.ErrorIs(t, err, certMgr.ErrNotIssued)
+
+	first, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	_, err = cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "other.cern.ch"})
+	require.NoError(t, err)
+
+	issued, err := cli.GetIssuedCertificate(ctx, "TF-test.cern.ch", first.Serial)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, issued.ID)
+	require.Contains(t, issued.CertificatePEM, "BEGIN CERTIFICATE")
+
+	_, err = cli.GetIssuedCertificate(ctx, "other.cern.ch", first.Serial)
+	require.ErrorIs(t, err, certMgr.ErrNotIssued)
+}
+
+func TestRenewCertificate(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
+
+	original, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	_, err = cli.GetCertificate(ctx, "tf-test.cern.ch")
+	require.NoError(t, err)
+
+	renewed, err := cli.RenewCertificate(ctx, original.ID)
+	require.NoError(t, err)
+	require.NotEqual(t, original.ID, renewed.ID)
+	require.NotEqual(t, original.Serial, renewed.Serial)
+	require.NotEmpty(t, renewed.End)
+
+	latest, err := cli.GetCertificate(ctx, "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Equal(t, renewed.ID, latest.ID)
+
+	_, err = cli.RenewCertificate(ctx, 999)
+	require.ErrorIs(t, err, certMgr.ErrNoCertificates)
+}
+
+func TestCreateCertificateAcceptedAsynchronously(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
+
+	previous, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+
+	server.AcceptAsync = true
+	created, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+	require.NotEqual(t, previous.ID, created.ID)
+}
+
+func TestCreateCertificatesAwaitsLateEntries(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		// The bulk request's entries are missing from the listing right
+		// after it, and only tf-test-1 shows up when polled.
+		if r.URL.Query().Get("hostname") == "tf-test-1.cern.ch" {
+			fmt.Fprint(w, `{"meta": {}, "objects": [{"id": 7, "hostname": "tf-test-1.cern.ch"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"meta": {}, "objects": []}`)
+	}))
+	cli.CreateTimeout = 500 * time.Millisecond
+
+	created, err := cli.CreateCertificates(context.Background(), []string{"tf-test-1.cern.ch"})
+	require.NoError(t, err)
+	require.Equal(t, []certMgr.Certificate{{ID: 7, Hostname: "tf-test-1.cern.ch"}}, created)
+
+	_, err = cli.CreateCertificates(context.Background(), []string{"tf-test-1.cern.ch", "tf-test-2.cern.ch"})
+	require.ErrorContains(t, err, "no staged entry appeared for tf-test-2.cern.ch:")
+}
+
+func TestCreateCertificateListsOnlyWhenAccepted(t *testing.T) {
+	var lists, posts atomic.Int32
+	accepted := false
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts.Add(1)
+			if accepted {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			fmt.Fprint(w, `{"id": 7, "hostname": "tf-test.cern.ch"}`)
+			return
+		}
+		// The entry superseded by the new one started long ago; the new one
+		// only shows up in the second listing.
+		if lists.Add(1) == 1 {
+			fmt.Fprint(w, `{"meta": {}, "objects": [{"id": 7, "hostname": "tf-test.cern.ch", "start": "2024-01-01T00:00:00"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"meta": {}, "objects": [{"id": 7, "hostname": "tf-test.cern.ch", "start": "2024-01-01T00:00:00"},
+			{"id": 8, "hostname": "tf-test.cern.ch"}]}`)
+	}))
+	ctx := context.Background()
+
+	created, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	require.Equal(t, 7, created.ID)
+	require.Zero(t, lists.Load())
+
+	accepted = true
+	created, err = cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	require.Equal(t, 8, created.ID)
+	require.Equal(t, int32(2), posts.Load())
 }
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"fmt"
+	"time"
+)
+
+// timestampLayouts are the layouts certMgr has been seen to use for
+// timestamps, most specific first.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a certMgr timestamp. Timestamps without a zone are
+// interpreted as UTC.
+func ParseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
+
+// StaleEntries returns the entries that started before cutoff. When
+// keepLatest is set, the newest entry of every hostname is never returned,
+// so only superseded entries are pruned. Entries with unparsable start
+// timestamps are skipped.
+func StaleEntries(entries []Certificate, cutoff time.Time, keepLatest bool) []Certificate {
+	latest := map[string]int{}
+	if keepLatest {
+		newest := map[string]time.Time{}
+		for _, entry := range entries {
+			start, err := ParseTimestamp(entry.Start)
+			if err != nil {
+				continue
+			}
+			if current, ok := newest[entry.Hostname]; !ok || start.After(current) {
+				newest[entry.Hostname] = start
+				latest[entry.Hostname] = entry.ID
+			}
+		}
+	}
+
+	var stale []Certificate
+	for _, entry := range entries {
+		start, err := ParseTimestamp(entry.Start)
+		if err != nil || !start.Before(cutoff) {
+			continue
+		}
+		if id, ok := latest[entry.Hostname]; ok && id == entry.ID {
+			continue
+		}
+		stale = append(stale, entry)
+	}
+	return stale
+}
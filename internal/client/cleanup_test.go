@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleEntries(t *testing.T) {
+	entries := []certMgr.Certificate{
+		{ID: 1, Hostname: "a.cern.ch", Start: "2024-01-01T10:00:00"},
+		{ID: 2, Hostname: "a.cern.ch", Start: "2024-02-01T10:00:00"},
+		{ID: 3, Hostname: "b.cern.ch", Start: "2024-01-15T10:00:00Z"},
+		{ID: 4, Hostname: "b.cern.ch", Start: "2025-06-01T10:00:00"},
+		{ID: 5, Hostname: "c.cern.ch", Start: "not a timestamp"},
+	}
+	cutoff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ids := func(certs []certMgr.Certificate) []int {
+		var out []int
+		for _, cert := range certs {
+			out = append(out, cert.ID)
+		}
+		return out
+	}
+
+	require.Equal(t, []int{1, 3}, ids(certMgr.StaleEntries(entries, cutoff, true)))
+	require.Equal(t, []int{1, 2, 3}, ids(certMgr.StaleEntries(entries, cutoff, false)))
+}
@@ -6,12 +6,21 @@ package certMgr
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/jcmturner/gokrb5/v8/client"
 	"github.com/jcmturner/gokrb5/v8/config"
@@ -23,8 +32,158 @@ type Client struct {
 	HTTPClient *spnego.Client
 	Host       string
 	Port       int
+
+	// TokenSource, when set, authenticates requests with bearer tokens instead
+	// of Kerberos. Tokens are cached and refreshed shortly before they expire.
+	TokenSource TokenSource
+
+	// ExternalHTTPClient is used for services other than certMgr, such as
+	// OIDC token endpoints and the targets certificates are exported to. Nil
+	// means http.DefaultClient.
+	ExternalHTTPClient *http.Client
+
+	// Principal is the Kerberos principal requests are made as, recorded in
+	// the audit log.
+	Principal string
+
+	// SocketPath, when set, is the unix domain socket of a local certMgr agent
+	// that requests are sent to instead of Host and Port. The agent
+	// authenticates on the caller's behalf, so plain HTTP is spoken over the
+	// socket.
+	SocketPath string
+
+	// RetryBudget caps the total time a single read waits on rate limited
+	// (429) and unavailable (503) responses and timeouts before the error is
+	// returned.
+	RetryBudget time.Duration
+
+	// WriteRetryBudget is the RetryBudget of requests that modify certMgr.
+	// Requests creating entries are only retried while they carry an
+	// idempotency key.
+	WriteRetryBudget time.Duration
+
+	// CreateTimeout bounds how long CreateCertificate waits for a request
+	// certMgr accepted asynchronously to show up as a staged entry. Zero means
+	// DefaultCreateTimeout.
+	CreateTimeout time.Duration
+
+	// CreateBatchWindow, when positive, is how long CreateCertificate waits
+	// for concurrent calls to join a single bulk request, at most
+	// MaxCreateBatch requests each. Zero sends a POST per certificate.
+	CreateBatchWindow time.Duration
+
+	// DisableIdempotencyKeys stops sending Idempotency-Key headers on POSTs,
+	// for servers that reject them. POSTs are then never retried.
+	DisableIdempotencyKeys bool
+
+	// BreakerThreshold is the number of consecutive connection failures or
+	// server errors (5xx) after which requests fail fast with ErrUnreachable
+	// for a cooldown period. Errors on the client's side, such as Kerberos
+	// or TLS configuration errors, do not count.
+	// Zero disables the circuit breaker.
+	BreakerThreshold int
+
+	// BreakerCooldown is how long the circuit breaker stays open before a
+	// single request probes whether certMgr recovered. Zero means
+	// DefaultBreakerCooldown.
+	BreakerCooldown time.Duration
+
+	// StrictDecoding rejects responses carrying fields the client does not
+	// know, to detect API drift early.
+	StrictDecoding bool
+
+	// WarnOnReadErrors asks resources to keep their prior state with a
+	// warning when refreshing them fails transiently, instead of failing the
+	// whole plan.
+	WarnOnReadErrors bool
+
+	// Organization scopes requests to one organization (VO) of a multi-tenant
+	// certMgr instance, unless their context names another one through
+	// WithOrganization. Empty means the instance's default tenancy.
+	Organization string
+
+	// Run is sent with every request that modifies certMgr and recorded in
+	// the audit log.
+	Run RunMetadata
+
+	// NegotiateVersion probes the certMgr API version before the first request,
+	// failing with ErrUnsupportedAPIVersion on servers older than
+	// MinimumAPIVersion and talking to the v2 endpoints where available.
+	NegotiateVersion bool
+
+	// Reauthenticate, when set, re-runs the authentication flow after certMgr
+	// rejected a request as unauthorized, for example because the Kerberos
+	// tickets expired mid-apply. The request is then retried once with the
+	// returned HTTP client. NewClient reloads the credential cache.
+	Reauthenticate func(context.Context) (*spnego.Client, error)
+
+	// authMu guards HTTPClient against replacement by reauthenticate.
+	authMu sync.RWMutex
+
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
+
+	validators   validatorCache
+	breaker      circuitBreaker
+	certificates certificateCache
+	versions     versionNegotiator
+	audit        auditLog
+	tokens       tokenCache
+	creates      createBatchers
+	authorities  authorityCache
+	revocations  revocationCache
+
+	// noHead is set once certMgr refused a HEAD request.
+	noHead atomic.Bool
+
+	// fips is set by SetFIPSMode.
+	fips bool
+
+	// slots limits the number of requests in flight; nil means unlimited.
+	slots chan struct{}
+}
+
+// SetMaxConcurrentRequests limits the number of requests in flight at once
+// across all callers of the client. Zero or less removes the limit. It must
+// be called before the client is used.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		c.slots = nil
+		return
+	}
+	c.slots = make(chan struct{}, n)
 }
 
+// ReadErrorsAsWarnings reports whether WarnOnReadErrors is set.
+func (c *Client) ReadErrorsAsWarnings() bool {
+	return c.WarnOnReadErrors
+}
+
+// RequestHook inspects or modifies a request before it is sent, for example
+// to inject headers or log it. The request carries the caller's context.
+type RequestHook func(*http.Request)
+
+// ResponseHook inspects a response before its body is read, for example to
+// record metrics. Hooks must not consume the body.
+type ResponseHook func(*http.Response)
+
+// OnRequest appends hook to the hooks run, in order, on every request.
+func (c *Client) OnRequest(hook RequestHook) {
+	c.requestHooks = append(c.requestHooks, hook)
+}
+
+// OnResponse appends hook to the hooks run, in order, on every response.
+func (c *Client) OnResponse(hook ResponseHook) {
+	c.responseHooks = append(c.responseHooks, hook)
+}
+
+// DefaultRetryBudget is the RetryBudget of clients created by NewClient.
+const DefaultRetryBudget = time.Minute
+
+// defaultRetryAfter is the wait used when certMgr rate limits without a
+// usable Retry-After header.
+const defaultRetryAfter = time.Second
+
 func loadKrb5Config() (*config.Config, error) {
 	path := os.Getenv("KRB5_CONFIG")
 	if path == "" {
@@ -44,7 +203,7 @@ func loadCCache() (*credentials.CCache, error) {
 }
 
 func NewClient(host string, port int) (*Client, error) {
-	if  port <= 0 || port > 65535 {
+	if port <= 0 || port > 65535 {
 		return nil, fmt.Errorf("invalid port: %q", port)
 	}
 
@@ -53,65 +212,317 @@ func NewClient(host string, port int) (*Client, error) {
 		return nil, fmt.Errorf("failed to load krb5.conf: %w", err)
 	}
 
+	httpClient, principal, err := newKerberosHTTPClient(krbConf)
+	if err != nil {
+		return nil, err
+	}
+
+	fqdn, err := fqdns.resolve(host, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve fqdn for host %q: %w", host, err)
+	}
+
+	return &Client{
+		Host:             fqdn,
+		Port:             port,
+		HTTPClient:       httpClient,
+		Principal:        principal,
+		RetryBudget:      DefaultRetryBudget,
+		WriteRetryBudget: DefaultRetryBudget,
+		BreakerThreshold: DefaultBreakerThreshold,
+		NegotiateVersion: true,
+		Reauthenticate: func(context.Context) (*spnego.Client, error) {
+			httpClient, _, err := newKerberosHTTPClient(krbConf)
+			return httpClient, err
+		},
+	}, nil
+}
+
+// NewUnixSocketClient returns a client for a local certMgr agent listening on
+// the unix domain socket at path.
+func NewUnixSocketClient(path string) (*Client, error) {
+	if path == "" {
+		return nil, fmt.Errorf("socket path is required")
+	}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}
+
+	return &Client{
+		HTTPClient:       spnego.NewClient(nil, &http.Client{Transport: transport}, ""),
+		Host:             "localhost",
+		SocketPath:       path,
+		RetryBudget:      DefaultRetryBudget,
+		WriteRetryBudget: DefaultRetryBudget,
+		BreakerThreshold: DefaultBreakerThreshold,
+		NegotiateVersion: true,
+	}, nil
+}
+
+// origin returns the scheme and authority requests are sent to.
+func (c *Client) origin() string {
+	if c.SocketPath != "" {
+		return "http://" + c.Host
+	}
+	return fmt.Sprintf("https://%s:%d", c.Host, c.Port)
+}
+
+// newKerberosHTTPClient returns an SPNEGO client authenticating with the
+// tickets currently in the credential cache, and the principal they belong
+// to.
+func newKerberosHTTPClient(krbConf *config.Config) (*spnego.Client, string, error) {
 	ccache, err := loadCCache()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load credential cache: %w", err)
+		return nil, "", fmt.Errorf("failed to load credential cache: %w", err)
 	}
 
 	krbClient, err := client.NewFromCCache(ccache, krbConf)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create kerberos client: %w", err)
+		return nil, "", fmt.Errorf("failed to create kerberos client: %w", err)
 	}
 
-	fqdn, err := resolveFQDN(host)
+	principal := ccache.GetClientPrincipalName().PrincipalNameString() + "@" + ccache.GetClientRealm()
+	return spnego.NewClient(krbClient, nil, ""), principal, nil
+}
+
+// NewKerberosHTTPClient returns an SPNEGO client authenticating with the
+// tickets in the credential cache, for CERN services other than certMgr
+// that accept the same credentials.
+func NewKerberosHTTPClient() (*spnego.Client, error) {
+	krbConf, err := loadKrb5Config()
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve fqdn for host %q: %w", host, err)
+		return nil, fmt.Errorf("failed to load krb5.conf: %w", err)
 	}
+	httpClient, _, err := newKerberosHTTPClient(krbConf)
+	return httpClient, err
+}
 
-	httpClient := spnego.NewClient(krbClient, nil, "")
+// reauthenticate replaces the HTTP client with one from Reauthenticate.
+// canRenewCredentials reports whether a request refused with 401 may be sent
+// again with fresh credentials.
+func (c *Client) canRenewCredentials() bool {
+	return c.Reauthenticate != nil || c.TokenSource != nil
+}
 
-	return &Client{
-		Host:       fqdn,
-		Port:       port,
-		HTTPClient: httpClient,
-	}, nil
+// renewCredentials drops the cached bearer token and, with Reauthenticate,
+// replaces the HTTP client, after certMgr refused the credentials.
+func (c *Client) renewCredentials(ctx context.Context) error {
+	c.tokens.invalidate()
+	if c.Reauthenticate != nil {
+		return c.reauthenticate(ctx)
+	}
+	return nil
 }
 
-func resolveFQDN(host string) (string, error) {
-	ips, err := net.LookupIP(host)
+func (c *Client) reauthenticate(ctx context.Context) error {
+	httpClient, err := c.Reauthenticate(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to resolve IP for hostname %s: %w", host, err)
+		return fmt.Errorf("re-authentication failed: %w", err)
 	}
 
-	for _, ip := range ips {
-		if ip.To4() == nil {
+	c.authMu.Lock()
+	c.HTTPClient = httpClient
+	if c.fips {
+		c.restrictTLS()
+	}
+	c.authMu.Unlock()
+	return nil
+}
+
+func (c *Client) httpClient() *spnego.Client {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.HTTPClient
+}
+
+func (c *Client) doRequest(ctx context.Context, method, url string, payload []byte) ([]byte, int, error) {
+	body, status, _, err := c.doRequestHeader(ctx, method, url, payload)
+	return body, status, err
+}
+
+// doRequestHeader is doRequest that also returns the header of the response.
+// Responses answered from the validatorCache carry the cached ETag.
+func (c *Client) doRequestHeader(ctx context.Context, method, url string, payload []byte) ([]byte, int, http.Header, error) {
+	body, status, header, err := c.do(ctx, method, url, payload, nil)
+	if method == http.MethodGet || method == http.MethodHead {
+		return body, status, header, err
+	}
+
+	entry := AuditEntry{
+		Time:         time.Now().UTC(),
+		Principal:    c.Principal,
+		Organization: c.organization(ctx),
+		Workspace:    c.Run.Workspace,
+		RunID:        c.Run.RunID,
+		Method:       method,
+		URL:          url,
+		Status:       status,
+		Outcome:      AuditSuccess,
+	}
+	if err != nil {
+		entry.Outcome = AuditFailure
+		entry.Error = err.Error()
+	}
+	if auditErr := c.audit.record(entry); auditErr != nil {
+		return body, status, header, errors.Join(err, auditErr)
+	}
+	return body, status, header, err
+}
+
+// doStream GETs url and hands the body of a successful response to stream
+// instead of buffering it, for responses too large to hold in memory twice.
+func (c *Client) doStream(ctx context.Context, url string, stream func(io.Reader) error) error {
+	_, _, _, err := c.do(ctx, http.MethodGet, url, nil, stream)
+	return err
+}
+
+// do performs a request, retrying it while certMgr asks to back off or the
+// connection timed out, and once after re-authenticating on a 401. When
+// stream is set, successful bodies are passed to it rather than returned.
+func (c *Client) do(ctx context.Context, method, url string, payload []byte, stream func(io.Reader) error) ([]byte, int, http.Header, error) {
+	// POSTs are not idempotent; a key shared by all attempts lets certMgr
+	// recognize a retry of a request it already processed, for example one
+	// whose response was lost to a timeout.
+	var idempotencyKey string
+	if method == http.MethodPost && !c.DisableIdempotencyKeys {
+		idempotencyKey = newIdempotencyKey()
+	}
+	budget := c.retryBudget(ctx, method, idempotencyKey)
+
+	url, err := c.negotiate(ctx, url)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var waited time.Duration
+	reauthenticated := false
+	for {
+		body, status, header, err := c.send(ctx, method, url, payload, idempotencyKey, stream)
+		if err != nil {
+			if !isTimeout(err) || ctx.Err() != nil || budget <= 0 || waited+defaultRetryAfter > budget {
+				return body, status, header, err
+			}
+			select {
+			case <-ctx.Done():
+				return body, status, header, err
+			case <-time.After(defaultRetryAfter):
+			}
+			waited += defaultRetryAfter
 			continue
 		}
-		ptrs, err := net.LookupAddr(ip.String())
-		if err != nil {
-			return "", fmt.Errorf("reverse lookup failed for IP %s: %w", ip, err)
+
+		if status >= 200 && status <= 299 {
+			return body, status, header, nil
 		}
-		if len(ptrs) > 0 {
-			return strings.TrimSuffix(ptrs[0], "."), nil
+
+		statusErr := newStatusError(method, url, status, body)
+		if status == http.StatusUnauthorized && c.canRenewCredentials() && !reauthenticated {
+			reauthenticated = true
+			if err := c.renewCredentials(ctx); err != nil {
+				return body, status, header, fmt.Errorf("%w: %w", statusErr, err)
+			}
+			continue
 		}
+
+		wait, ok := retryAfter(status, header, time.Now())
+		if !ok || budget <= 0 || waited+wait > budget {
+			return body, status, header, statusErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return body, status, header, fmt.Errorf("%w: %w", statusErr, ctx.Err())
+		case <-time.After(wait):
+		}
+		waited += wait
+	}
+}
+
+// retryBudget returns how long a request may wait on retries in total.
+// Requests creating entries, POSTs and bulk PATCHes, are never retried
+// without an idempotency key, as certMgr could not tell a retry from a
+// second request.
+func (c *Client) retryBudget(ctx context.Context, method, idempotencyKey string) time.Duration {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return c.RetryBudget
 	}
-	return "", fmt.Errorf("no valid IPv4 PTR record found for host %s", host)
+	if (method == http.MethodPost || createsEntries(ctx)) && idempotencyKey == "" {
+		return 0
+	}
+	return c.WriteRetryBudget
 }
 
-func (c *Client) doRequest(method, url string, payload []byte) ([]byte, int, error) {
-	req, err := http.NewRequest(method, url, bytes.NewReader(payload))
+// send performs a single attempt of a request.
+func (c *Client) send(ctx context.Context, method, url string, payload []byte, idempotencyKey string, stream func(io.Reader) error) ([]byte, int, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	if payload != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "application/json")
+	// Setting Accept-Encoding explicitly disables the transport's transparent
+	// decompression, so responses are decompressed by bodyReader.
+	req.Header.Set("Accept-Encoding", "gzip")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	setModuleHeaders(ctx, req.Header)
+	c.setOrganizationHeader(ctx, req.Header)
+	if method != http.MethodGet && method != http.MethodHead {
+		c.setRunHeaders(req.Header)
+	}
+	// Streamed bodies are never cached, so they cannot be revalidated.
+	validator := validatorKey{organization: c.organization(ctx), url: url}
+	if method == http.MethodGet && stream == nil {
+		c.validators.prepare(validator, req)
+	}
+	if etag, ok := ctx.Value(ifNoneMatchKey{}).(string); ok {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	if c.TokenSource != nil {
+		token, err := c.tokens.get(ctx, c.TokenSource, time.Now())
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	for _, hook := range c.requestHooks {
+		hook(req)
+	}
+
+	probe, err := c.breaker.allow(c.BreakerThreshold, time.Now())
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+			defer func() { <-c.slots }()
+		case <-ctx.Done():
+			c.breaker.record(ctx, c.BreakerThreshold, c.BreakerCooldown, probe, ctx.Err(), time.Now())
+			return nil, 0, nil, fmt.Errorf("waiting for a request slot: %w", ctx.Err())
+		}
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if failure, counted := breakerFailure(method, url, resp, err); counted {
+		c.breaker.record(ctx, c.BreakerThreshold, c.BreakerCooldown, probe, failure, time.Now())
+	} else {
+		c.breaker.release(probe)
+	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("request failed: %w", err)
+		return nil, 0, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() {
 		if cerr := resp.Body.Close(); cerr != nil {
@@ -119,10 +530,92 @@ func (c *Client) doRequest(method, url string, payload []byte) ([]byte, int, err
 		}
 	}()
 
-	body, err := io.ReadAll(resp.Body)
+	for _, hook := range c.responseHooks {
+		hook(resp)
+	}
+
+	reader, err := bodyReader(resp)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if stream != nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil, resp.StatusCode, resp.Header, stream(reader)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if method == http.MethodGet {
+		switch resp.StatusCode {
+		case http.StatusNotModified:
+			if cached, ok := c.validators.cached(validator); ok {
+				header := resp.Header.Clone()
+				if header.Get("ETag") == "" {
+					header.Set("ETag", c.validators.etag(validator))
+				}
+				return cached, http.StatusOK, header, nil
+			}
+		case http.StatusOK:
+			c.validators.store(validator, resp.Header, body)
+		}
 	}
 
-	return body, resp.StatusCode, nil
+	return body, resp.StatusCode, resp.Header, nil
+}
+
+// newIdempotencyKey returns a random key identifying one logical request
+// across its attempts.
+func newIdempotencyKey() string {
+	key := make([]byte, 16)
+	_, _ = rand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+// isTimeout reports whether err is a network timeout, after which the
+// request may or may not have reached certMgr.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryAfter reports how long to wait before retrying a response that was
+// rate limited (429) or rejected as unavailable (503). 503 responses are only
+// retried when certMgr announces a Retry-After, which may be given in seconds
+// or as an HTTP date.
+func retryAfter(status int, header http.Header, now time.Time) (time.Duration, bool) {
+	if status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return defaultRetryAfter, status == http.StatusTooManyRequests
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return defaultRetryAfter, true
+}
+
+// bodyReader returns the body of resp, decompressing it when certMgr sent it
+// gzip encoded.
+func bodyReader(resp *http.Response) (io.Reader, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if errors.Is(err, io.EOF) {
+		return http.NoBody, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip response: %w", err)
+	}
+	return reader, nil
 }
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// validatorCache remembers the ETag and Last-Modified validators of GET
+// responses together with their bodies, so that repeated reads of unchanged
+// objects are answered by certMgr with an empty 304 Not Modified.
+type validatorCache struct {
+	mu      sync.Mutex
+	entries map[validatorKey]cachedResponse
+}
+
+// validatorKey identifies a cached response. The same URL returns different
+// objects for different organizations, so their validators are kept apart.
+type validatorKey struct {
+	organization string
+	url          string
+}
+
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// prepare adds conditional headers for key to req when a cached response
+// exists.
+func (v *validatorCache) prepare(key validatorKey, req *http.Request) {
+	v.mu.Lock()
+	cached, ok := v.entries[key]
+	v.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	if cached.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.lastModified)
+	}
+}
+
+// cached returns the body remembered for key, if any.
+func (v *validatorCache) cached(key validatorKey) ([]byte, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	cached, ok := v.entries[key]
+	return cached.body, ok
+}
+
+// etag returns the ETag remembered for key, if any.
+func (v *validatorCache) etag(key validatorKey) string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.entries[key].etag
+}
+
+// store remembers body for key when the response carries validators.
+func (v *validatorCache) store(key validatorKey, header http.Header, body []byte) {
+	etag, lastModified := header.Get("ETag"), header.Get("Last-Modified")
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if etag == "" && lastModified == "" {
+		delete(v.entries, key)
+		return
+	}
+	if v.entries == nil {
+		v.entries = map[validatorKey]cachedResponse{}
+	}
+	v.entries[key] = cachedResponse{etag: etag, lastModified: lastModified, body: body}
+}
+
+type ifNoneMatchKey struct{}
+
+// withIfNoneMatch asks for the requests made with ctx to be conditional on
+// etag, a validator kept by the caller rather than by the validatorCache.
+func withIfNoneMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifNoneMatchKey{}, etag)
+}
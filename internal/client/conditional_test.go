@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalGet(t *testing.T) {
+	notModified := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"total": 7}`)
+	}))
+
+	for range 2 {
+		stats, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 7, stats.Total)
+	}
+	require.Equal(t, 1, notModified)
+}
+
+func TestGetCertificateByIDETag(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasSuffix(r.URL.Path, "/staged/7/"), r.URL.Path)
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id": 7, "hostname": "tf-test.cern.ch"}`)
+	}))
+
+	cert, err := cli.GetCertificateByID(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, `"v1"`, cert.ETag)
+}
+
+func TestGetCertificateByIDETagV2(t *testing.T) {
+	var heads int
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/krb/certmgr/version/":
+			fmt.Fprint(w, `{"version": "2.1"}`)
+		case "/krb/certmgr/v2/staged/7/":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				heads++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			fmt.Fprint(w, `{"id": 7, "hostname": "tf-test.cern.ch"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	cli.NegotiateVersion = true
+	ctx := context.Background()
+
+	cert, err := cli.GetCertificateByID(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, `"v1"`, cert.ETag)
+
+	unchanged, err := cli.CertificateUnchanged(ctx, cert.ID, cert.ETag)
+	require.NoError(t, err)
+	require.True(t, unchanged)
+	require.Equal(t, 1, heads)
+}
+
+func TestCertificateUnchanged(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	ctx := context.Background()
+	created, err := server.NewClient().CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	cert, err := server.NewClient().GetCertificateByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, cert.ETag)
+
+	cli := server.NewClient()
+	var methods []string
+	cli.OnRequest(func(req *http.Request) {
+		methods = append(methods, req.Method)
+	})
+
+	unchanged, err := cli.CertificateUnchanged(ctx, cert.ID, cert.ETag)
+	require.NoError(t, err)
+	require.True(t, unchanged)
+
+	unchanged, err = cli.CertificateUnchanged(ctx, cert.ID, `"outdated"`)
+	require.NoError(t, err)
+	require.False(t, unchanged)
+
+	// Without an ETag there is nothing to compare to.
+	unchanged, err = cli.CertificateUnchanged(ctx, cert.ID, "")
+	require.NoError(t, err)
+	require.False(t, unchanged)
+	require.Equal(t, []string{http.MethodHead, http.MethodHead}, methods)
+
+	server.Remove(cert.ID)
+	_, err = cli.CertificateUnchanged(ctx, cert.ID, cert.ETag)
+	require.ErrorIs(t, err, certMgr.ErrNoCertificates)
+}
+
+func TestCertificateUnchangedWithoutHead(t *testing.T) {
+	heads := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodHead, r.Method)
+		heads++
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+
+	for range 2 {
+		unchanged, err := cli.CertificateUnchanged(context.Background(), 7, `"v1"`)
+		require.NoError(t, err)
+		require.False(t, unchanged)
+	}
+	require.Equal(t, 1, heads)
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/krb/certmgr/statistics/":
+			fmt.Fprint(w, `{"total": 1, "revoked": 2}`)
+		default:
+			fmt.Fprint(w, `{"meta": {"limit": 20, "next": null},
+				"objects": [{"id": 1, "hostname": "tf-test.cern.ch", "issuer": "CERN"}]}`)
+		}
+	}))
+	ctx := context.Background()
+
+	_, err := cli.GetStatistics(ctx, certMgr.StatisticsFilter{})
+	require.NoError(t, err)
+	_, err = cli.ListStaged(ctx, "tf-test.cern.ch")
+	require.NoError(t, err)
+
+	cli.StrictDecoding = true
+	_, err = cli.GetStatistics(ctx, certMgr.StatisticsFilter{})
+	require.ErrorContains(t, err, `unknown field "revoked"`)
+	_, err = cli.ListStaged(ctx, "tf-test.cern.ch")
+	require.ErrorContains(t, err, `unknown field "issuer"`)
+}
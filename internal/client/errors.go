@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Errors that a StatusError classifies into, for use with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// StatusError is returned for responses with a non-2xx status code.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+
+	// Message is the error message certMgr sent in the response body, if any.
+	Message string
+	// FieldErrors holds validation errors certMgr reported per field.
+	FieldErrors map[string][]string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s %s: unexpected status %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	for _, field := range fields {
+		msg += fmt.Sprintf("; %s: %s", field, strings.Join(e.FieldErrors[field], " "))
+	}
+	return msg
+}
+
+// maxErrorMessage bounds the length of plain text error bodies kept in a
+// StatusError.
+const maxErrorMessage = 200
+
+// newStatusError returns the StatusError for a response, with the message
+// and field errors parsed from its body.
+func newStatusError(method, url string, status int, body []byte) *StatusError {
+	err := &StatusError{Method: method, URL: url, StatusCode: status}
+	err.Message, err.FieldErrors = parseErrorBody(body)
+	return err
+}
+
+// parseErrorBody extracts the message and field errors of an error response.
+// Tastypie reports failures as {"error_message": ...} or {"error": ...} and
+// validation errors as {"<resource>": {"<field>": ["..."]}}; other JSON APIs
+// commonly use "detail" or "message". Non-JSON bodies are kept as the message
+// unless they are HTML error pages.
+func parseErrorBody(body []byte) (string, map[string][]string) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return "", nil
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		if body[0] == '<' || body[0] == '{' || body[0] == '[' {
+			return "", nil
+		}
+		message, _, _ := strings.Cut(string(body), "\n")
+		if len(message) > maxErrorMessage {
+			message = message[:maxErrorMessage] + "..."
+		}
+		return message, nil
+	}
+
+	var message string
+	fieldErrors := map[string][]string{}
+	for key, raw := range payload {
+		switch key {
+		case "error_message", "error", "detail", "message":
+			var text string
+			if json.Unmarshal(raw, &text) == nil && message == "" {
+				message = text
+			}
+		case "traceback":
+		default:
+			collectFieldErrors(fieldErrors, key, raw)
+		}
+	}
+	if len(fieldErrors) == 0 {
+		fieldErrors = nil
+	}
+	return message, fieldErrors
+}
+
+// collectFieldErrors adds the errors of field, given either directly as a
+// message or list of messages, or nested per field below a resource name.
+func collectFieldErrors(fieldErrors map[string][]string, field string, raw json.RawMessage) {
+	var messages []string
+	if json.Unmarshal(raw, &messages) == nil {
+		fieldErrors[field] = append(fieldErrors[field], messages...)
+		return
+	}
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		fieldErrors[field] = append(fieldErrors[field], text)
+		return
+	}
+	var nested map[string]json.RawMessage
+	if json.Unmarshal(raw, &nested) == nil {
+		for name, value := range nested {
+			collectFieldErrors(fieldErrors, name, value)
+		}
+	}
+}
+
+// IsTransient reports whether err is likely to go away when the request is
+// repeated later: timeouts, connection failures, rate limiting and server
+// errors. Errors certMgr answered deliberately, and cancellations, are not.
+func IsTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.Is(err, ErrUnreachable) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		isTimeout(err) ||
+		errors.As(err, &opErr) ||
+		errors.As(err, &dnsErr)
+}
+
+// Unwrap classifies the status code, so that errors.Is(err, ErrNotFound) and
+// friends work on any error returned by the client.
+func (e *StatusError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
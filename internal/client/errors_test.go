@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusErrorClassification(t *testing.T) {
+	cases := map[int]error{
+		http.StatusNotFound:        certMgr.ErrNotFound,
+		http.StatusUnauthorized:    certMgr.ErrUnauthorized,
+		http.StatusForbidden:       certMgr.ErrUnauthorized,
+		http.StatusConflict:        certMgr.ErrConflict,
+		http.StatusTooManyRequests: certMgr.ErrRateLimited,
+	}
+
+	for code, want := range cases {
+		err := fmt.Errorf("wrapped: %w", &certMgr.StatusError{Method: http.MethodGet, URL: "https://certmgr/", StatusCode: code})
+		require.ErrorIs(t, err, want, code)
+
+		var statusErr *certMgr.StatusError
+		require.True(t, errors.As(err, &statusErr))
+		require.Equal(t, code, statusErr.StatusCode)
+	}
+
+	err := &certMgr.StatusError{StatusCode: http.StatusInternalServerError}
+	for _, sentinel := range cases {
+		require.NotErrorIs(t, err, sentinel)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	require.True(t, certMgr.IsTransient(fmt.Errorf("read: %w", &certMgr.StatusError{StatusCode: http.StatusServiceUnavailable})))
+	require.True(t, certMgr.IsTransient(&certMgr.StatusError{StatusCode: http.StatusTooManyRequests}))
+	require.True(t, certMgr.IsTransient(fmt.Errorf("read: %w", context.DeadlineExceeded)))
+	require.True(t, certMgr.IsTransient(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	require.True(t, certMgr.IsTransient(certMgr.ErrUnreachable))
+
+	require.False(t, certMgr.IsTransient(&certMgr.StatusError{StatusCode: http.StatusNotFound}))
+	require.False(t, certMgr.IsTransient(&certMgr.StatusError{StatusCode: http.StatusForbidden}))
+	require.False(t, certMgr.IsTransient(context.Canceled))
+	require.False(t, certMgr.IsTransient(errors.New("unmarshal failed")))
+}
+
+func TestStatusErrorBody(t *testing.T) {
+	cases := map[string]struct {
+		body   string
+		want   string
+		fields map[string][]string
+	}{
+		"tastypie message": {
+			body: `{"error_message": "Hostname is not registered", "traceback": "Traceback ..."}`,
+			want: "unexpected status 400 Bad Request: Hostname is not registered",
+		},
+		"validation errors": {
+			body:   `{"staged": {"hostname": ["This field is required."], "csr": "Invalid CSR."}}`,
+			want:   "unexpected status 400 Bad Request; csr: Invalid CSR.; hostname: This field is required.",
+			fields: map[string][]string{"hostname": {"This field is required."}, "csr": {"Invalid CSR."}},
+		},
+		"plain text": {
+			body: "hostname already has a pending request\nmore details",
+			want: "unexpected status 400 Bad Request: hostname already has a pending request",
+		},
+		"html page": {
+			body: "<html><body>Bad Request</body></html>",
+			want: "unexpected status 400 Bad Request",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, tc.body)
+			}))
+
+			_, err := cli.CreateCertificate(context.Background(), certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+			require.Error(t, err)
+			require.True(t, strings.HasSuffix(err.Error(), tc.want), err.Error())
+
+			var statusErr *certMgr.StatusError
+			require.True(t, errors.As(err, &statusErr))
+			require.Equal(t, tc.fields, statusErr.FieldErrors)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"net/http"
+)
+
+// fipsCipherSuites are the TLS 1.2 cipher suites built from FIPS approved
+// primitives. TLS 1.3 suites are not configurable and include
+// TLS_CHACHA20_POLY1305_SHA256, which is not approved, so TLS 1.3 is only
+// allowed when Go itself runs in FIPS 140 mode (GODEBUG=fips140=on) and
+// crypto/tls leaves that suite out.
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// fipsCurves are the FIPS approved key exchange curves.
+var fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+
+// SetFIPSMode restricts the TLS connections of the client to FIPS approved
+// protocol versions, cipher suites and curves, and makes FIPSMode report true
+// so that callers restrict locally generated keys as well. It must be called
+// before the client is used.
+func (c *Client) SetFIPSMode() {
+	c.fips = true
+	c.restrictTLS()
+}
+
+// FIPSMode reports whether SetFIPSMode was called.
+func (c *Client) FIPSMode() bool {
+	return c.fips
+}
+
+// NewFIPSHTTPClient returns an HTTP client whose TLS connections are
+// restricted like those of a client in FIPS mode, for the services other
+// than certMgr the provider talks to.
+func NewFIPSHTTPClient() *http.Client {
+	return &http.Client{Transport: fipsTransport(nil)}
+}
+
+// ExternalClient returns ExternalHTTPClient, or http.DefaultClient when it is
+// not set.
+func (c *Client) ExternalClient() *http.Client {
+	if c.ExternalHTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.ExternalHTTPClient
+}
+
+// restrictTLS applies the FIPS TLS settings to the transport of HTTPClient.
+// Transports other than *http.Transport, such as test doubles, are left
+// alone.
+func (c *Client) restrictTLS() {
+	if c.HTTPClient == nil {
+		return
+	}
+
+	switch c.HTTPClient.Transport.(type) {
+	case nil, *http.Transport:
+		c.HTTPClient.Transport = fipsTransport(c.HTTPClient.Transport)
+	}
+}
+
+// fipsTransport returns a copy of base, or of http.DefaultTransport when base
+// is nil, restricted to the FIPS TLS settings.
+func fipsTransport(base http.RoundTripper) *http.Transport {
+	var transport *http.Transport
+	if t, ok := base.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.MinVersion = tls.VersionTLS12
+	if !fips140.Enabled() {
+		transport.TLSClientConfig.MaxVersion = tls.VersionTLS12
+	}
+	transport.TLSClientConfig.CipherSuites = fipsCipherSuites
+	transport.TLSClientConfig.CurvePreferences = fipsCurves
+	return transport
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/stretchr/testify/require"
+)
+
+func newTLS12Client(t *testing.T, cipherSuite uint16) *certMgr.Client {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1, "hostname": "host.cern.ch"}`))
+	}))
+	server.TLS = &tls.Config{
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{cipherSuite},
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+
+	return &certMgr.Client{
+		HTTPClient: spnego.NewClient(nil, server.Client(), ""),
+		Host:       u.Hostname(),
+		Port:       port,
+	}
+}
+
+func TestFIPSModeRestrictsCipherSuites(t *testing.T) {
+	client := newTLS12Client(t, tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305)
+	_, err := client.GetCertificateByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	client = newTLS12Client(t, tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305)
+	client.SetFIPSMode()
+	require.True(t, client.FIPSMode())
+	_, err = client.GetCertificateByID(context.Background(), 1)
+	require.ErrorContains(t, err, "handshake failure")
+
+	client = newTLS12Client(t, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256)
+	client.SetFIPSMode()
+	_, err = client.GetCertificateByID(context.Background(), 1)
+	require.NoError(t, err)
+}
+
+func TestNewFIPSHTTPClient(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.TLS = &tls.Config{
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305},
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	client := certMgr.NewFIPSHTTPClient()
+	transport := client.Transport.(*http.Transport)
+	transport.TLSClientConfig.RootCAs = server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	_, err := client.Get(server.URL)
+	require.ErrorContains(t, err, "handshake failure")
+}
+
+func TestFIPSModeRefusesTLS13(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.TLS = &tls.Config{MinVersion: tls.VersionTLS13}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	client := certMgr.NewFIPSHTTPClient()
+	transport := client.Transport.(*http.Transport)
+	transport.TLSClientConfig.RootCAs = server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	_, err := client.Get(server.URL)
+	require.ErrorContains(t, err, "protocol version")
+}
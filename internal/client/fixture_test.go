@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/vcr"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureClient returns a client replaying testdata/fixtures/<name>.json.
+// With CERTMGR_RECORD set, the fixture is instead recorded against the
+// certMgr host in CERTMGR_HOST using the caller's Kerberos credentials.
+func newFixtureClient(t *testing.T, name string) *certMgr.Client {
+	t.Helper()
+
+	path := filepath.Join("testdata", "fixtures", name+".json")
+	mode := vcr.ModeFromEnv()
+	recorder, err := vcr.New(path, mode, nil)
+	require.NoError(t, err)
+
+	if mode == vcr.Record {
+		cli, err := certMgr.NewClient(os.Getenv("CERTMGR_HOST"), 8008)
+		require.NoError(t, err)
+		cli.HTTPClient.Transport = recorder
+		t.Cleanup(func() { require.NoError(t, recorder.Save()) })
+		return cli
+	}
+
+	return &certMgr.Client{
+		HTTPClient: spnego.NewClient(nil, &http.Client{Transport: recorder}, ""),
+		Host:       "certmgr.invalid",
+		Port:       8008,
+	}
+}
+
+func TestGetCertificateFixture(t *testing.T) {
+	cli := newFixtureClient(t, "get_certificate")
+	ctx := context.Background()
+
+	cert, err := cli.GetCertificate(ctx, "tf-fixture.cern.ch")
+	require.NoError(t, err)
+	require.Equal(t, 51873, cert.ID)
+	require.Equal(t, "terraform", cert.Requestor)
+
+	cert, err = cli.GetCertificateByID(ctx, 48211)
+	require.NoError(t, err)
+	require.Equal(t, "1A2B3C4D5E6F", cert.Serial)
+
+	_, err = cli.GetCertificateByID(ctx, 1)
+	require.ErrorIs(t, err, certMgr.ErrNoCertificates)
+}
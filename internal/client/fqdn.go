@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fqdnTTL is how long the FQDN resolved for a host is reused.
+const fqdnTTL = 10 * time.Minute
+
+// fqdns memoizes resolveFQDN for the clients of one provider process, such
+// as those of aliased provider configurations naming the same host.
+var fqdns = fqdnCache{lookup: resolveFQDN}
+
+type fqdnCache struct {
+	mu      sync.Mutex
+	entries map[string]fqdnEntry
+	pending map[string]*fqdnLookup
+	lookup  func(host string) (string, error)
+}
+
+type fqdnEntry struct {
+	fqdn    string
+	expires time.Time
+}
+
+// fqdnLookup is a lookup in progress, which concurrent callers resolving the
+// same host wait for instead of starting their own.
+type fqdnLookup struct {
+	done chan struct{}
+	fqdn string
+	err  error
+}
+
+// resolve returns the FQDN of host, looking it up when it was not resolved
+// within fqdnTTL. Failed lookups are not remembered. Lookups run without
+// holding the lock, so a slow resolver only delays callers of the same host.
+func (f *fqdnCache) resolve(host string, now time.Time) (string, error) {
+	f.mu.Lock()
+	if entry, ok := f.entries[host]; ok && now.Before(entry.expires) {
+		f.mu.Unlock()
+		return entry.fqdn, nil
+	}
+	if call, ok := f.pending[host]; ok {
+		f.mu.Unlock()
+		<-call.done
+		return call.fqdn, call.err
+	}
+	call := &fqdnLookup{done: make(chan struct{})}
+	if f.pending == nil {
+		f.pending = map[string]*fqdnLookup{}
+	}
+	f.pending[host] = call
+	f.mu.Unlock()
+
+	call.fqdn, call.err = f.lookup(host)
+
+	f.mu.Lock()
+	delete(f.pending, host)
+	if call.err == nil {
+		if f.entries == nil {
+			f.entries = map[string]fqdnEntry{}
+		}
+		f.entries[host] = fqdnEntry{fqdn: call.fqdn, expires: now.Add(fqdnTTL)}
+	}
+	f.mu.Unlock()
+	close(call.done)
+	return call.fqdn, call.err
+}
+
+func resolveFQDN(host string) (string, error) {
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve IP for hostname %s: %w", host, err)
+	}
+
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			continue
+		}
+		ptrs, err := net.LookupAddr(ip.String())
+		if err != nil {
+			return "", fmt.Errorf("reverse lookup failed for IP %s: %w", ip, err)
+		}
+		if len(ptrs) > 0 {
+			return strings.TrimSuffix(ptrs[0], "."), nil
+		}
+	}
+	return "", fmt.Errorf("no valid IPv4 PTR record found for host %s", host)
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFQDNCacheExpires(t *testing.T) {
+	lookups := 0
+	cache := fqdnCache{lookup: func(host string) (string, error) {
+		lookups++
+		return host + ".cern.ch", nil
+	}}
+	now := time.Now()
+
+	for _, at := range []time.Time{now, now.Add(fqdnTTL - time.Second)} {
+		fqdn, err := cache.resolve("certmgr", at)
+		require.NoError(t, err)
+		require.Equal(t, "certmgr.cern.ch", fqdn)
+	}
+	require.Equal(t, 1, lookups)
+
+	_, err := cache.resolve("certmgr", now.Add(fqdnTTL))
+	require.NoError(t, err)
+	require.Equal(t, 2, lookups)
+}
+
+func TestFQDNCacheForgetsFailures(t *testing.T) {
+	lookups := 0
+	cache := fqdnCache{lookup: func(host string) (string, error) {
+		lookups++
+		if lookups == 1 {
+			return "", errors.New("temporary failure in name resolution")
+		}
+		return host + ".cern.ch", nil
+	}}
+	now := time.Now()
+
+	_, err := cache.resolve("certmgr", now)
+	require.Error(t, err)
+
+	fqdn, err := cache.resolve("certmgr", now)
+	require.NoError(t, err)
+	require.Equal(t, "certmgr.cern.ch", fqdn)
+	require.Equal(t, 2, lookups)
+}
+
+func TestFQDNCacheLooksUpHostsConcurrently(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var mu sync.Mutex
+	lookups := map[string]int{}
+	cache := fqdnCache{lookup: func(host string) (string, error) {
+		mu.Lock()
+		lookups[host]++
+		mu.Unlock()
+		if host == "slow" {
+			close(started)
+			<-release
+		}
+		return host + ".cern.ch", nil
+	}}
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	resolveSlow := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fqdn, err := cache.resolve("slow", now)
+			require.NoError(t, err)
+			require.Equal(t, "slow.cern.ch", fqdn)
+		}()
+	}
+	resolveSlow()
+	<-started
+	// Callers of a host being looked up wait for that lookup.
+	resolveSlow()
+	resolveSlow()
+
+	// A host whose lookup is stuck does not hold up the others.
+	fqdn, err := cache.resolve("fast", now)
+	require.NoError(t, err)
+	require.Equal(t, "fast.cern.ch", fqdn)
+
+	close(release)
+	wg.Wait()
+	require.Equal(t, map[string]int{"slow": 1, "fast": 1}, lookups)
+}
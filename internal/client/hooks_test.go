@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "test", r.Header.Get("X-Trace"))
+		fmt.Fprint(w, `{"total": 1}`)
+	}))
+
+	var order []string
+	cli.OnRequest(func(req *http.Request) {
+		order = append(order, "request")
+		req.Header.Set("X-Trace", "test")
+	})
+	cli.OnResponse(func(resp *http.Response) {
+		order = append(order, fmt.Sprintf("response %d", resp.StatusCode))
+	})
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request", "response 200"}, order)
+}
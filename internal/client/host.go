@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Host is a host registered with certMgr. Certificates can only be issued
+// for registered hosts.
+type Host struct {
+	ID               int    `json:"id,omitempty"`
+	Hostname         string `json:"hostname"`
+	Owner            string `json:"owner"`
+	ResponsibleGroup string `json:"responsible_egroup,omitempty"`
+}
+
+var ErrNoHost = errors.New("no host found")
+
+func (c *Client) CreateHost(ctx context.Context, host Host) (*Host, error) {
+	hostname, err := NormalizeHostname(host.Hostname)
+	if err != nil {
+		return nil, err
+	}
+	host.Hostname = hostname
+
+	return createObject[Host](ctx, c, "host/", host)
+}
+
+// GetHost returns the host registered as hostname. certMgr also returns
+// hosts whose hostname merely contains the one looked up, which are skipped;
+// when none matches exactly, the error is both ErrNoHost and ErrNotFound.
+func (c *Client) GetHost(ctx context.Context, hostname string) (*Host, error) {
+	hostname, err := NormalizeHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.queryEndpoint("host/", url.Values{"hostname": {hostname}})
+	hosts, err := listAll[Host](ctx, c, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing hosts: %w", err)
+	}
+
+	for _, host := range hosts {
+		if strings.EqualFold(strings.TrimSuffix(host.Hostname, "."), hostname) {
+			return &host, nil
+		}
+	}
+	return nil, fmt.Errorf("%w for %s: %w", ErrNoHost, hostname, ErrNotFound)
+}
+
+func (c *Client) UpdateHost(ctx context.Context, host Host) error {
+	return c.putObject(ctx, fmt.Sprintf("host/%d/", host.ID), host)
+}
+
+func (c *Client) DeleteHost(ctx context.Context, id int) error {
+	url := c.endpoint("host/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for host %d: %w", id, err)
+	}
+	return nil
+}
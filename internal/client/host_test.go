@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHostExactHostname(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"meta": {}, "objects": [
+			{"id": 1, "hostname": "tf-test.cern.ch.example.org", "owner": "alice"},
+			{"id": 2, "hostname": "TF-test.cern.ch.", "owner": "bob"}]}`)
+	}))
+
+	host, err := cli.GetHost(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Equal(t, 2, host.ID)
+
+	_, err = cli.GetHost(context.Background(), "tf-test.cern")
+	require.ErrorIs(t, err, certMgr.ErrNotFound)
+	require.ErrorIs(t, err, certMgr.ErrNoHost)
+}
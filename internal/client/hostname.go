@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// NormalizeHostname converts a hostname to the lower-case ASCII (punycode)
+// form that certMgr stores, so that `bücher.cern.ch` and
+// `xn--bcher-kva.cern.ch` refer to the same host.
+func NormalizeHostname(hostname string) (string, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(hostname), ".")
+	if trimmed == "" {
+		return "", fmt.Errorf("hostname must not be empty")
+	}
+
+	ascii, err := idna.Lookup.ToASCII(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("invalid hostname %q: %w", hostname, err)
+	}
+	return ascii, nil
+}
+
+// normalizeHostnames normalizes hostnames in place.
+func normalizeHostnames(hostnames []string) error {
+	for i, hostname := range hostnames {
+		normalized, err := NormalizeHostname(hostname)
+		if err != nil {
+			return err
+		}
+		hostnames[i] = normalized
+	}
+	return nil
+}
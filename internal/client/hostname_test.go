@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeHostname(t *testing.T) {
+	cases := map[string]string{
+		"bücher.cern.ch":        "xn--bcher-kva.cern.ch",
+		"xn--bcher-kva.cern.ch": "xn--bcher-kva.cern.ch",
+		"Host.CERN.ch.":         "host.cern.ch",
+		" host.cern.ch ":        "host.cern.ch",
+	}
+
+	for in, want := range cases {
+		got, err := certMgr.NormalizeHostname(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got, in)
+	}
+
+	_, err := certMgr.NormalizeHostname("")
+	require.Error(t, err)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRetriesTimeoutWithSameIdempotencyKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		attempt := len(keys)
+		mu.Unlock()
+
+		if attempt == 1 {
+			time.Sleep(300 * time.Millisecond)
+		}
+		fmt.Fprint(w, `{"id": 1, "hostname": "tf-test.cern.ch"}`)
+	}))
+	cli.HTTPClient.Timeout = 100 * time.Millisecond
+	cli.WriteRetryBudget = 5 * time.Second
+
+	cert, err := cli.CreateCertificate(context.Background(), certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	require.Equal(t, 1, cert.ID)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, keys, 2)
+	require.NotEmpty(t, keys[0])
+	require.Equal(t, keys[0], keys[1])
+}
+
+func TestCreateWithoutIdempotencyKeyIsNotRetried(t *testing.T) {
+	attempts := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		require.Empty(t, r.Header.Get("Idempotency-Key"))
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	cli.WriteRetryBudget = 5 * time.Second
+	cli.DisableIdempotencyKeys = true
+
+	_, err := cli.CreateCertificate(context.Background(), certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.ErrorIs(t, err, certMgr.ErrRateLimited)
+	require.Equal(t, 1, attempts)
+}
+
+func TestBulkCreateIsNotRetriedAfterTimeout(t *testing.T) {
+	var mu sync.Mutex
+	patches := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		patches++
+		mu.Unlock()
+		require.Empty(t, r.Header.Get("Idempotency-Key"))
+		time.Sleep(300 * time.Millisecond)
+	}))
+	cli.HTTPClient.Timeout = 100 * time.Millisecond
+	cli.WriteRetryBudget = 5 * time.Second
+
+	_, err := cli.CreateCertificates(context.Background(), []string{"tf-test-1.cern.ch", "tf-test-2.cern.ch"})
+	require.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 1, patches)
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ServiceIdentity is a robot or service account that authenticates to other
+// services with a client certificate issued by certMgr.
+type ServiceIdentity struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name"`
+	OwnerGroup string `json:"owner_egroup"`
+}
+
+var ErrNoServiceIdentity = errors.New("no service identity found")
+
+func (c *Client) CreateServiceIdentity(ctx context.Context, identity ServiceIdentity) (*ServiceIdentity, error) {
+	return createObject[ServiceIdentity](ctx, c, "identity/", identity)
+}
+
+func (c *Client) GetServiceIdentity(ctx context.Context, id int) (*ServiceIdentity, error) {
+	return getObject[ServiceIdentity](ctx, c, fmt.Sprintf("identity/%d/", id), ErrNoServiceIdentity)
+}
+
+func (c *Client) UpdateServiceIdentity(ctx context.Context, identity ServiceIdentity) error {
+	return c.putObject(ctx, fmt.Sprintf("identity/%d/", identity.ID), identity)
+}
+
+// DeleteServiceIdentity removes the identity together with its client
+// certificates.
+func (c *Client) DeleteServiceIdentity(ctx context.Context, id int) error {
+	url := c.endpoint("identity/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for service identity %d: %w", id, err)
+	}
+	return nil
+}
+
+// IssueIdentityCertificate issues a new client certificate for the identity,
+// superseding the previous one. When csr is empty certMgr generates the key
+// pair and returns the private key alongside the certificate.
+func (c *Client) IssueIdentityCertificate(ctx context.Context, id int, csr string) (*Certificate, error) {
+	request := struct {
+		CSR string `json:"csr,omitempty"`
+	}{CSR: csr}
+	return createObject[Certificate](ctx, c, fmt.Sprintf("identity/%d/certificate/", id), request)
+}
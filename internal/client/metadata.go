@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"net/http"
+)
+
+// ModuleMetadata identifies the Terraform module on whose behalf requests are
+// made, so that certMgr can attribute certificates to the modules that
+// manage them.
+type ModuleMetadata struct {
+	Name    string
+	Version string
+}
+
+type moduleMetadataKey struct{}
+
+// WithModuleMetadata returns a copy of ctx whose requests are sent with the
+// X-Terraform-Module and X-Terraform-Module-Version headers of module.
+func WithModuleMetadata(ctx context.Context, module ModuleMetadata) context.Context {
+	return context.WithValue(ctx, moduleMetadataKey{}, module)
+}
+
+// setModuleHeaders sets the headers of the ModuleMetadata attached to ctx,
+// if any.
+func setModuleHeaders(ctx context.Context, header http.Header) {
+	module, ok := ctx.Value(moduleMetadataKey{}).(ModuleMetadata)
+	if !ok {
+		return
+	}
+	if module.Name != "" {
+		header.Set("X-Terraform-Module", module.Name)
+	}
+	if module.Version != "" {
+		header.Set("X-Terraform-Module-Version", module.Version)
+	}
+}
+
+// RunMetadata identifies the Terraform run on whose behalf a client modifies
+// certMgr, so that certMgr's audit trail links each change to the pipeline
+// run that caused it.
+type RunMetadata struct {
+	Workspace string
+	RunID     string
+}
+
+// setRunHeaders sets the X-Terraform-Workspace and X-Terraform-Run-ID headers
+// of the client's RunMetadata.
+func (c *Client) setRunHeaders(header http.Header) {
+	if c.Run.Workspace != "" {
+		header.Set("X-Terraform-Workspace", c.Run.Workspace)
+	}
+	if c.Run.RunID != "" {
+		header.Set("X-Terraform-Run-ID", c.Run.RunID)
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleMetadataHeaders(t *testing.T) {
+	var modules []string
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		modules = append(modules, r.Header.Get("X-Terraform-Module")+"@"+r.Header.Get("X-Terraform-Module-Version"))
+		fmt.Fprint(w, `{"total": 1}`)
+	}))
+
+	ctx := certMgr.WithModuleMetadata(context.Background(), certMgr.ModuleMetadata{Name: "web-frontend", Version: "1.2.0"})
+	_, err := cli.GetStatistics(ctx, certMgr.StatisticsFilter{})
+	require.NoError(t, err)
+
+	_, err = cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"web-frontend@1.2.0", "@"}, modules)
+}
+
+func TestRunMetadataHeaders(t *testing.T) {
+	var runs []string
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs = append(runs, r.Method+" "+r.Header.Get("X-Terraform-Workspace")+"/"+r.Header.Get("X-Terraform-Run-ID"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	cli.Run = certMgr.RunMetadata{Workspace: "production", RunID: "run-CZcmD7eagjhyX0vN"}
+	ctx := context.Background()
+
+	// Only requests modifying certMgr carry the run.
+	_, err := cli.CertificateUnchanged(ctx, 7, `"v1"`)
+	require.NoError(t, err)
+	require.NoError(t, cli.DeleteStaged(ctx, 7))
+
+	require.Equal(t, []string{"HEAD /", "DELETE production/run-CZcmD7eagjhyX0vN"}, runs)
+}
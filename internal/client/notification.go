@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Notification subscribes an e-mail address or webhook to expiry warnings for
+// a certificate or for every certificate of a hostgroup.
+type Notification struct {
+	ID            int    `json:"id,omitempty"`
+	CertificateID int    `json:"certificate_id,omitempty"`
+	Hostgroup     string `json:"hostgroup,omitempty"`
+	Email         string `json:"email,omitempty"`
+	WebhookURL    string `json:"webhook_url,omitempty"`
+	LeadDays      []int  `json:"lead_days"`
+}
+
+var ErrNoNotification = errors.New("no notification found")
+
+func (c *Client) CreateNotification(ctx context.Context, notification Notification) (*Notification, error) {
+	return createObject[Notification](ctx, c, "notification/", notification)
+}
+
+func (c *Client) GetNotification(ctx context.Context, id int) (*Notification, error) {
+	return getObject[Notification](ctx, c, fmt.Sprintf("notification/%d/", id), ErrNoNotification)
+}
+
+func (c *Client) UpdateNotification(ctx context.Context, notification Notification) error {
+	return c.putObject(ctx, fmt.Sprintf("notification/%d/", notification.ID), notification)
+}
+
+func (c *Client) DeleteNotification(ctx context.Context, id int) error {
+	url := c.endpoint("notification/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for notification %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"net/http"
+)
+
+// organizationHeader scopes a request to one organization (VO) of a
+// multi-tenant certMgr instance.
+const organizationHeader = "X-CertMgr-Organization"
+
+type organizationKey struct{}
+
+// WithOrganization returns a copy of ctx whose requests are scoped to the
+// organization org instead of the client's Organization. An empty org keeps
+// the client's.
+func WithOrganization(ctx context.Context, org string) context.Context {
+	return context.WithValue(ctx, organizationKey{}, org)
+}
+
+// organization returns the organization the requests made with ctx are
+// scoped to, empty for the default tenancy of the certMgr instance.
+func (c *Client) organization(ctx context.Context) string {
+	if org, ok := ctx.Value(organizationKey{}).(string); ok && org != "" {
+		return org
+	}
+	return c.Organization
+}
+
+// ownOrganization reports whether the requests made with ctx are scoped to
+// the client's Organization, the only one whose certificate reads are cached.
+func (c *Client) ownOrganization(ctx context.Context) bool {
+	return c.organization(ctx) == c.Organization
+}
+
+// setOrganizationHeader scopes req to the organization of its context.
+func (c *Client) setOrganizationHeader(ctx context.Context, header http.Header) {
+	if org := c.organization(ctx); org != "" {
+		header.Set(organizationHeader, org)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganization(t *testing.T) {
+	var organizations []string
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		organization := r.Header.Get("X-CertMgr-Organization")
+		organizations = append(organizations, organization)
+		fmt.Fprintf(w, `{"id": 7, "hostname": "tf-test.cern.ch", "requestor": %q}`, organization)
+	}))
+	cli.Organization = "atlas"
+	ctx := context.Background()
+
+	for range 2 {
+		cert, err := cli.GetCertificateByID(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, "atlas", cert.Requestor)
+	}
+
+	// Reads scoped to another organization bypass the cache, which holds
+	// the entries of the client's.
+	cms := certMgr.WithOrganization(ctx, "cms")
+	for range 2 {
+		cert, err := cli.GetCertificateByID(cms, 7)
+		require.NoError(t, err)
+		require.Equal(t, "cms", cert.Requestor)
+	}
+
+	cert, err := cli.GetCertificateByID(certMgr.WithOrganization(ctx, ""), 7)
+	require.NoError(t, err)
+	require.Equal(t, "atlas", cert.Requestor)
+	require.Equal(t, []string{"atlas", "cms", "cms"}, organizations)
+}
+
+func TestConditionalGetPerOrganization(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		organization := r.Header.Get("X-CertMgr-Organization")
+		etag := fmt.Sprintf("%q", organization)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		require.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"total": %d}`, len(organization))
+	}))
+	ctx := context.Background()
+
+	for range 2 {
+		for organization, total := range map[string]int{"atlas": 5, "lhcb": 4} {
+			stats, err := cli.GetStatistics(certMgr.WithOrganization(ctx, organization), certMgr.StatisticsFilter{})
+			require.NoError(t, err)
+			require.Equal(t, total, stats.Total, organization)
+		}
+	}
+}
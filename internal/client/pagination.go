@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// listAll fetches a Tastypie list endpoint and follows meta.next until every
+// page has been read, returning the objects of all pages in order.
+func listAll[T any](ctx context.Context, c *Client, url string) ([]T, error) {
+	var objects []T
+	err := eachObject(ctx, c, url, func(object T) error {
+		objects = append(objects, object)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// eachObject passes the objects of every page of a Tastypie list endpoint
+// to fn, decoding one page at a time so that large listings are never
+// buffered as a whole. A page is only handed to fn once it was read
+// completely, as a page whose body timed out is requested again.
+func eachObject[T any](ctx context.Context, c *Client, url string, fn func(T) error) error {
+	var page []T
+	for url != "" {
+		var next string
+		err := c.doStream(ctx, url, func(body io.Reader) error {
+			var err error
+			dec := json.NewDecoder(body)
+			if c.StrictDecoding {
+				dec.DisallowUnknownFields()
+			}
+			page = page[:0]
+			next, err = decodeListPage(dec, func(object T) error {
+				page = append(page, object)
+				return nil
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, object := range page {
+			if err := fn(object); err != nil {
+				return err
+			}
+		}
+
+		next = c.pageURL(next)
+		if next == url {
+			return fmt.Errorf("pagination did not advance past %s", url)
+		}
+		url = next
+	}
+	return nil
+}
+
+// decodeListPage decodes a {"meta": {...}, "objects": [...]} page token by
+// token, passing each object to fn, and returns the meta.next link. Pages of
+// the v2 API, shaped {"next": ..., "results": [...]}, are decoded alike.
+func decodeListPage[T any](dec *json.Decoder, fn func(T) error) (string, error) {
+	var next string
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return "", err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("unmarshal failed: %w", err)
+		}
+
+		switch token {
+		case "meta":
+			// Only meta.next is of interest, so the rest of meta is never
+			// decoded strictly.
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return "", fmt.Errorf("unmarshal failed: %w", err)
+			}
+			var meta struct {
+				Next string `json:"next"`
+			}
+			if err := json.Unmarshal(raw, &meta); err != nil {
+				return "", fmt.Errorf("unmarshal failed: %w", err)
+			}
+			next = meta.Next
+		case "next":
+			var link *string
+			if err := dec.Decode(&link); err != nil {
+				return "", fmt.Errorf("unmarshal failed: %w", err)
+			}
+			if link != nil {
+				next = *link
+			}
+		case "objects", "results":
+			if err := expectDelim(dec, '['); err != nil {
+				return "", err
+			}
+			for dec.More() {
+				var object T
+				if err := dec.Decode(&object); err != nil {
+					return "", fmt.Errorf("unmarshal failed: %w", err)
+				}
+				if err := fn(object); err != nil {
+					return "", err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return "", err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return "", fmt.Errorf("unmarshal failed: %w", err)
+			}
+		}
+	}
+	return next, expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("unmarshal failed: %w", err)
+	}
+	if token != want {
+		return fmt.Errorf("unmarshal failed: expected %v, got %v", want, token)
+	}
+	return nil
+}
+
+// pageURL turns the meta.next link of a list response, which certMgr reports
+// relative to the server, into an absolute URL.
+func (c *Client) pageURL(next string) string {
+	if next == "" || strings.HasPrefix(next, "https://") || strings.HasPrefix(next, "http://") {
+		return next
+	}
+	return c.origin() + next
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client talking to handler over TLS without
+// Kerberos.
+func newTestClient(t *testing.T, handler http.Handler) *certMgr.Client {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+
+	return &certMgr.Client{
+		HTTPClient: spnego.NewClient(nil, server.Client(), ""),
+		Host:       u.Hostname(),
+		Port:       port,
+	}
+}
+
+func TestListStagedFollowsPages(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "tf-test.cern.ch", r.URL.Query().Get("hostname"))
+
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"meta": {"next": "/krb/certmgr/staged/?hostname=tf-test.cern.ch&limit=2&offset=2"},
+				"objects": [{"id": 1, "hostname": "tf-test.cern.ch"}, {"id": 2, "hostname": "tf-test.cern.ch"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"meta": {"next": null}, "objects": [{"id": 3, "hostname": "tf-test.cern.ch"}]}`)
+		default:
+			t.Errorf("unexpected page %s", r.URL)
+		}
+	}))
+
+	staged, err := cli.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Len(t, staged, 3)
+
+	latest, err := cli.GetCertificate(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Equal(t, 3, latest.ID)
+}
+
+func TestListStagedRetriesTruncatedPage(t *testing.T) {
+	var attempts atomic.Int32
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Send the first object, then stall until the client times out.
+			fmt.Fprint(w, `{"meta": {"next": null}, "objects": [{"id": 1, "hostname": "tf-test.cern.ch"}, `)
+			w.(http.Flusher).Flush()
+			time.Sleep(300 * time.Millisecond)
+			return
+		}
+		fmt.Fprint(w, `{"meta": {"next": null}, "objects": [{"id": 1, "hostname": "tf-test.cern.ch"}, {"id": 2, "hostname": "tf-test.cern.ch"}]}`)
+	}))
+	cli.HTTPClient.Timeout = 100 * time.Millisecond
+	cli.RetryBudget = 5 * time.Second
+
+	staged, err := cli.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), attempts.Load())
+	require.Len(t, staged, 2)
+}
+
+func TestListCertificatesFilter(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		require.Equal(t, "db-", query.Get("hostname__startswith"))
+		require.Equal(t, "alice", query.Get("requestor"))
+		require.Equal(t, "prod", query.Get("tags__env"))
+		require.Equal(t, "2025-01-01T00:00:00Z", query.Get("start__gte"))
+		require.Empty(t, query.Get("start__lte"))
+		fmt.Fprint(w, `{"meta": {}, "objects": [{"id": 1}]}`)
+	}))
+
+	certs, err := cli.ListCertificates(context.Background(), certMgr.CertificateFilter{
+		HostnamePrefix: "DB-",
+		Requestor:      "alice",
+		Tags:           map[string]string{"env": "prod"},
+		StartAfter:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+}
+
+func TestListStagedStreamsAnyKeyOrder(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"objects": [{"id": 1, "hostname": "tf-test.cern.ch"}, {"id": 2, "hostname": "tf-test.cern.ch"}],
+			"extra": {"nested": [1, 2]}, "meta": {"next": null, "total_count": 2}}`)
+	}))
+
+	staged, err := cli.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Len(t, staged, 2)
+	require.Equal(t, "tf-test.cern.ch", staged[0].Hostname)
+
+	broken := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"objects": [{"id": 1}`)
+	}))
+	_, err = broken.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryParametersAreEncoded(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, []string{"CERN Grid & Root"}, r.URL.Query()["name"])
+		fmt.Fprint(w, `{"objects": [{"name": "CERN Grid & Root"}]}`)
+	}))
+
+	_, err := cli.GetCertificateAuthority(context.Background(), "CERN Grid & Root")
+	require.NoError(t, err)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReauthenticateOnUnauthorized(t *testing.T) {
+	authenticated := false
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authenticated {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"total": 1}`)
+	}))
+
+	reauths := 0
+	cli.Reauthenticate = func(context.Context) (*spnego.Client, error) {
+		reauths++
+		authenticated = true
+		return cli.HTTPClient, nil
+	}
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 1, reauths)
+}
+
+func TestReauthenticateRetriesOnce(t *testing.T) {
+	attempts := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	cli.Reauthenticate = func(context.Context) (*spnego.Client, error) {
+		return cli.HTTPClient, nil
+	}
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.ErrorIs(t, err, certMgr.ErrUnauthorized)
+	require.Equal(t, 2, attempts)
+}
+
+func TestReauthenticateFailure(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	expired := errors.New("credential cache expired")
+	cli.Reauthenticate = func(context.Context) (*spnego.Client, error) {
+		return nil, expired
+	}
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.ErrorIs(t, err, certMgr.ErrUnauthorized)
+	require.ErrorIs(t, err, expired)
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// endpoint returns the URL of an API path, formatted with args, below the
+// certMgr API root.
+func (c *Client) endpoint(format string, args ...any) string {
+	return c.origin() + apiRootV1 + fmt.Sprintf(format, args...)
+}
+
+// queryEndpoint returns the URL of an API path with query appended.
+func (c *Client) queryEndpoint(path string, query url.Values) string {
+	endpoint := c.endpoint("%s", path)
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	return endpoint
+}
+
+// createObject POSTs object to the collection at path and returns the
+// object certMgr created.
+func createObject[T any](ctx context.Context, c *Client, path string, object any) (*T, error) {
+	payload, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("marshal failed: %w", err)
+	}
+
+	body, _, err := c.doRequest(ctx, http.MethodPost, c.endpoint("%s", path), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var created T
+	if err := c.decodeJSON(body, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// getObject fetches the object at path, returning notFound when it does not
+// exist.
+func getObject[T any](ctx context.Context, c *Client, path string, notFound error) (*T, error) {
+	body, _, err := c.doRequest(ctx, http.MethodGet, c.endpoint("%s", path), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var object T
+	if err := c.decodeJSON(body, &object); err != nil {
+		return nil, err
+	}
+	return &object, nil
+}
+
+// decodeJSON unmarshals body into v. With StrictDecoding, fields that v does
+// not know are rejected, so that schema drift between certMgr and the client
+// surfaces as an error instead of silently dropped data.
+func (c *Client) decodeJSON(body []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if c.StrictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("unmarshal failed: %w", err)
+	}
+	return nil
+}
+
+// putObject replaces the object at path with object.
+func (c *Client) putObject(ctx context.Context, path string, object any) error {
+	payload, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+
+	_, _, err = c.doRequest(ctx, http.MethodPut, c.endpoint("%s", path), payload)
+	return err
+}
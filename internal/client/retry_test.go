@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	attempts := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"total": 7}`)
+	}))
+	cli.RetryBudget = time.Second
+
+	stats, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 7, stats.Total)
+	require.Equal(t, 3, attempts)
+}
+
+func TestRetryAfterExceedsBudget(t *testing.T) {
+	attempts := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	cli.RetryBudget = time.Minute
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetryBudgetPerOperation(t *testing.T) {
+	attempts := map[string]int{}
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.Method]++
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	cli.RetryBudget = time.Second
+	cli.WriteRetryBudget = 0
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.ErrorIs(t, err, certMgr.ErrRateLimited)
+	require.Greater(t, attempts[http.MethodGet], 1)
+
+	err = cli.DeleteStaged(context.Background(), 1)
+	require.ErrorIs(t, err, certMgr.ErrRateLimited)
+	require.Equal(t, 1, attempts[http.MethodDelete])
+}
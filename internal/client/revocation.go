@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Revocation is a revocation request for a certificate, identified either by
+// serial number or by certMgr certificate ID.
+type Revocation struct {
+	ID            int    `json:"id,omitempty"`
+	Serial        string `json:"serial,omitempty"`
+	CertificateID int    `json:"certificate_id,omitempty"`
+	Reason        string `json:"reason"`
+	RevokedAt     string `json:"revoked_at,omitempty"`
+}
+
+// RevocationReasons are the CRL reason codes from RFC 5280 accepted by
+// certMgr.
+var RevocationReasons = []string{
+	"unspecified",
+	"keyCompromise",
+	"cACompromise",
+	"affiliationChanged",
+	"superseded",
+	"cessationOfOperation",
+	"certificateHold",
+	"privilegeWithdrawn",
+	"aACompromise",
+}
+
+var ErrNoRevocation = errors.New("no revocation found")
+
+func (c *Client) CreateRevocation(ctx context.Context, revocation Revocation) (*Revocation, error) {
+	if revocation.Serial == "" && revocation.CertificateID == 0 {
+		return nil, fmt.Errorf("either serial or certificate ID is required to revoke a certificate")
+	}
+	if revocation.Reason == "" {
+		revocation.Reason = "unspecified"
+	}
+	if !slices.Contains(RevocationReasons, revocation.Reason) {
+		return nil, fmt.Errorf("invalid revocation reason %q", revocation.Reason)
+	}
+	defer c.certificates.invalidateAll()
+	defer c.revocations.invalidate()
+
+	return createObject[Revocation](ctx, c, "revocation/", revocation)
+}
+
+// RevokeCertificate revokes the certificate with the given serial number. An
+// empty reason is sent as "unspecified". A certificate that certMgr refuses
+// to revoke with 409 Conflict because it already is returns its earliest
+// revocation, whatever its reason.
+func (c *Client) RevokeCertificate(ctx context.Context, serial, reason string) (*Revocation, error) {
+	if serial == "" {
+		return nil, fmt.Errorf("serial is required to revoke a certificate")
+	}
+
+	revocation, err := c.CreateRevocation(ctx, Revocation{Serial: serial, Reason: reason})
+	if !errors.Is(err, ErrConflict) {
+		return revocation, err
+	}
+
+	existing, listErr := c.ListRevocations(ctx, serial)
+	if listErr != nil || len(existing) == 0 {
+		return nil, err
+	}
+	return &existing[0], nil
+}
+
+func (c *Client) GetRevocation(ctx context.Context, id int) (*Revocation, error) {
+	return getObject[Revocation](ctx, c, fmt.Sprintf("revocation/%d/", id), ErrNoRevocation)
+}
+
+// ListRevocations returns the revocations of the certificate with the given
+// serial number, none if it was not revoked.
+func (c *Client) ListRevocations(ctx context.Context, serial string) ([]Revocation, error) {
+	if serial == "" {
+		return nil, fmt.Errorf("serial is required to list revocations")
+	}
+	revocations, err := listAll[Revocation](ctx, c, c.queryEndpoint("revocation/", url.Values{"serial": {serial}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed listing revocations: %w", err)
+	}
+	return revocations, nil
+}
+
+// revocationCache remembers the serials certMgr revoked, by organization, for
+// the lifetime of the client, one Terraform operation, so that refreshing many
+// certificates lists the revocations once rather than once per certificate.
+// Revocations made through the client invalidate it. Concurrent lookups in
+// the same organization share one listing.
+type revocationCache struct {
+	mu             sync.Mutex
+	byOrganization map[string]*revocationLookup
+}
+
+type revocationLookup struct {
+	done    chan struct{}
+	serials map[string]bool
+	err     error
+}
+
+func (r *revocationCache) invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byOrganization = nil
+}
+
+// CertificateRevoked reports whether certMgr revoked the certificate with the
+// given serial number. Successful listings are cached; failed ones are
+// retried by the next call.
+func (c *Client) CertificateRevoked(ctx context.Context, serial string) (bool, error) {
+	if serial == "" {
+		return false, fmt.Errorf("serial is required to look up revocations")
+	}
+
+	org := c.organization(ctx)
+	cache := &c.revocations
+	cache.mu.Lock()
+	lookup, ok := cache.byOrganization[org]
+	if !ok {
+		lookup = &revocationLookup{done: make(chan struct{})}
+		if cache.byOrganization == nil {
+			cache.byOrganization = map[string]*revocationLookup{}
+		}
+		cache.byOrganization[org] = lookup
+	}
+	cache.mu.Unlock()
+
+	if !ok {
+		lookup.serials = map[string]bool{}
+		err := eachObject(ctx, c, c.endpoint("revocation/"), func(revocation Revocation) error {
+			lookup.serials[strings.ToLower(revocation.Serial)] = true
+			return nil
+		})
+		if err != nil {
+			cache.mu.Lock()
+			if cache.byOrganization[org] == lookup {
+				delete(cache.byOrganization, org)
+			}
+			cache.mu.Unlock()
+			lookup.err = fmt.Errorf("failed listing revocations: %w", err)
+		}
+		close(lookup.done)
+	}
+
+	select {
+	case <-lookup.done:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+	if lookup.err != nil {
+		return false, lookup.err
+	}
+	return lookup.serials[strings.ToLower(serial)], nil
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeCertificate(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
+
+	cert, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+
+	revocation, err := cli.RevokeCertificate(ctx, cert.Serial, "")
+	require.NoError(t, err)
+	require.Equal(t, "unspecified", revocation.Reason)
+	require.NotEmpty(t, revocation.RevokedAt)
+
+	read, err := cli.GetRevocation(ctx, revocation.ID)
+	require.NoError(t, err)
+	require.Equal(t, cert.Serial, read.Serial)
+
+	again, err := cli.RevokeCertificate(ctx, cert.Serial, "keyCompromise")
+	require.NoError(t, err)
+	require.Equal(t, *revocation, *again)
+
+	_, err = cli.RevokeCertificate(ctx, cert.Serial, "stolen")
+	require.ErrorContains(t, err, "invalid revocation reason")
+
+	_, err = cli.RevokeCertificate(ctx, "", "keyCompromise")
+	require.Error(t, err)
+
+	_, err = cli.RevokeCertificate(ctx, "DEADBEEF", "keyCompromise")
+	require.ErrorIs(t, err, certMgr.ErrNotFound)
+}
+
+func TestRevokeCertificateOnlyRecoversFromConflict(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta": {"next": null}, "objects": [{"id": 1, "serial": "0a", "reason": "unspecified"}]}`))
+	}))
+
+	_, err := cli.RevokeCertificate(context.Background(), "0a", "")
+	require.ErrorIs(t, err, certMgr.ErrUnauthorized)
+}
+
+func TestListRevocations(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
+
+	cert, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+
+	revocations, err := cli.ListRevocations(ctx, cert.Serial)
+	require.NoError(t, err)
+	require.Empty(t, revocations)
+
+	_, err = cli.CreateRevocation(ctx, certMgr.Revocation{CertificateID: cert.ID, Reason: "keyCompromise"})
+	require.NoError(t, err)
+
+	revocations, err = cli.ListRevocations(ctx, cert.Serial)
+	require.NoError(t, err)
+	require.Len(t, revocations, 1)
+	require.Equal(t, "keyCompromise", revocations[0].Reason)
+}
+
+func TestCertificateRevoked(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
+
+	listings := 0
+	cli.OnRequest(func(req *http.Request) {
+		if req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/revocation/") {
+			listings++
+		}
+	})
+
+	first, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test-1.cern.ch"})
+	require.NoError(t, err)
+	second, err := cli.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test-2.cern.ch"})
+	require.NoError(t, err)
+	_, err = cli.RevokeCertificate(ctx, first.Serial, "")
+	require.NoError(t, err)
+	listings = 0
+
+	for range 3 {
+		revoked, err := cli.CertificateRevoked(ctx, strings.ToUpper(first.Serial))
+		require.NoError(t, err)
+		require.True(t, revoked)
+		revoked, err = cli.CertificateRevoked(ctx, second.Serial)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	}
+	require.Equal(t, 1, listings)
+
+	// Revoking through the client lists the revocations again.
+	_, err = cli.RevokeCertificate(ctx, second.Serial, "")
+	require.NoError(t, err)
+	revoked, err := cli.CertificateRevoked(ctx, second.Serial)
+	require.NoError(t, err)
+	require.True(t, revoked)
+	require.Equal(t, 2, listings)
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSocketClient(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "certmgr.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/krb/certmgr/staged/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"meta": {}, "objects": [{"id": 1, "hostname": "tf-test.cern.ch"}]}`)
+	})}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+
+	cli, err := certMgr.NewUnixSocketClient(socket)
+	require.NoError(t, err)
+
+	staged, err := cli.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Len(t, staged, 1)
+
+	_, err = certMgr.NewUnixSocketClient("")
+	require.Error(t, err)
+}
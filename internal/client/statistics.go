@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Statistics holds aggregate certificate counts for a requestor, a
+// hostgroup or the whole service.
+type Statistics struct {
+	Total           int `json:"total"`
+	IssuedThisMonth int `json:"issued_this_month"`
+	ExpiringSoon    int `json:"expiring_30_days"`
+}
+
+// StatisticsFilter scopes GetStatistics. At most one field should be set;
+// an empty filter returns service-wide counts.
+type StatisticsFilter struct {
+	Requestor string
+	Hostgroup string
+}
+
+func (c *Client) GetStatistics(ctx context.Context, filter StatisticsFilter) (*Statistics, error) {
+	query := url.Values{}
+	if filter.Requestor != "" {
+		query.Set("requestor", filter.Requestor)
+	}
+	if filter.Hostgroup != "" {
+		query.Set("hostgroup", filter.Hostgroup)
+	}
+
+	body, _, err := c.doRequest(ctx, http.MethodGet, c.queryEndpoint("statistics/", query), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats Statistics
+	if err := c.decodeJSON(body, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
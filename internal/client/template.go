@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Template is an issuance profile constraining the certificates certMgr
+// issues with it.
+type Template struct {
+	ID                 int      `json:"id,omitempty"`
+	Name               string   `json:"name"`
+	Description        string   `json:"description,omitempty"`
+	KeyAlgorithm       string   `json:"key_algorithm,omitempty"`
+	MinKeyBits         int      `json:"min_key_bits,omitempty"`
+	MaxValidityDays    int      `json:"max_validity_days,omitempty"`
+	AllowedSANPatterns []string `json:"allowed_san_patterns,omitempty"`
+}
+
+var ErrNoTemplate = errors.New("no template found")
+
+func (c *Client) CreateTemplate(ctx context.Context, template Template) (*Template, error) {
+	return createObject[Template](ctx, c, "template/", template)
+}
+
+func (c *Client) GetTemplate(ctx context.Context, id int) (*Template, error) {
+	return getObject[Template](ctx, c, fmt.Sprintf("template/%d/", id), ErrNoTemplate)
+}
+
+func (c *Client) UpdateTemplate(ctx context.Context, template Template) error {
+	return c.putObject(ctx, fmt.Sprintf("template/%d/", template.ID), template)
+}
+
+func (c *Client) DeleteTemplate(ctx context.Context, id int) error {
+	url := c.endpoint("template/%d/", id)
+	if _, _, err := c.doRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return fmt.Errorf("delete failed for template %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+)
+
+// APIToken is a short-lived bearer token for the certMgr API, obtained
+// through the Kerberos authenticated session of the client.
+type APIToken struct {
+	Token   string `json:"token"`
+	Expires string `json:"expires"`
+}
+
+// CreateAPIToken issues a token valid for lifetime. certMgr may cap the
+// lifetime; the returned Expires is authoritative.
+func (c *Client) CreateAPIToken(ctx context.Context, lifetime time.Duration) (*APIToken, error) {
+	request := map[string]int64{"lifetime": int64(lifetime.Seconds())}
+	return createObject[APIToken](ctx, c, "token/", request)
+}
+
+// TokenSource obtains a bearer token for certMgr and the time it expires. A
+// zero expiry means the token is used until certMgr rejects it.
+type TokenSource func(ctx context.Context) (token string, expires time.Time, err error)
+
+// tokenRefreshMargin is how long before its expiry a cached token is
+// replaced, so that it cannot lapse while a request is in flight.
+const tokenRefreshMargin = time.Minute
+
+// tokenCache holds the current token of a TokenSource.
+type tokenCache struct {
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// get returns the cached token, fetching a new one from source when there is
+// none or it expires within tokenRefreshMargin.
+func (t *tokenCache) get(ctx context.Context, source TokenSource, now time.Time) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && (t.expires.IsZero() || now.Add(tokenRefreshMargin).Before(t.expires)) {
+		return t.token, nil
+	}
+
+	token, expires, err := source(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain token: %w", err)
+	}
+	t.token, t.expires = token, expires
+	return token, nil
+}
+
+// invalidate drops the cached token, after certMgr rejected it.
+func (t *tokenCache) invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token, t.expires = "", time.Time{}
+}
+
+// NewTokenClient returns a client authenticating with bearer tokens from
+// source instead of Kerberos.
+func NewTokenClient(host string, port int, source TokenSource) (*Client, error) {
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid port: %q", port)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("token source is required")
+	}
+
+	return &Client{
+		HTTPClient:       spnego.NewClient(nil, nil, ""),
+		Host:             host,
+		Port:             port,
+		TokenSource:      source,
+		RetryBudget:      DefaultRetryBudget,
+		WriteRetryBudget: DefaultRetryBudget,
+		BreakerThreshold: DefaultBreakerThreshold,
+		NegotiateVersion: true,
+	}, nil
+}
+
+// OIDCClientCredentials returns a TokenSource obtaining access tokens from
+// the OpenID Connect token endpoint at tokenURL with the client credentials
+// grant.
+func OIDCClientCredentials(httpClient *http.Client, tokenURL, clientID, clientSecret string) TokenSource {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return func(ctx context.Context) (string, time.Time, error) {
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+
+		requested := time.Now()
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", time.Time{}, newStatusError(http.MethodPost, tokenURL, resp.StatusCode, body)
+		}
+
+		var token struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int64  `json:"expires_in"`
+		}
+		if err := json.Unmarshal(body, &token); err != nil {
+			return "", time.Time{}, fmt.Errorf("unmarshal failed: %w", err)
+		}
+		if token.AccessToken == "" {
+			return "", time.Time{}, fmt.Errorf("token endpoint returned no access token")
+		}
+
+		var expires time.Time
+		if token.ExpiresIn > 0 {
+			expires = requested.Add(time.Duration(token.ExpiresIn) * time.Second)
+		}
+		return token.AccessToken, expires, nil
+	}
+}
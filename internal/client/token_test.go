@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenCachedUntilShortlyBeforeExpiry(t *testing.T) {
+	var seen []string
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"total": 1}`)
+	}))
+
+	issued := 0
+	lifetimes := []time.Duration{30 * time.Second, time.Hour}
+	cli.TokenSource = func(context.Context) (string, time.Time, error) {
+		lifetime := lifetimes[min(issued, len(lifetimes)-1)]
+		issued++
+		return fmt.Sprintf("token-%d", issued), time.Now().Add(lifetime), nil
+	}
+
+	for range 3 {
+		_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+		require.NoError(t, err)
+	}
+
+	// The first token expires within the refresh margin and is replaced right
+	// away; the second one is reused.
+	require.Equal(t, []string{"Bearer token-1", "Bearer token-2", "Bearer token-2"}, seen)
+	require.Equal(t, 2, issued)
+}
+
+func TestTokenRefreshedOnUnauthorized(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"total": 1}`)
+	}))
+
+	issued := 0
+	cli.TokenSource = func(context.Context) (string, time.Time, error) {
+		issued++
+		return fmt.Sprintf("token-%d", issued), time.Time{}, nil
+	}
+
+	_, err := cli.GetStatistics(context.Background(), certMgr.StatisticsFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 2, issued)
+}
+
+func TestOIDCClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "abc", "token_type": "Bearer", "expires_in": 300}`)
+	}))
+	defer server.Close()
+
+	token, expires, err := certMgr.OIDCClientCredentials(nil, server.URL, "terraform", "secret")(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "abc", token)
+	require.WithinDuration(t, time.Now().Add(5*time.Minute), expires, 5*time.Second)
+
+	_, _, err = certMgr.OIDCClientCredentials(nil, server.URL, "terraform", "wrong")(context.Background())
+	require.ErrorIs(t, err, certMgr.ErrUnauthorized)
+	require.ErrorContains(t, err, "invalid_client")
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// APIVersion is a certMgr API version.
+type APIVersion struct {
+	Major int
+	Minor int
+}
+
+func (v APIVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Less reports whether v is older than other.
+func (v APIVersion) Less(other APIVersion) bool {
+	return v.Major < other.Major || v.Major == other.Major && v.Minor < other.Minor
+}
+
+// ParseAPIVersion parses a "major.minor" version. A patch level, if any, is
+// ignored.
+func ParseAPIVersion(value string) (APIVersion, error) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(value), "v"), ".", 3)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return APIVersion{}, fmt.Errorf("invalid API version %q", value)
+	}
+	minor := 0
+	if len(parts) > 1 {
+		if minor, err = strconv.Atoi(parts[1]); err != nil {
+			return APIVersion{}, fmt.Errorf("invalid API version %q", value)
+		}
+	}
+	return APIVersion{Major: major, Minor: minor}, nil
+}
+
+var (
+	// MinimumAPIVersion is the oldest certMgr API the client supports.
+	MinimumAPIVersion = APIVersion{Major: 1, Minor: 0}
+
+	// legacyAPIVersion is assumed for servers predating the version endpoint.
+	legacyAPIVersion = APIVersion{Major: 1, Minor: 0}
+)
+
+// ErrUnsupportedAPIVersion is returned when certMgr is older than
+// MinimumAPIVersion.
+var ErrUnsupportedAPIVersion = errors.New("unsupported certMgr API version")
+
+const (
+	apiRootV1 = "/krb/certmgr/"
+	apiRootV2 = "/krb/certmgr/v2/"
+)
+
+type versionNegotiator struct {
+	mu      sync.Mutex
+	known   bool
+	version APIVersion
+}
+
+// ServerVersion returns the API version of certMgr, probing it on first use.
+// Servers without a version endpoint are reported as version 1.0.
+func (c *Client) ServerVersion(ctx context.Context) (APIVersion, error) {
+	c.versions.mu.Lock()
+	defer c.versions.mu.Unlock()
+
+	if c.versions.known {
+		return c.versions.version, nil
+	}
+
+	// The probe goes through send rather than do, so it neither recurses into
+	// negotiation nor is rewritten to a versioned root. Refused credentials
+	// are renewed once, as do would.
+	ctx = probeContext(ctx)
+	url := c.origin() + apiRootV1 + "version/"
+	body, status, _, err := c.send(ctx, http.MethodGet, url, nil, "", nil)
+	if err == nil && status == http.StatusUnauthorized && c.canRenewCredentials() {
+		if err := c.renewCredentials(ctx); err != nil {
+			return APIVersion{}, fmt.Errorf("failed probing API version: %w: %w", newStatusError(http.MethodGet, url, status, body), err)
+		}
+		body, status, _, err = c.send(ctx, http.MethodGet, url, nil, "", nil)
+	}
+	if err != nil {
+		return APIVersion{}, fmt.Errorf("failed probing API version: %w", err)
+	}
+
+	version := legacyAPIVersion
+	switch {
+	case status == http.StatusNotFound:
+	case status >= 200 && status <= 299:
+		var payload struct {
+			Version string `json:"version"`
+		}
+		if err := c.decodeJSON(body, &payload); err != nil {
+			return APIVersion{}, fmt.Errorf("failed probing API version: %w", err)
+		}
+		if version, err = ParseAPIVersion(payload.Version); err != nil {
+			return APIVersion{}, fmt.Errorf("failed probing API version: %w", err)
+		}
+	default:
+		return APIVersion{}, fmt.Errorf("failed probing API version: %w", newStatusError(http.MethodGet, url, status, body))
+	}
+
+	if version.Less(MinimumAPIVersion) {
+		return APIVersion{}, fmt.Errorf("%w: server speaks %s, the provider requires at least %s", ErrUnsupportedAPIVersion, version, MinimumAPIVersion)
+	}
+
+	c.versions.version = version
+	c.versions.known = true
+	return version, nil
+}
+
+// probeContext drops the options ctx carries for the request that triggered
+// the probe, such as the validator of CertificateUnchanged, which must not
+// apply to the probe itself.
+func probeContext(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, ifNoneMatchKey{}, nil)
+	return context.WithValue(ctx, createsEntriesKey{}, nil)
+}
+
+// negotiate probes the API version when NegotiateVersion is set and moves url
+// below the root of that version. Endpoints are built against the v1 root,
+// which v2 servers serve below /v2/.
+func (c *Client) negotiate(ctx context.Context, url string) (string, error) {
+	if !c.NegotiateVersion {
+		return url, nil
+	}
+
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	if version.Major < 2 || strings.Contains(url, apiRootV2) {
+		return url, nil
+	}
+	return strings.Replace(url, apiRootV1, apiRootV2, 1), nil
+}
+
+// apiRoot returns the path of the API root of the negotiated version, for
+// resource URIs sent in request bodies.
+func (c *Client) apiRoot() string {
+	c.versions.mu.Lock()
+	defer c.versions.mu.Unlock()
+
+	if c.NegotiateVersion && c.versions.known && c.versions.version.Major >= 2 {
+		return apiRootV2
+	}
+	return apiRootV1
+}
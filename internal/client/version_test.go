@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package certMgr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateV2(t *testing.T) {
+	probes := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/krb/certmgr/version/":
+			probes++
+			fmt.Fprint(w, `{"version": "2.1.3"}`)
+		case "/krb/certmgr/v2/staged/":
+			if r.URL.Query().Get("offset") == "" {
+				fmt.Fprint(w, `{"count": 2, "next": "/krb/certmgr/v2/staged/?hostname=tf-test.cern.ch&offset=1", "results": [{"id": 1, "hostname": "tf-test.cern.ch"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"count": 2, "next": null, "results": [{"id": 2, "hostname": "tf-test.cern.ch"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	cli.NegotiateVersion = true
+	ctx := context.Background()
+
+	staged, err := cli.ListStaged(ctx, "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Len(t, staged, 2)
+
+	version, err := cli.ServerVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, certMgr.APIVersion{Major: 2, Minor: 1}, version)
+	require.Equal(t, 1, probes)
+}
+
+func TestNegotiateReauthenticates(t *testing.T) {
+	authenticated := false
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authenticated {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"version": "1.4"}`)
+	}))
+	cli.NegotiateVersion = true
+
+	reauths := 0
+	cli.Reauthenticate = func(context.Context) (*spnego.Client, error) {
+		reauths++
+		authenticated = true
+		return cli.HTTPClient, nil
+	}
+
+	version, err := cli.ServerVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, certMgr.APIVersion{Major: 1, Minor: 4}, version)
+	require.Equal(t, 1, reauths)
+}
+
+func TestNegotiateProbeIsNotConditional(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/krb/certmgr/version/" {
+			if r.Header.Get("If-None-Match") != "" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			fmt.Fprint(w, `{"version": "1.4"}`)
+			return
+		}
+		require.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	cli.NegotiateVersion = true
+
+	unchanged, err := cli.CertificateUnchanged(context.Background(), 1, `"v1"`)
+	require.NoError(t, err)
+	require.True(t, unchanged)
+}
+
+func TestNegotiateLegacyServer(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/krb/certmgr/staged/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"meta": {}, "objects": [{"id": 1, "hostname": "tf-test.cern.ch"}]}`)
+	}))
+	cli.NegotiateVersion = true
+
+	staged, err := cli.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.NoError(t, err)
+	require.Len(t, staged, 1)
+}
+
+func TestNegotiateUnsupportedVersion(t *testing.T) {
+	requests := 0
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"version": "0.9"}`)
+	}))
+	cli.NegotiateVersion = true
+
+	_, err := cli.ListStaged(context.Background(), "tf-test.cern.ch")
+	require.ErrorIs(t, err, certMgr.ErrUnsupportedAPIVersion)
+	require.ErrorContains(t, err, "server speaks 0.9")
+	require.Equal(t, 1, requests)
+}
+
+func TestParseAPIVersion(t *testing.T) {
+	for value, want := range map[string]certMgr.APIVersion{
+		"1":      {Major: 1},
+		"v2.3":   {Major: 2, Minor: 3},
+		"2.10.4": {Major: 2, Minor: 10},
+	} {
+		got, err := certMgr.ParseAPIVersion(value)
+		require.NoError(t, err, value)
+		require.Equal(t, want, got)
+	}
+
+	_, err := certMgr.ParseAPIVersion("latest")
+	require.Error(t, err)
+}
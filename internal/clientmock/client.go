@@ -0,0 +1,552 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package clientmock provides a certMgr.ClientAPI whose operations are
+// supplied per test as functions.
+package clientmock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	certMgr "certMgr/internal/client"
+)
+
+// Client implements certMgr.ClientAPI by calling the function field named
+// after each operation. Operations without a function fail with
+// ErrNotMocked.
+type Client struct {
+	ServerVersionFunc        func(ctx context.Context) (certMgr.APIVersion, error)
+	FIPSModeFunc             func() bool
+	ExternalClientFunc       func() *http.Client
+	ReadErrorsAsWarningsFunc func() bool
+
+	CreateCertificateFunc      func(ctx context.Context, request certMgr.CertificateRequest) (*certMgr.Certificate, error)
+	ListStagedFunc             func(ctx context.Context, hostname string) ([]certMgr.Certificate, error)
+	ListCertificatesFunc       func(ctx context.Context, filter certMgr.CertificateFilter) ([]certMgr.Certificate, error)
+	ListStagedByRequestorFunc  func(ctx context.Context, requestor string) ([]certMgr.Certificate, error)
+	ListStagedByTagsFunc       func(ctx context.Context, selector map[string]string) ([]certMgr.Certificate, error)
+	GetCertificateFunc         func(ctx context.Context, hostname string) (*certMgr.Certificate, error)
+	GetStagedFunc              func(ctx context.Context, hostname string, id int) (*certMgr.Certificate, error)
+	GetCertificateByIDFunc     func(ctx context.Context, id int) (*certMgr.Certificate, error)
+	CertificateUnchangedFunc   func(ctx context.Context, id int, etag string) (bool, error)
+	GetCertificateBySerialFunc func(ctx context.Context, serial string) (*certMgr.Certificate, error)
+	GetIssuedCertificateFunc   func(ctx context.Context, hostname, serial string) (*certMgr.Certificate, error)
+	UpdateCertificateFunc      func(ctx context.Context, update certMgr.CertificateUpdate) error
+	RenewCertificateFunc       func(ctx context.Context, id int) (*certMgr.Certificate, error)
+	DeleteCertificateFunc      func(ctx context.Context, hostname string) error
+	DeleteStagedFunc           func(ctx context.Context, id int) error
+
+	CreateCertificatesFunc     func(ctx context.Context, hostnames []string) ([]certMgr.Certificate, error)
+	ListStagedForHostnamesFunc func(ctx context.Context, hostnames []string) ([]certMgr.Certificate, error)
+	DeleteStagedEntriesFunc    func(ctx context.Context, ids []int) error
+
+	CreateHostFunc func(ctx context.Context, host certMgr.Host) (*certMgr.Host, error)
+	GetHostFunc    func(ctx context.Context, hostname string) (*certMgr.Host, error)
+	UpdateHostFunc func(ctx context.Context, host certMgr.Host) error
+	DeleteHostFunc func(ctx context.Context, id int) error
+
+	CreateACLFunc func(ctx context.Context, acl certMgr.ACL) (*certMgr.ACL, error)
+	GetACLFunc    func(ctx context.Context, id int) (*certMgr.ACL, error)
+	UpdateACLFunc func(ctx context.Context, acl certMgr.ACL) error
+	DeleteACLFunc func(ctx context.Context, id int) error
+
+	CreateTemplateFunc func(ctx context.Context, template certMgr.Template) (*certMgr.Template, error)
+	GetTemplateFunc    func(ctx context.Context, id int) (*certMgr.Template, error)
+	UpdateTemplateFunc func(ctx context.Context, template certMgr.Template) error
+	DeleteTemplateFunc func(ctx context.Context, id int) error
+
+	CreateAutoRenewalPolicyFunc func(ctx context.Context, policy certMgr.AutoRenewalPolicy) (*certMgr.AutoRenewalPolicy, error)
+	GetAutoRenewalPolicyFunc    func(ctx context.Context, id int) (*certMgr.AutoRenewalPolicy, error)
+	UpdateAutoRenewalPolicyFunc func(ctx context.Context, policy certMgr.AutoRenewalPolicy) error
+	DeleteAutoRenewalPolicyFunc func(ctx context.Context, id int) error
+
+	CreateNotificationFunc func(ctx context.Context, notification certMgr.Notification) (*certMgr.Notification, error)
+	GetNotificationFunc    func(ctx context.Context, id int) (*certMgr.Notification, error)
+	UpdateNotificationFunc func(ctx context.Context, notification certMgr.Notification) error
+	DeleteNotificationFunc func(ctx context.Context, id int) error
+
+	CreateRevocationFunc   func(ctx context.Context, revocation certMgr.Revocation) (*certMgr.Revocation, error)
+	GetRevocationFunc      func(ctx context.Context, id int) (*certMgr.Revocation, error)
+	RevokeCertificateFunc  func(ctx context.Context, serial, reason string) (*certMgr.Revocation, error)
+	ListRevocationsFunc    func(ctx context.Context, serial string) ([]certMgr.Revocation, error)
+	CertificateRevokedFunc func(ctx context.Context, serial string) (bool, error)
+
+	GetCertificateAuthorityFunc func(ctx context.Context, name string) (*certMgr.CertificateAuthority, error)
+
+	CreateBindingFunc func(ctx context.Context, binding certMgr.Binding) (*certMgr.Binding, error)
+	GetBindingFunc    func(ctx context.Context, id int) (*certMgr.Binding, error)
+	UpdateBindingFunc func(ctx context.Context, binding certMgr.Binding) error
+	DeleteBindingFunc func(ctx context.Context, id int) error
+
+	CreateDNSAliasFunc func(ctx context.Context, alias certMgr.DNSAlias) (*certMgr.DNSAlias, error)
+	GetDNSAliasFunc    func(ctx context.Context, id int) (*certMgr.DNSAlias, error)
+	ListDNSAliasesFunc func(ctx context.Context, hostname string) ([]certMgr.DNSAlias, error)
+	DeleteDNSAliasFunc func(ctx context.Context, id int) error
+
+	CreateServiceIdentityFunc    func(ctx context.Context, identity certMgr.ServiceIdentity) (*certMgr.ServiceIdentity, error)
+	GetServiceIdentityFunc       func(ctx context.Context, id int) (*certMgr.ServiceIdentity, error)
+	UpdateServiceIdentityFunc    func(ctx context.Context, identity certMgr.ServiceIdentity) error
+	DeleteServiceIdentityFunc    func(ctx context.Context, id int) error
+	IssueIdentityCertificateFunc func(ctx context.Context, id int, csr string) (*certMgr.Certificate, error)
+
+	GetStatisticsFunc func(ctx context.Context, filter certMgr.StatisticsFilter) (*certMgr.Statistics, error)
+
+	CreateAPITokenFunc func(ctx context.Context, lifetime time.Duration) (*certMgr.APIToken, error)
+}
+
+var _ certMgr.ClientAPI = &Client{}
+
+// ErrNotMocked is returned by operations of Client that have no function set.
+var ErrNotMocked = fmt.Errorf("operation not mocked")
+
+func notMocked(name string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, name)
+}
+
+func (m *Client) ServerVersion(ctx context.Context) (certMgr.APIVersion, error) {
+	if m.ServerVersionFunc == nil {
+		return certMgr.APIVersion{}, notMocked("ServerVersion")
+	}
+	return m.ServerVersionFunc(ctx)
+}
+
+// FIPSMode returns false unless FIPSModeFunc is set.
+func (m *Client) FIPSMode() bool {
+	if m.FIPSModeFunc == nil {
+		return false
+	}
+	return m.FIPSModeFunc()
+}
+
+// ExternalClient returns http.DefaultClient unless ExternalClientFunc is set.
+func (m *Client) ExternalClient() *http.Client {
+	if m.ExternalClientFunc == nil {
+		return http.DefaultClient
+	}
+	return m.ExternalClientFunc()
+}
+
+// ReadErrorsAsWarnings returns false unless ReadErrorsAsWarningsFunc is set.
+func (m *Client) ReadErrorsAsWarnings() bool {
+	if m.ReadErrorsAsWarningsFunc == nil {
+		return false
+	}
+	return m.ReadErrorsAsWarningsFunc()
+}
+
+func (m *Client) CreateCertificate(ctx context.Context, request certMgr.CertificateRequest) (*certMgr.Certificate, error) {
+	if m.CreateCertificateFunc == nil {
+		return nil, notMocked("CreateCertificate")
+	}
+	return m.CreateCertificateFunc(ctx, request)
+}
+
+func (m *Client) ListStaged(ctx context.Context, hostname string) ([]certMgr.Certificate, error) {
+	if m.ListStagedFunc == nil {
+		return nil, notMocked("ListStaged")
+	}
+	return m.ListStagedFunc(ctx, hostname)
+}
+
+func (m *Client) ListCertificates(ctx context.Context, filter certMgr.CertificateFilter) ([]certMgr.Certificate, error) {
+	if m.ListCertificatesFunc == nil {
+		return nil, notMocked("ListCertificates")
+	}
+	return m.ListCertificatesFunc(ctx, filter)
+}
+
+func (m *Client) ListStagedByRequestor(ctx context.Context, requestor string) ([]certMgr.Certificate, error) {
+	if m.ListStagedByRequestorFunc == nil {
+		return nil, notMocked("ListStagedByRequestor")
+	}
+	return m.ListStagedByRequestorFunc(ctx, requestor)
+}
+
+func (m *Client) ListStagedByTags(ctx context.Context, selector map[string]string) ([]certMgr.Certificate, error) {
+	if m.ListStagedByTagsFunc == nil {
+		return nil, notMocked("ListStagedByTags")
+	}
+	return m.ListStagedByTagsFunc(ctx, selector)
+}
+
+func (m *Client) GetCertificate(ctx context.Context, hostname string) (*certMgr.Certificate, error) {
+	if m.GetCertificateFunc == nil {
+		return nil, notMocked("GetCertificate")
+	}
+	return m.GetCertificateFunc(ctx, hostname)
+}
+
+func (m *Client) GetStaged(ctx context.Context, hostname string, id int) (*certMgr.Certificate, error) {
+	if m.GetStagedFunc == nil {
+		return nil, notMocked("GetStaged")
+	}
+	return m.GetStagedFunc(ctx, hostname, id)
+}
+
+func (m *Client) GetCertificateByID(ctx context.Context, id int) (*certMgr.Certificate, error) {
+	if m.GetCertificateByIDFunc == nil {
+		return nil, notMocked("GetCertificateByID")
+	}
+	return m.GetCertificateByIDFunc(ctx, id)
+}
+
+func (m *Client) CertificateUnchanged(ctx context.Context, id int, etag string) (bool, error) {
+	if m.CertificateUnchangedFunc == nil {
+		return false, notMocked("CertificateUnchanged")
+	}
+	return m.CertificateUnchangedFunc(ctx, id, etag)
+}
+
+func (m *Client) GetCertificateBySerial(ctx context.Context, serial string) (*certMgr.Certificate, error) {
+	if m.GetCertificateBySerialFunc == nil {
+		return nil, notMocked("GetCertificateBySerial")
+	}
+	return m.GetCertificateBySerialFunc(ctx, serial)
+}
+
+func (m *Client) GetIssuedCertificate(ctx context.Context, hostname, serial string) (*certMgr.Certificate, error) {
+	if m.GetIssuedCertificateFunc == nil {
+		return nil, notMocked("GetIssuedCertificate")
+	}
+	return m.GetIssuedCertificateFunc(ctx, hostname, serial)
+}
+
+func (m *Client) UpdateCertificate(ctx context.Context, update certMgr.CertificateUpdate) error {
+	if m.UpdateCertificateFunc == nil {
+		return notMocked("UpdateCertificate")
+	}
+	return m.UpdateCertificateFunc(ctx, update)
+}
+
+func (m *Client) RenewCertificate(ctx context.Context, id int) (*certMgr.Certificate, error) {
+	if m.RenewCertificateFunc == nil {
+		return nil, notMocked("RenewCertificate")
+	}
+	return m.RenewCertificateFunc(ctx, id)
+}
+
+func (m *Client) DeleteCertificate(ctx context.Context, hostname string) error {
+	if m.DeleteCertificateFunc == nil {
+		return notMocked("DeleteCertificate")
+	}
+	return m.DeleteCertificateFunc(ctx, hostname)
+}
+
+func (m *Client) DeleteStaged(ctx context.Context, id int) error {
+	if m.DeleteStagedFunc == nil {
+		return notMocked("DeleteStaged")
+	}
+	return m.DeleteStagedFunc(ctx, id)
+}
+
+func (m *Client) CreateCertificates(ctx context.Context, hostnames []string) ([]certMgr.Certificate, error) {
+	if m.CreateCertificatesFunc == nil {
+		return nil, notMocked("CreateCertificates")
+	}
+	return m.CreateCertificatesFunc(ctx, hostnames)
+}
+
+func (m *Client) ListStagedForHostnames(ctx context.Context, hostnames []string) ([]certMgr.Certificate, error) {
+	if m.ListStagedForHostnamesFunc == nil {
+		return nil, notMocked("ListStagedForHostnames")
+	}
+	return m.ListStagedForHostnamesFunc(ctx, hostnames)
+}
+
+func (m *Client) DeleteStagedEntries(ctx context.Context, ids []int) error {
+	if m.DeleteStagedEntriesFunc == nil {
+		return notMocked("DeleteStagedEntries")
+	}
+	return m.DeleteStagedEntriesFunc(ctx, ids)
+}
+
+func (m *Client) CreateHost(ctx context.Context, host certMgr.Host) (*certMgr.Host, error) {
+	if m.CreateHostFunc == nil {
+		return nil, notMocked("CreateHost")
+	}
+	return m.CreateHostFunc(ctx, host)
+}
+
+func (m *Client) GetHost(ctx context.Context, hostname string) (*certMgr.Host, error) {
+	if m.GetHostFunc == nil {
+		return nil, notMocked("GetHost")
+	}
+	return m.GetHostFunc(ctx, hostname)
+}
+
+func (m *Client) UpdateHost(ctx context.Context, host certMgr.Host) error {
+	if m.UpdateHostFunc == nil {
+		return notMocked("UpdateHost")
+	}
+	return m.UpdateHostFunc(ctx, host)
+}
+
+func (m *Client) DeleteHost(ctx context.Context, id int) error {
+	if m.DeleteHostFunc == nil {
+		return notMocked("DeleteHost")
+	}
+	return m.DeleteHostFunc(ctx, id)
+}
+
+func (m *Client) CreateACL(ctx context.Context, acl certMgr.ACL) (*certMgr.ACL, error) {
+	if m.CreateACLFunc == nil {
+		return nil, notMocked("CreateACL")
+	}
+	return m.CreateACLFunc(ctx, acl)
+}
+
+func (m *Client) GetACL(ctx context.Context, id int) (*certMgr.ACL, error) {
+	if m.GetACLFunc == nil {
+		return nil, notMocked("GetACL")
+	}
+	return m.GetACLFunc(ctx, id)
+}
+
+func (m *Client) UpdateACL(ctx context.Context, acl certMgr.ACL) error {
+	if m.UpdateACLFunc == nil {
+		return notMocked("UpdateACL")
+	}
+	return m.UpdateACLFunc(ctx, acl)
+}
+
+func (m *Client) DeleteACL(ctx context.Context, id int) error {
+	if m.DeleteACLFunc == nil {
+		return notMocked("DeleteACL")
+	}
+	return m.DeleteACLFunc(ctx, id)
+}
+
+func (m *Client) CreateTemplate(ctx context.Context, template certMgr.Template) (*certMgr.Template, error) {
+	if m.CreateTemplateFunc == nil {
+		return nil, notMocked("CreateTemplate")
+	}
+	return m.CreateTemplateFunc(ctx, template)
+}
+
+func (m *Client) GetTemplate(ctx context.Context, id int) (*certMgr.Template, error) {
+	if m.GetTemplateFunc == nil {
+		return nil, notMocked("GetTemplate")
+	}
+	return m.GetTemplateFunc(ctx, id)
+}
+
+func (m *Client) UpdateTemplate(ctx context.Context, template certMgr.Template) error {
+	if m.UpdateTemplateFunc == nil {
+		return notMocked("UpdateTemplate")
+	}
+	return m.UpdateTemplateFunc(ctx, template)
+}
+
+func (m *Client) DeleteTemplate(ctx context.Context, id int) error {
+	if m.DeleteTemplateFunc == nil {
+		return notMocked("DeleteTemplate")
+	}
+	return m.DeleteTemplateFunc(ctx, id)
+}
+
+func (m *Client) CreateAutoRenewalPolicy(ctx context.Context, policy certMgr.AutoRenewalPolicy) (*certMgr.AutoRenewalPolicy, error) {
+	if m.CreateAutoRenewalPolicyFunc == nil {
+		return nil, notMocked("CreateAutoRenewalPolicy")
+	}
+	return m.CreateAutoRenewalPolicyFunc(ctx, policy)
+}
+
+func (m *Client) GetAutoRenewalPolicy(ctx context.Context, id int) (*certMgr.AutoRenewalPolicy, error) {
+	if m.GetAutoRenewalPolicyFunc == nil {
+		return nil, notMocked("GetAutoRenewalPolicy")
+	}
+	return m.GetAutoRenewalPolicyFunc(ctx, id)
+}
+
+func (m *Client) UpdateAutoRenewalPolicy(ctx context.Context, policy certMgr.AutoRenewalPolicy) error {
+	if m.UpdateAutoRenewalPolicyFunc == nil {
+		return notMocked("UpdateAutoRenewalPolicy")
+	}
+	return m.UpdateAutoRenewalPolicyFunc(ctx, policy)
+}
+
+func (m *Client) DeleteAutoRenewalPolicy(ctx context.Context, id int) error {
+	if m.DeleteAutoRenewalPolicyFunc == nil {
+		return notMocked("DeleteAutoRenewalPolicy")
+	}
+	return m.DeleteAutoRenewalPolicyFunc(ctx, id)
+}
+
+func (m *Client) CreateNotification(ctx context.Context, notification certMgr.Notification) (*certMgr.Notification, error) {
+	if m.CreateNotificationFunc == nil {
+		return nil, notMocked("CreateNotification")
+	}
+	return m.CreateNotificationFunc(ctx, notification)
+}
+
+func (m *Client) GetNotification(ctx context.Context, id int) (*certMgr.Notification, error) {
+	if m.GetNotificationFunc == nil {
+		return nil, notMocked("GetNotification")
+	}
+	return m.GetNotificationFunc(ctx, id)
+}
+
+func (m *Client) UpdateNotification(ctx context.Context, notification certMgr.Notification) error {
+	if m.UpdateNotificationFunc == nil {
+		return notMocked("UpdateNotification")
+	}
+	return m.UpdateNotificationFunc(ctx, notification)
+}
+
+func (m *Client) DeleteNotification(ctx context.Context, id int) error {
+	if m.DeleteNotificationFunc == nil {
+		return notMocked("DeleteNotification")
+	}
+	return m.DeleteNotificationFunc(ctx, id)
+}
+
+func (m *Client) CreateRevocation(ctx context.Context, revocation certMgr.Revocation) (*certMgr.Revocation, error) {
+	if m.CreateRevocationFunc == nil {
+		return nil, notMocked("CreateRevocation")
+	}
+	return m.CreateRevocationFunc(ctx, revocation)
+}
+
+func (m *Client) GetRevocation(ctx context.Context, id int) (*certMgr.Revocation, error) {
+	if m.GetRevocationFunc == nil {
+		return nil, notMocked("GetRevocation")
+	}
+	return m.GetRevocationFunc(ctx, id)
+}
+
+func (m *Client) RevokeCertificate(ctx context.Context, serial, reason string) (*certMgr.Revocation, error) {
+	if m.RevokeCertificateFunc == nil {
+		return nil, notMocked("RevokeCertificate")
+	}
+	return m.RevokeCertificateFunc(ctx, serial, reason)
+}
+
+func (m *Client) ListRevocations(ctx context.Context, serial string) ([]certMgr.Revocation, error) {
+	if m.ListRevocationsFunc == nil {
+		return nil, notMocked("ListRevocations")
+	}
+	return m.ListRevocationsFunc(ctx, serial)
+}
+
+func (m *Client) CertificateRevoked(ctx context.Context, serial string) (bool, error) {
+	if m.CertificateRevokedFunc == nil {
+		return false, notMocked("CertificateRevoked")
+	}
+	return m.CertificateRevokedFunc(ctx, serial)
+}
+
+func (m *Client) GetCertificateAuthority(ctx context.Context, name string) (*certMgr.CertificateAuthority, error) {
+	if m.GetCertificateAuthorityFunc == nil {
+		return nil, notMocked("GetCertificateAuthority")
+	}
+	return m.GetCertificateAuthorityFunc(ctx, name)
+}
+
+func (m *Client) CreateBinding(ctx context.Context, binding certMgr.Binding) (*certMgr.Binding, error) {
+	if m.CreateBindingFunc == nil {
+		return nil, notMocked("CreateBinding")
+	}
+	return m.CreateBindingFunc(ctx, binding)
+}
+
+func (m *Client) GetBinding(ctx context.Context, id int) (*certMgr.Binding, error) {
+	if m.GetBindingFunc == nil {
+		return nil, notMocked("GetBinding")
+	}
+	return m.GetBindingFunc(ctx, id)
+}
+
+func (m *Client) UpdateBinding(ctx context.Context, binding certMgr.Binding) error {
+	if m.UpdateBindingFunc == nil {
+		return notMocked("UpdateBinding")
+	}
+	return m.UpdateBindingFunc(ctx, binding)
+}
+
+func (m *Client) DeleteBinding(ctx context.Context, id int) error {
+	if m.DeleteBindingFunc == nil {
+		return notMocked("DeleteBinding")
+	}
+	return m.DeleteBindingFunc(ctx, id)
+}
+
+func (m *Client) CreateDNSAlias(ctx context.Context, alias certMgr.DNSAlias) (*certMgr.DNSAlias, error) {
+	if m.CreateDNSAliasFunc == nil {
+		return nil, notMocked("CreateDNSAlias")
+	}
+	return m.CreateDNSAliasFunc(ctx, alias)
+}
+
+func (m *Client) GetDNSAlias(ctx context.Context, id int) (*certMgr.DNSAlias, error) {
+	if m.GetDNSAliasFunc == nil {
+		return nil, notMocked("GetDNSAlias")
+	}
+	return m.GetDNSAliasFunc(ctx, id)
+}
+
+func (m *Client) ListDNSAliases(ctx context.Context, hostname string) ([]certMgr.DNSAlias, error) {
+	if m.ListDNSAliasesFunc == nil {
+		return nil, notMocked("ListDNSAliases")
+	}
+	return m.ListDNSAliasesFunc(ctx, hostname)
+}
+
+func (m *Client) DeleteDNSAlias(ctx context.Context, id int) error {
+	if m.DeleteDNSAliasFunc == nil {
+		return notMocked("DeleteDNSAlias")
+	}
+	return m.DeleteDNSAliasFunc(ctx, id)
+}
+
+func (m *Client) CreateServiceIdentity(ctx context.Context, identity certMgr.ServiceIdentity) (*certMgr.ServiceIdentity, error) {
+	if m.CreateServiceIdentityFunc == nil {
+		return nil, notMocked("CreateServiceIdentity")
+	}
+	return m.CreateServiceIdentityFunc(ctx, identity)
+}
+
+func (m *Client) GetServiceIdentity(ctx context.Context, id int) (*certMgr.ServiceIdentity, error) {
+	if m.GetServiceIdentityFunc == nil {
+		return nil, notMocked("GetServiceIdentity")
+	}
+	return m.GetServiceIdentityFunc(ctx, id)
+}
+
+func (m *Client) UpdateServiceIdentity(ctx context.Context, identity certMgr.ServiceIdentity) error {
+	if m.UpdateServiceIdentityFunc == nil {
+		return notMocked("UpdateServiceIdentity")
+	}
+	return m.UpdateServiceIdentityFunc(ctx, identity)
+}
+
+func (m *Client) DeleteServiceIdentity(ctx context.Context, id int) error {
+	if m.DeleteServiceIdentityFunc == nil {
+		return notMocked("DeleteServiceIdentity")
+	}
+	return m.DeleteServiceIdentityFunc(ctx, id)
+}
+
+func (m *Client) IssueIdentityCertificate(ctx context.Context, id int, csr string) (*certMgr.Certificate, error) {
+	if m.IssueIdentityCertificateFunc == nil {
+		return nil, notMocked("IssueIdentityCertificate")
+	}
+	return m.IssueIdentityCertificateFunc(ctx, id, csr)
+}
+
+func (m *Client) GetStatistics(ctx context.Context, filter certMgr.StatisticsFilter) (*certMgr.Statistics, error) {
+	if m.GetStatisticsFunc == nil {
+		return nil, notMocked("GetStatistics")
+	}
+	return m.GetStatisticsFunc(ctx, filter)
+}
+
+func (m *Client) CreateAPIToken(ctx context.Context, lifetime time.Duration) (*certMgr.APIToken, error) {
+	if m.CreateAPITokenFunc == nil {
+		return nil, notMocked("CreateAPIToken")
+	}
+	return m.CreateAPITokenFunc(ctx, lifetime)
+}
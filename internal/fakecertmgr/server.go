@@ -0,0 +1,592 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package fakecertmgr provides an in-memory certMgr server for tests. It
+// implements the staged and certificate endpoints closely enough for the
+// client and the provider to run their full lifecycle against it without
+// Kerberos or network access to CERN.
+package fakecertmgr
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+
+	"github.com/jcmturner/gokrb5/v8/spnego"
+)
+
+// pageSize is the number of objects per list page, matching the Tastypie
+// default, so that clients exercise pagination on larger listings.
+const pageSize = 20
+
+// timestampLayout is the zone-less layout certMgr uses for start and end.
+const timestampLayout = "2006-01-02T15:04:05"
+
+// Server is a certMgr fake serving on an httptest TLS server. Staged requests
+// are issued immediately, signed by a CA created for the server.
+type Server struct {
+	*httptest.Server
+
+	// AcceptAsync makes new staged requests answer 202 Accepted without a
+	// body, like busy certMgr instances do. The entry is listed right away.
+	AcceptAsync bool
+
+	// AlwaysReturnData makes bulk PATCHes answer with the objects they
+	// created, like certMgr instances whose staged resource sets Tastypie's
+	// always_return_data.
+	AlwaysReturnData bool
+
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+
+	mu          sync.Mutex
+	nextID      int
+	staged      map[int]certMgr.Certificate
+	revocations map[int]certMgr.Revocation
+}
+
+// NewServer starts a fake certMgr server. It is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	caKey, caCert, err := newCA()
+	if err != nil {
+		t.Fatalf("creating fake CA: %v", err)
+	}
+
+	s := &Server{
+		caCert:      caCert,
+		caKey:       caKey,
+		nextID:      1,
+		staged:      map[int]certMgr.Certificate{},
+		revocations: map[int]certMgr.Revocation{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /krb/certmgr/staged/", s.listStaged)
+	mux.HandleFunc("POST /krb/certmgr/staged/", s.createStaged)
+	mux.HandleFunc("PATCH /krb/certmgr/staged/", s.bulkStaged)
+	mux.HandleFunc("GET /krb/certmgr/staged/{id}/", s.getStaged)
+	mux.HandleFunc("DELETE /krb/certmgr/staged/{id}/", s.deleteStaged)
+	mux.HandleFunc("POST /krb/certmgr/staged/{id}/renew/", s.renewStaged)
+	mux.HandleFunc("GET /krb/certmgr/certificate/", s.listStaged)
+	mux.HandleFunc("POST /krb/certmgr/certificate/", s.updateCertificate)
+	mux.HandleFunc("GET /krb/certmgr/revocation/", s.listRevocations)
+	mux.HandleFunc("POST /krb/certmgr/revocation/", s.createRevocation)
+	mux.HandleFunc("GET /krb/certmgr/revocation/{id}/", s.getRevocation)
+
+	s.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// NewClient returns a client talking to the server without Kerberos.
+func (s *Server) NewClient() *certMgr.Client {
+	u, _ := url.Parse(s.URL)
+	port, _ := strconv.Atoi(u.Port())
+	return &certMgr.Client{
+		HTTPClient:       spnego.NewClient(nil, s.Client(), ""),
+		Host:             u.Hostname(),
+		Port:             port,
+		RetryBudget:      certMgr.DefaultRetryBudget,
+		WriteRetryBudget: certMgr.DefaultRetryBudget,
+	}
+}
+
+// CA returns the certificate of the CA signing issued certificates.
+func (s *Server) CA() *x509.Certificate {
+	return s.caCert
+}
+
+// Certificates returns a snapshot of every staged entry, ordered by ID.
+func (s *Server) Certificates() []certMgr.Certificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedLocked()
+}
+
+// Remove deletes the staged entry with the given ID behind the back of
+// clients, as deleting it in the certMgr UI does.
+func (s *Server) Remove(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.staged, id)
+}
+
+// ServeUnix additionally serves plain HTTP on a unix domain socket at path,
+// the way a local certMgr agent does, so that the provider can be pointed at
+// the server with its endpoint attribute. The listener is closed when the
+// test ends.
+func (s *Server) ServeUnix(t testing.TB, path string) {
+	t.Helper()
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("listening on %s: %v", path, err)
+	}
+	server := &http.Server{Handler: s.Config.Handler}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+}
+
+func (s *Server) sortedLocked() []certMgr.Certificate {
+	certs := make([]certMgr.Certificate, 0, len(s.staged))
+	for _, cert := range s.staged {
+		certs = append(certs, cert)
+	}
+	slices.SortFunc(certs, func(a, b certMgr.Certificate) int { return a.ID - b.ID })
+	return certs
+}
+
+func (s *Server) listStaged(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	s.mu.Lock()
+	var matched []certMgr.Certificate
+	for _, cert := range s.sortedLocked() {
+		if matches(cert, query) {
+			matched = append(matched, cert)
+		}
+	}
+	s.mu.Unlock()
+
+	offset = min(max(offset, 0), len(matched))
+	end := min(offset+pageSize, len(matched))
+
+	var next *string
+	if end < len(matched) {
+		query.Set("offset", strconv.Itoa(end))
+		link := r.URL.Path + "?" + query.Encode()
+		next = &link
+	}
+
+	objects := matched[offset:end]
+	if objects == nil {
+		objects = []certMgr.Certificate{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"meta": map[string]any{
+			"limit":       pageSize,
+			"offset":      offset,
+			"total_count": len(matched),
+			"next":        next,
+		},
+		"objects": objects,
+	})
+}
+
+// matches reports whether cert satisfies the Tastypie filters in query that
+// the client uses.
+func matches(cert certMgr.Certificate, query url.Values) bool {
+	for key, values := range query {
+		value := values[0]
+		switch {
+		case key == "hostname":
+			if !strings.EqualFold(cert.Hostname, value) {
+				return false
+			}
+		case key == "hostname__in":
+			if !slices.Contains(strings.Split(value, ","), cert.Hostname) {
+				return false
+			}
+		case key == "hostname__startswith":
+			if !strings.HasPrefix(cert.Hostname, value) {
+				return false
+			}
+		case key == "requestor":
+			if cert.Requestor != value {
+				return false
+			}
+		case key == "serial":
+			if !strings.EqualFold(cert.Serial, value) {
+				return false
+			}
+		case key == "start__gte" || key == "start__lte":
+			bound, err := certMgr.ParseTimestamp(value)
+			if err != nil {
+				return false
+			}
+			start, err := certMgr.ParseTimestamp(cert.Start)
+			if err != nil {
+				return false
+			}
+			if key == "start__gte" && start.Before(bound) || key == "start__lte" && start.After(bound) {
+				return false
+			}
+		case strings.HasPrefix(key, "tags__"):
+			if cert.Tags[strings.TrimPrefix(key, "tags__")] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *Server) createStaged(w http.ResponseWriter, r *http.Request) {
+	var request certMgr.CertificateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cert, err := s.stage(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if s.AcceptAsync {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeJSON(w, http.StatusCreated, cert)
+}
+
+// bulkStaged implements the Tastypie bulk PATCH, creating objects and
+// deleting deleted_objects in one request. Like certMgr, it stages either all
+// objects or none.
+func (s *Server) bulkStaged(w http.ResponseWriter, r *http.Request) {
+	var bulk struct {
+		Objects        []certMgr.CertificateRequest `json:"objects"`
+		DeletedObjects []string                     `json:"deleted_objects"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&bulk); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	staged := make([]certMgr.Certificate, 0, len(bulk.Objects))
+	for _, request := range bulk.Objects {
+		cert, err := s.stage(request)
+		if err != nil {
+			for _, cert := range staged {
+				s.Remove(cert.ID)
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		staged = append(staged, cert)
+	}
+
+	s.mu.Lock()
+	for _, uri := range bulk.DeletedObjects {
+		id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(uri, "/krb/certmgr/staged/"), "/"))
+		if err == nil {
+			delete(s.staged, id)
+		}
+	}
+	s.mu.Unlock()
+
+	if s.AlwaysReturnData {
+		writeJSON(w, http.StatusAccepted, map[string]any{"objects": staged})
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) getStaged(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	cert, ok := s.staged[id]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := json.Marshal(cert)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf(`"%x"`, sum[:8])
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSON(w, http.StatusOK, cert)
+}
+
+func (s *Server) deleteStaged(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	_, ok := s.staged[id]
+	delete(s.staged, id)
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// renewStaged issues a new certificate for the hostname of an entry, keeping
+// its requestor and tags.
+func (s *Server) renewStaged(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	old, ok := s.staged[id]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	renewed, err := s.stage(certMgr.CertificateRequest{Hostname: old.Hostname})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.mu.Lock()
+	renewed.Requestor = old.Requestor
+	renewed.Tags = old.Tags
+	s.staged[renewed.ID] = renewed
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, renewed)
+}
+
+// updateCertificate applies a CertificateUpdate to the entry with its ID, or
+// to every entry of its hostname when no ID is given.
+func (s *Server) updateCertificate(w http.ResponseWriter, r *http.Request) {
+	var update certMgr.CertificateUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for id, cert := range s.staged {
+		if update.ID != 0 && id != update.ID || update.ID == 0 && cert.Hostname != update.Hostname {
+			continue
+		}
+		found = true
+		if update.Requestor != nil {
+			cert.Requestor = *update.Requestor
+		}
+		if update.Tags != nil {
+			cert.Tags = *update.Tags
+		}
+		s.staged[id] = cert
+	}
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// createRevocation revokes the entry named by serial or certificate ID.
+// Revoking an unknown certificate fails with 404.
+func (s *Server) createRevocation(w http.ResponseWriter, r *http.Request) {
+	var revocation certMgr.Revocation
+	if err := json.NewDecoder(r.Body).Decode(&revocation); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for id, cert := range s.staged {
+		if id == revocation.CertificateID || revocation.Serial != "" && strings.EqualFold(cert.Serial, revocation.Serial) {
+			found = true
+			revocation.Serial = cert.Serial
+			break
+		}
+	}
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	for _, existing := range s.revocations {
+		if strings.EqualFold(existing.Serial, revocation.Serial) {
+			http.Error(w, "certificate is already revoked", http.StatusConflict)
+			return
+		}
+	}
+
+	revocation.ID = s.nextID
+	revocation.RevokedAt = time.Now().UTC().Format(timestampLayout)
+	s.revocations[revocation.ID] = revocation
+	s.nextID++
+	writeJSON(w, http.StatusCreated, revocation)
+}
+
+// listRevocations lists the revocations, filtered by serial, on one page.
+func (s *Server) listRevocations(w http.ResponseWriter, r *http.Request) {
+	serial := r.URL.Query().Get("serial")
+
+	s.mu.Lock()
+	revocations := []certMgr.Revocation{}
+	for _, revocation := range s.revocations {
+		if serial == "" || strings.EqualFold(revocation.Serial, serial) {
+			revocations = append(revocations, revocation)
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(revocations, func(a, b certMgr.Revocation) int { return a.ID - b.ID })
+	writeJSON(w, http.StatusOK, map[string]any{
+		"meta":    map[string]any{"total_count": len(revocations), "next": nil},
+		"objects": revocations,
+	})
+}
+
+func (s *Server) getRevocation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	revocation, ok := s.revocations[id]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, revocation)
+}
+
+// stage issues a certificate for request and stores it as a new entry. When
+// the request carries no CSR, a key pair is generated and returned with it.
+func (s *Server) stage(request certMgr.CertificateRequest) (certMgr.Certificate, error) {
+	hostname, err := certMgr.NormalizeHostname(request.Hostname)
+	if err != nil {
+		return certMgr.Certificate{}, err
+	}
+
+	var public crypto.PublicKey
+	var keyPEM string
+	if request.CSR != "" {
+		csr, err := pki.ParseCSRPEM(request.CSR)
+		if err != nil {
+			return certMgr.Certificate{}, err
+		}
+		public = csr.PublicKey
+	} else {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return certMgr.Certificate{}, err
+		}
+		if keyPEM, err = pki.EncodePrivateKeyPEM(key); err != nil {
+			return certMgr.Certificate{}, err
+		}
+		public = key.Public()
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return certMgr.Certificate{}, err
+	}
+	start := time.Now().UTC().Truncate(time.Second)
+	end := start.AddDate(1, 0, 0)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    start,
+		NotAfter:     end,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.caCert, public, s.caKey)
+	if err != nil {
+		return certMgr.Certificate{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert := certMgr.Certificate{
+		ID:             s.nextID,
+		Hostname:       hostname,
+		Start:          start.Format(timestampLayout),
+		End:            end.Format(timestampLayout),
+		Serial:         fmt.Sprintf("%X", serial),
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		PrivateKeyPEM:  keyPEM,
+	}
+	s.staged[cert.ID] = cert
+	s.nextID++
+	return cert, nil
+}
+
+func newCA() (crypto.Signer, *x509.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fake certMgr CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, cert, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
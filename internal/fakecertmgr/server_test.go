@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package fakecertmgr_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuedCertificatesChainToCA(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+
+	key, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	csr, err := pki.CreateCSR(key, pki.CSRSubject{CommonName: "tf-test.cern.ch"})
+	require.NoError(t, err)
+
+	cert, err := cli.CreateCertificate(context.Background(), certMgr.CertificateRequest{Hostname: "tf-test.cern.ch", CSR: csr})
+	require.NoError(t, err)
+	require.Empty(t, cert.PrivateKeyPEM)
+
+	parsed, err := pki.ParseCertificatePEM(cert.CertificatePEM)
+	require.NoError(t, err)
+	require.NoError(t, parsed.CheckSignatureFrom(server.CA()))
+	require.Equal(t, []string{"tf-test.cern.ch"}, parsed.DNSNames)
+}
+
+func TestBulkCreateAcrossPages(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	cli := server.NewClient()
+	ctx := context.Background()
+
+	hostnames := make([]string, 45)
+	for i := range hostnames {
+		hostnames[i] = fmt.Sprintf("tf-test-%02d.cern.ch", i)
+	}
+
+	created, err := cli.CreateCertificates(ctx, hostnames)
+	require.NoError(t, err)
+	require.Len(t, created, len(hostnames))
+
+	listed, err := cli.ListCertificates(ctx, certMgr.CertificateFilter{HostnamePrefix: "tf-test-"})
+	require.NoError(t, err)
+	require.Len(t, listed, len(hostnames))
+
+	require.NoError(t, cli.DeleteCertificate(ctx, "tf-test-00.cern.ch"))
+	require.Len(t, server.Certificates(), len(hostnames)-1)
+}
@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package gcp is a minimal client for the certificate APIs of Google Cloud:
+// Certificate Manager and the SSL certificates of Compute Engine, used to
+// upload issued certificates for Google Cloud load balancers.
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default endpoints of the APIs.
+const (
+	CertificateManagerURL = "https://certificatemanager.googleapis.com/v1"
+	ComputeURL            = "https://compute.googleapis.com/compute/v1"
+)
+
+// ErrNotFound is returned when a certificate does not exist.
+var ErrNotFound = errors.New("certificate not found")
+
+// Client uploads certificates to Google Cloud.
+type Client struct {
+	HTTPClient *http.Client
+	// Token is an OAuth 2.0 access token, for example from
+	// `gcloud auth print-access-token`.
+	Token string
+
+	CertificateManagerURL string
+	ComputeURL            string
+	// PollInterval is the time between checks of a pending operation.
+	PollInterval time.Duration
+}
+
+// NewClient returns a client authenticating with the access token.
+func NewClient(token string) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("google access token is required")
+	}
+	return &Client{
+		HTTPClient:            http.DefaultClient,
+		Token:                 token,
+		CertificateManagerURL: CertificateManagerURL,
+		ComputeURL:            ComputeURL,
+		PollInterval:          2 * time.Second,
+	}, nil
+}
+
+// Certificate is a certificate as uploaded to either API.
+type Certificate struct {
+	// CertificatePEM is the certificate followed by its chain.
+	CertificatePEM string
+	PrivateKeyPEM  string
+	Description    string
+}
+
+// ManagedCertificate is a self-managed certificate of Certificate Manager.
+type ManagedCertificate struct {
+	Name           string `json:"name"`
+	CertificatePEM string `json:"pemCertificate"`
+}
+
+type managedCertificatePayload struct {
+	Description string `json:"description,omitempty"`
+	SelfManaged struct {
+		PEMCertificate string `json:"pemCertificate"`
+		PEMPrivateKey  string `json:"pemPrivateKey"`
+	} `json:"selfManaged"`
+}
+
+func newManagedCertificatePayload(certificate Certificate) managedCertificatePayload {
+	var payload managedCertificatePayload
+	payload.Description = certificate.Description
+	payload.SelfManaged.PEMCertificate = certificate.CertificatePEM
+	payload.SelfManaged.PEMPrivateKey = certificate.PrivateKeyPEM
+	return payload
+}
+
+// ManagedCertificateName returns the resource name of a Certificate Manager
+// certificate.
+func ManagedCertificateName(project, location, name string) string {
+	return fmt.Sprintf("projects/%s/locations/%s/certificates/%s", project, location, name)
+}
+
+// CreateManagedCertificate uploads certificate to Certificate Manager under
+// name and waits for the upload to complete.
+func (c *Client) CreateManagedCertificate(ctx context.Context, project, location, name string, certificate Certificate) error {
+	parent := fmt.Sprintf("%s/projects/%s/locations/%s/certificates", c.CertificateManagerURL, project, location)
+	return c.managedOperation(ctx, http.MethodPost, parent+"?certificateId="+url.QueryEscape(name), newManagedCertificatePayload(certificate))
+}
+
+// UpdateManagedCertificate replaces the certificate, private key and
+// description of an existing Certificate Manager certificate in place, so
+// that load balancers using it pick up the new certificate.
+func (c *Client) UpdateManagedCertificate(ctx context.Context, project, location, name string, certificate Certificate) error {
+	u := c.CertificateManagerURL + "/" + ManagedCertificateName(project, location, name) + "?updateMask=selfManaged,description"
+	return c.managedOperation(ctx, http.MethodPatch, u, newManagedCertificatePayload(certificate))
+}
+
+// GetManagedCertificate returns a Certificate Manager certificate, or
+// ErrNotFound.
+func (c *Client) GetManagedCertificate(ctx context.Context, project, location, name string) (*ManagedCertificate, error) {
+	body, err := c.do(ctx, http.MethodGet, c.CertificateManagerURL+"/"+ManagedCertificateName(project, location, name), nil)
+	if err != nil {
+		return nil, err
+	}
+	var certificate ManagedCertificate
+	if err := json.Unmarshal(body, &certificate); err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+	return &certificate, nil
+}
+
+// DeleteManagedCertificate deletes a Certificate Manager certificate.
+// Certificates that do not exist are not an error.
+func (c *Client) DeleteManagedCertificate(ctx context.Context, project, location, name string) error {
+	err := c.managedOperation(ctx, http.MethodDelete, c.CertificateManagerURL+"/"+ManagedCertificateName(project, location, name), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// managedOperation sends a request returning a long-running operation of
+// Certificate Manager and waits for the operation to complete.
+func (c *Client) managedOperation(ctx context.Context, method, u string, payload any) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("marshal failed: %w", err)
+		}
+	}
+
+	for {
+		respBody, err := c.do(ctx, method, u, body)
+		if err != nil {
+			return err
+		}
+		var operation struct {
+			Name  string `json:"name"`
+			Done  bool   `json:"done"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &operation); err != nil {
+			return fmt.Errorf("failed to decode operation: %w", err)
+		}
+		switch {
+		case operation.Error != nil:
+			return fmt.Errorf("operation %s failed: %s", operation.Name, operation.Error.Message)
+		case operation.Done:
+			return nil
+		}
+
+		if err := c.wait(ctx); err != nil {
+			return err
+		}
+		method, u, body = http.MethodGet, c.CertificateManagerURL+"/"+operation.Name, nil
+	}
+}
+
+// SSLCertificate is an SSL certificate of Compute Engine.
+type SSLCertificate struct {
+	Name           string `json:"name"`
+	CertificatePEM string `json:"certificate"`
+	SelfLink       string `json:"selfLink"`
+}
+
+// sslCertificatesURL returns the collection of global SSL certificates, or
+// of regional ones when region is not "global".
+func (c *Client) sslCertificatesURL(project, region string) string {
+	if region == "" || region == "global" {
+		return fmt.Sprintf("%s/projects/%s/global/sslCertificates", c.ComputeURL, project)
+	}
+	return fmt.Sprintf("%s/projects/%s/regions/%s/sslCertificates", c.ComputeURL, project, region)
+}
+
+// CreateSSLCertificate uploads certificate as a Compute Engine SSL
+// certificate and waits for the upload to complete. SSL certificates are
+// immutable; a reissued certificate needs a new one.
+func (c *Client) CreateSSLCertificate(ctx context.Context, project, region, name string, certificate Certificate) error {
+	payload, err := json.Marshal(map[string]string{
+		"name":        name,
+		"description": certificate.Description,
+		"certificate": certificate.CertificatePEM,
+		"privateKey":  certificate.PrivateKeyPEM,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+	return c.computeOperation(ctx, http.MethodPost, c.sslCertificatesURL(project, region), payload)
+}
+
+// GetSSLCertificate returns a Compute Engine SSL certificate, or
+// ErrNotFound.
+func (c *Client) GetSSLCertificate(ctx context.Context, project, region, name string) (*SSLCertificate, error) {
+	body, err := c.do(ctx, http.MethodGet, c.sslCertificatesURL(project, region)+"/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	var certificate SSLCertificate
+	if err := json.Unmarshal(body, &certificate); err != nil {
+		return nil, fmt.Errorf("failed to decode SSL certificate: %w", err)
+	}
+	return &certificate, nil
+}
+
+// DeleteSSLCertificate deletes a Compute Engine SSL certificate.
+// Certificates that do not exist are not an error.
+func (c *Client) DeleteSSLCertificate(ctx context.Context, project, region, name string) error {
+	err := c.computeOperation(ctx, http.MethodDelete, c.sslCertificatesURL(project, region)+"/"+url.PathEscape(name), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// computeOperation sends a request returning a Compute Engine operation and
+// waits for the operation to complete.
+func (c *Client) computeOperation(ctx context.Context, method, u string, payload []byte) error {
+	for {
+		respBody, err := c.do(ctx, method, u, payload)
+		if err != nil {
+			return err
+		}
+		var operation struct {
+			Name     string `json:"name"`
+			Status   string `json:"status"`
+			SelfLink string `json:"selfLink"`
+			Error    *struct {
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &operation); err != nil {
+			return fmt.Errorf("failed to decode operation: %w", err)
+		}
+		if operation.Error != nil && len(operation.Error.Errors) > 0 {
+			messages := make([]string, 0, len(operation.Error.Errors))
+			for _, e := range operation.Error.Errors {
+				messages = append(messages, e.Message)
+			}
+			return fmt.Errorf("operation %s failed: %s", operation.Name, strings.Join(messages, "; "))
+		}
+		if operation.Status == "DONE" {
+			return nil
+		}
+
+		if err := c.wait(ctx); err != nil {
+			return err
+		}
+		method, u, payload = http.MethodGet, operation.SelfLink, nil
+	}
+}
+
+func (c *Client) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(c.PollInterval):
+		return nil
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, url)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%s %s returned %s: %s", method, url, resp.Status, googleError(body))
+	}
+	return body, nil
+}
+
+// googleError returns the message of a Google API error response, or the
+// body.
+func googleError(body []byte) string {
+	var resp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err == nil && resp.Error.Message != "" {
+		return resp.Error.Message
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package gcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"certMgr/internal/gcp"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *gcp.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gcp.NewClient("token")
+	require.NoError(t, err)
+	client.CertificateManagerURL = server.URL + "/cm"
+	client.ComputeURL = server.URL + "/compute"
+	client.PollInterval = time.Millisecond
+	return client
+}
+
+func TestManagedCertificateRoundTrip(t *testing.T) {
+	const certificatePath = "/cm/projects/p/locations/global/certificates/web"
+
+	var mu sync.Mutex
+	stored := ""
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/cm/projects/p/locations/global/certificates":
+			require.Equal(t, "web", r.URL.Query().Get("certificateId"))
+			var payload struct {
+				SelfManaged struct {
+					PEMCertificate string `json:"pemCertificate"`
+					PEMPrivateKey  string `json:"pemPrivateKey"`
+				} `json:"selfManaged"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			require.Equal(t, "key", payload.SelfManaged.PEMPrivateKey)
+			stored = payload.SelfManaged.PEMCertificate
+			_, _ = w.Write([]byte(`{"name": "projects/p/locations/global/operations/op-1", "done": false}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/cm/projects/p/locations/global/operations/op-1":
+			polls++
+			_, _ = w.Write([]byte(`{"name": "projects/p/locations/global/operations/op-1", "done": true}`))
+		case r.Method == http.MethodGet && r.URL.Path == certificatePath && stored != "":
+			_ = json.NewEncoder(w).Encode(map[string]string{"name": "web", "pemCertificate": stored})
+		case r.Method == http.MethodDelete && r.URL.Path == certificatePath && stored != "":
+			stored = ""
+			_, _ = w.Write([]byte(`{"name": "projects/p/locations/global/operations/op-2", "done": true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "not found"}}`))
+		}
+	})
+	ctx := context.Background()
+
+	require.NoError(t, client.CreateManagedCertificate(ctx, "p", "global", "web", gcp.Certificate{CertificatePEM: "cert", PrivateKeyPEM: "key"}))
+	require.Equal(t, 1, polls)
+
+	certificate, err := client.GetManagedCertificate(ctx, "p", "global", "web")
+	require.NoError(t, err)
+	require.Equal(t, "cert", certificate.CertificatePEM)
+
+	require.NoError(t, client.DeleteManagedCertificate(ctx, "p", "global", "web"))
+	_, err = client.GetManagedCertificate(ctx, "p", "global", "web")
+	require.ErrorIs(t, err, gcp.ErrNotFound)
+
+	// Deleting a certificate that is already gone is not an error.
+	require.NoError(t, client.DeleteManagedCertificate(ctx, "p", "global", "web"))
+}
+
+func TestCreateSSLCertificateRegional(t *testing.T) {
+	var operationURL string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/compute/projects/p/regions/europe-west1/sslCertificates":
+			_ = json.NewEncoder(w).Encode(map[string]string{"name": "op-1", "status": "RUNNING", "selfLink": operationURL})
+		case r.Method == http.MethodGet && r.URL.Path == "/compute/projects/p/regions/europe-west1/operations/op-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name":   "op-1",
+				"status": "DONE",
+				"error":  map[string]any{"errors": []map[string]string{{"message": "certificate is invalid"}}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	operationURL = client.ComputeURL + "/projects/p/regions/europe-west1/operations/op-1"
+
+	err := client.CreateSSLCertificate(context.Background(), "p", "europe-west1", "web", gcp.Certificate{CertificatePEM: "cert", PrivateKeyPEM: "key"})
+	require.ErrorContains(t, err, "certificate is invalid")
+}
+
+func TestNewClientRequiresToken(t *testing.T) {
+	_, err := gcp.NewClient("")
+	require.Error(t, err)
+}
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package keyvault is a minimal client for the certificates of Azure Key
+// Vault, used to export issued certificates.
+package keyvault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiVersion is the Key Vault REST API version the client speaks.
+const apiVersion = "7.4"
+
+// AuthorityURL is the Microsoft Entra ID endpoint tokens are obtained from.
+const AuthorityURL = "https://login.microsoftonline.com"
+
+// ErrNotFound is returned when a certificate does not exist.
+var ErrNotFound = errors.New("certificate not found")
+
+// Client manages the certificates of one key vault.
+type Client struct {
+	HTTPClient *http.Client
+	VaultURL   string
+	Token      string
+}
+
+// NewClient returns a client for the key vault at vaultURL, for example
+// https://example.vault.azure.net, authenticating with the access token.
+func NewClient(vaultURL, token string) (*Client, error) {
+	if vaultURL == "" {
+		return nil, errors.New("key vault URL is required")
+	}
+	if token == "" {
+		return nil, errors.New("azure access token is required")
+	}
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		VaultURL:   strings.TrimSuffix(vaultURL, "/"),
+		Token:      token,
+	}, nil
+}
+
+// ClientCredentialsToken obtains an access token for Key Vault from the
+// Microsoft Entra ID tenant at authorityURL with the client credentials
+// grant.
+func ClientCredentialsToken(ctx context.Context, httpClient *http.Client, authorityURL, tenantID, clientID, clientSecret string) (string, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", strings.TrimSuffix(authorityURL, "/"), url.PathEscape(tenantID))
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {"https://vault.azure.net/.default"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("POST %s returned %s: %s", tokenURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("token endpoint returned no access token")
+	}
+	return token.AccessToken, nil
+}
+
+// Certificate is a certificate object of Key Vault.
+type Certificate struct {
+	// ID is the identifier of the certificate version,
+	// <vault>/certificates/<name>/<version>.
+	ID string `json:"id"`
+	// CER is the DER encoded certificate.
+	CER  []byte            `json:"cer"`
+	Tags map[string]string `json:"tags"`
+}
+
+// Version returns the version of the certificate, the last segment of its
+// identifier.
+func (c *Certificate) Version() string {
+	return c.ID[strings.LastIndex(c.ID, "/")+1:]
+}
+
+// VersionlessID returns the identifier of the certificate without its
+// version, which always refers to the latest version.
+func (c *Certificate) VersionlessID() string {
+	return strings.TrimSuffix(c.ID, "/"+c.Version())
+}
+
+func (c *Client) certificateURL(name, suffix string) string {
+	return fmt.Sprintf("%s/certificates/%s%s?api-version=%s", c.VaultURL, url.PathEscape(name), suffix, apiVersion)
+}
+
+// ImportCertificate imports the PKCS#12 archive pfx, protected by password,
+// as a new version of the certificate name and returns that version.
+func (c *Client) ImportCertificate(ctx context.Context, name string, pfx []byte, password string, tags map[string]string) (*Certificate, error) {
+	payload, err := json.Marshal(map[string]any{
+		"value": base64.StdEncoding.EncodeToString(pfx),
+		"pwd":   password,
+		"policy": map[string]any{
+			"secret_props": map[string]string{"contentType": "application/x-pkcs12"},
+		},
+		"tags": tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal failed: %w", err)
+	}
+
+	body, err := c.do(ctx, http.MethodPost, c.certificateURL(name, "/import"), payload)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCertificate(body)
+}
+
+// GetCertificate returns the latest version of the certificate name, or
+// ErrNotFound.
+func (c *Client) GetCertificate(ctx context.Context, name string) (*Certificate, error) {
+	body, err := c.do(ctx, http.MethodGet, c.certificateURL(name, ""), nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCertificate(body)
+}
+
+// DeleteCertificate deletes the certificate name with all its versions.
+// With soft-delete enabled on the vault, it is purged as well when purge is
+// set, so that the name can be reused right away. Certificates that do not
+// exist are not an error.
+func (c *Client) DeleteCertificate(ctx context.Context, name string, purge bool) error {
+	_, err := c.do(ctx, http.MethodDelete, c.certificateURL(name, ""), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil || !purge {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/deletedcertificates/%s?api-version=%s", c.VaultURL, url.PathEscape(name), apiVersion)
+	_, err = c.do(ctx, http.MethodDelete, u, nil)
+	return err
+}
+
+func decodeCertificate(body []byte) (*Certificate, error) {
+	var certificate Certificate
+	if err := json.Unmarshal(body, &certificate); err != nil {
+		return nil, fmt.Errorf("failed to decode key vault response: %w", err)
+	}
+	return &certificate, nil
+}
+
+func (c *Client) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, url)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%s %s returned %s: %s", method, url, resp.Status, keyVaultError(body))
+	}
+	return body, nil
+}
+
+// keyVaultError returns the message of a Key Vault error response, or the
+// body.
+func keyVaultError(body []byte) string {
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err == nil && resp.Error.Message != "" {
+		return resp.Error.Code + ": " + resp.Error.Message
+	}
+	return strings.TrimSpace(string(body))
+}
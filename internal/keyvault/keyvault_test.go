@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package keyvault_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"certMgr/internal/keyvault"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateRoundTrip(t *testing.T) {
+	var mu sync.Mutex
+	var stored map[string]any
+	var purged bool
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Equal(t, "7.4", r.URL.Query().Get("api-version"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/certificates/web/import":
+			var payload map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			stored = payload
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":   server.URL + "/certificates/web/v1",
+				"cer":  base64.StdEncoding.EncodeToString([]byte("der")),
+				"tags": payload["tags"],
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/certificates/web" && stored != nil:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": server.URL + "/certificates/web/v1", "tags": stored["tags"]})
+		case r.Method == http.MethodDelete && r.URL.Path == "/certificates/web" && stored != nil:
+			stored = nil
+		case r.Method == http.MethodDelete && r.URL.Path == "/deletedcertificates/web":
+			purged = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": "CertificateNotFound", "message": "not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := keyvault.NewClient(server.URL+"/", "token")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	imported, err := client.ImportCertificate(ctx, "web", []byte("pfx"), "", map[string]string{"certmgr_serial": "0a"})
+	require.NoError(t, err)
+	require.Equal(t, "v1", imported.Version())
+	require.Equal(t, server.URL+"/certificates/web", imported.VersionlessID())
+	require.Equal(t, []byte("der"), imported.CER)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("pfx")), stored["value"])
+
+	certificate, err := client.GetCertificate(ctx, "web")
+	require.NoError(t, err)
+	require.Equal(t, "0a", certificate.Tags["certmgr_serial"])
+
+	require.NoError(t, client.DeleteCertificate(ctx, "web", true))
+	require.True(t, purged)
+	_, err = client.GetCertificate(ctx, "web")
+	require.ErrorIs(t, err, keyvault.ErrNotFound)
+
+	// Deleting a certificate that is already gone is not an error.
+	require.NoError(t, client.DeleteCertificate(ctx, "web", false))
+}
+
+func TestClientCredentialsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/tenant/oauth2/v2.0/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		require.Equal(t, "https://vault.azure.net/.default", r.PostForm.Get("scope"))
+		_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 3599}`))
+	}))
+	defer server.Close()
+
+	token, err := keyvault.ClientCredentialsToken(context.Background(), nil, server.URL, "tenant", "id", "secret")
+	require.NoError(t, err)
+	require.Equal(t, "token", token)
+}
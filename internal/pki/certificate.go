@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// FingerprintAlgorithms lists the digests supported by Fingerprint.
+var FingerprintAlgorithms = []string{"sha1", "sha256", "sha384", "sha512"}
+
+// ParseCertificatePEM decodes the first "CERTIFICATE" PEM block in data.
+func ParseCertificatePEM(data string) (*x509.Certificate, error) {
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no CERTIFICATE PEM block found")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		return cert, nil
+	}
+}
+
+// ParseCertificatesPEM decodes every "CERTIFICATE" PEM block in data, in
+// order, ignoring blocks of other types.
+func ParseCertificatesPEM(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// Fingerprint returns the lower-case hex digest of the DER encoding of cert.
+func Fingerprint(cert *x509.Certificate, algorithm string) (string, error) {
+	var h hash.Hash
+	switch algorithm {
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	case "sha384":
+		h = sha512.New384()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported fingerprint algorithm %q", algorithm)
+	}
+	h.Write(cert.Raw)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCertificatePEMSkipsOtherBlocks(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert := issueCertificate(t, &x509.Certificate{SerialNumber: big.NewInt(7)}, nil, key, key)
+
+	keyPEM, err := pki.EncodePrivateKeyPEM(key)
+	require.NoError(t, err)
+	bundle := keyPEM + string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+
+	parsed, err := pki.ParseCertificatePEM(bundle)
+	require.NoError(t, err)
+	require.Equal(t, cert.Raw, parsed.Raw)
+
+	_, err = pki.ParseCertificatePEM(keyPEM)
+	require.Error(t, err)
+}
+
+func TestFingerprint(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert := issueCertificate(t, &x509.Certificate{SerialNumber: big.NewInt(7)}, nil, key, key)
+
+	sum := sha256.Sum256(cert.Raw)
+	got, err := pki.Fingerprint(cert, "sha256")
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(sum[:]), got)
+
+	_, err = pki.Fingerprint(cert, "md5")
+	require.Error(t, err)
+}
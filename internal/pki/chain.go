@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+)
+
+// SplitChain splits a PEM bundle into its certificates, each re-encoded as a
+// single PEM block. When the certificates form one chain, they are returned
+// leaf first regardless of their order in the bundle; otherwise the bundle
+// order is kept.
+func SplitChain(data string) ([]string, error) {
+	certs, err := ParseCertificatesPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := orderChain(certs)
+	blocks := make([]string, 0, len(ordered))
+	for _, cert := range ordered {
+		blocks = append(blocks, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+	}
+	return blocks, nil
+}
+
+// orderChain returns certs ordered from leaf to root, or certs unchanged if
+// they do not form a single chain.
+func orderChain(certs []*x509.Certificate) []*x509.Certificate {
+	if len(certs) < 2 {
+		return certs
+	}
+
+	issuedBy := func(child, parent *x509.Certificate) bool {
+		return child != parent && bytes.Equal(child.RawIssuer, parent.RawSubject) &&
+			child.CheckSignatureFrom(parent) == nil
+	}
+
+	// The leaf is the only certificate that issued none of the others.
+	var leaf *x509.Certificate
+	for _, candidate := range certs {
+		issuer := false
+		for _, other := range certs {
+			if issuedBy(other, candidate) {
+				issuer = true
+				break
+			}
+		}
+		if !issuer {
+			if leaf != nil {
+				return certs
+			}
+			leaf = candidate
+		}
+	}
+	if leaf == nil {
+		return certs
+	}
+
+	ordered := []*x509.Certificate{leaf}
+	for len(ordered) < len(certs) {
+		current := ordered[len(ordered)-1]
+		var next *x509.Certificate
+		for _, candidate := range certs {
+			if issuedBy(current, candidate) {
+				next = candidate
+				break
+			}
+		}
+		if next == nil {
+			return certs
+		}
+		ordered = append(ordered, next)
+	}
+	return ordered
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitChainOrdersLeafFirst(t *testing.T) {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	root := issueCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, rootKey, rootKey)
+
+	intermediateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	intermediate := issueCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Intermediate"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, root, intermediateKey, rootKey)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leaf := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "host.example.org"},
+	}, intermediate, leafKey, intermediateKey)
+
+	bundle := encodeCertificatePEM(root) + encodeCertificatePEM(leaf) + encodeCertificatePEM(intermediate)
+	blocks, err := pki.SplitChain(bundle)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		encodeCertificatePEM(leaf),
+		encodeCertificatePEM(intermediate),
+		encodeCertificatePEM(root),
+	}, blocks)
+}
+
+func TestSplitChainKeepsUnrelatedOrder(t *testing.T) {
+	var bundle string
+	var want []string
+	for i := int64(1); i <= 2; i++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		cert := issueCertificate(t, &x509.Certificate{SerialNumber: big.NewInt(i)}, nil, key, key)
+		bundle += encodeCertificatePEM(cert)
+		want = append(want, encodeCertificatePEM(cert))
+	}
+
+	blocks, err := pki.SplitChain(bundle)
+	require.NoError(t, err)
+	require.Equal(t, want, blocks)
+}
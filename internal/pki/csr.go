@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// CSRSubject holds the subject and subject alternative names of a
+// certificate signing request.
+type CSRSubject struct {
+	CommonName         string
+	Organization       string
+	OrganizationalUnit string
+	Country            string
+	Province           string
+	Locality           string
+	DNSNames           []string
+	IPAddresses        []string
+	EmailAddresses     []string
+}
+
+// CreateCSR creates a PEM encoded "CERTIFICATE REQUEST" signed by key.
+func CreateCSR(key crypto.Signer, subject CSRSubject) (string, error) {
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:         subject.CommonName,
+			Organization:       nonEmpty(subject.Organization),
+			OrganizationalUnit: nonEmpty(subject.OrganizationalUnit),
+			Country:            nonEmpty(subject.Country),
+			Province:           nonEmpty(subject.Province),
+			Locality:           nonEmpty(subject.Locality),
+		},
+		DNSNames:       subject.DNSNames,
+		EmailAddresses: subject.EmailAddresses,
+	}
+
+	for _, address := range subject.IPAddresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP address %q", address)
+		}
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to create certificate request: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// ParseCSRPEM decodes a PEM encoded "CERTIFICATE REQUEST".
+func ParseCSRPEM(data string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("expected a CERTIFICATE REQUEST PEM block, got %q", block.Type)
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate request: %w", err)
+	}
+	return csr, nil
+}
+
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCSRRoundTrip(t *testing.T) {
+	key, err := pki.GenerateKey("ECDSA", 0, "P384")
+	require.NoError(t, err)
+
+	encoded, err := pki.CreateCSR(key, pki.CSRSubject{
+		CommonName:  "host.example.org",
+		DNSNames:    []string{"host.example.org", "alias.example.org"},
+		IPAddresses: []string{"192.0.2.10"},
+	})
+	require.NoError(t, err)
+
+	csr, err := pki.ParseCSRPEM(encoded)
+	require.NoError(t, err)
+	require.NoError(t, csr.CheckSignature())
+	require.Equal(t, "host.example.org", csr.Subject.CommonName)
+	require.Equal(t, []string{"host.example.org", "alias.example.org"}, csr.DNSNames)
+	require.Equal(t, "192.0.2.10", csr.IPAddresses[0].String())
+
+	algorithm, size, err := pki.DescribePublicKey(csr.PublicKey)
+	require.NoError(t, err)
+	require.Equal(t, "ECDSA", algorithm)
+	require.Equal(t, 384, size)
+}
+
+func TestParseCSRPEMRejectsOtherBlocks(t *testing.T) {
+	key, err := pki.GenerateKey("ED25519", 0, "")
+	require.NoError(t, err)
+	encoded, err := pki.EncodePrivateKeyPEM(key)
+	require.NoError(t, err)
+
+	_, err = pki.ParseCSRPEM(encoded)
+	require.Error(t, err)
+}
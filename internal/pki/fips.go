@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FIPSKeyAlgorithms are the KeyAlgorithms allowed in FIPS mode. ED25519 is
+// left out, as FIPS 140-2 validated modules do not cover EdDSA.
+var FIPSKeyAlgorithms = []string{"RSA", "ECDSA"}
+
+// ErrNotFIPSApproved is returned for keys that may not be used in FIPS mode.
+var ErrNotFIPSApproved = errors.New("not FIPS approved")
+
+// CheckFIPSKey returns an error wrapping ErrNotFIPSApproved unless key is an
+// RSA key of at least 2048 bits or an ECDSA key on one of ECDSACurves.
+func CheckFIPSKey(key crypto.PublicKey) error {
+	algorithm, bits, err := DescribePublicKey(key)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(FIPSKeyAlgorithms, algorithm) {
+		return fmt.Errorf("%s keys are %w, use one of: %v", algorithm, ErrNotFIPSApproved, FIPSKeyAlgorithms)
+	}
+	if algorithm == "RSA" && bits < 2048 {
+		return fmt.Errorf("RSA keys of %d bits are %w, use at least 2048 bits", bits, ErrNotFIPSApproved)
+	}
+	if k, ok := key.(*ecdsa.PublicKey); ok {
+		if curve := strings.ReplaceAll(k.Curve.Params().Name, "-", ""); !slices.Contains(ECDSACurves, curve) {
+			return fmt.Errorf("ECDSA keys on curve %s are %w, use one of: %v", k.Curve.Params().Name, ErrNotFIPSApproved, ECDSACurves)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFIPSKey(t *testing.T) {
+	for _, algorithm := range pki.FIPSKeyAlgorithms {
+		key, err := pki.GenerateKey(algorithm, 2048, "P384")
+		require.NoError(t, err)
+		require.NoError(t, pki.CheckFIPSKey(key.Public()), algorithm)
+	}
+
+	key, err := pki.GenerateKey("ED25519", 0, "")
+	require.NoError(t, err)
+	require.ErrorIs(t, pki.CheckFIPSKey(key.Public()), pki.ErrNotFIPSApproved)
+
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	require.ErrorIs(t, pki.CheckFIPSKey(weak.Public()), pki.ErrNotFIPSApproved)
+
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	require.ErrorIs(t, pki.CheckFIPSKey(p224.Public()), pki.ErrNotFIPSApproved)
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// Key algorithms and ECDSA curves supported by GenerateKey.
+var (
+	KeyAlgorithms = []string{"RSA", "ECDSA", "ED25519"}
+	ECDSACurves   = []string{"P256", "P384", "P521"}
+)
+
+// GenerateKey creates a new private key. rsaBits is only used for RSA keys
+// and curve only for ECDSA keys.
+func GenerateKey(algorithm string, rsaBits int, curve string) (crypto.Signer, error) {
+	switch algorithm {
+	case "RSA":
+		if rsaBits < 2048 {
+			return nil, fmt.Errorf("RSA keys must be at least 2048 bits, got %d", rsaBits)
+		}
+		return rsa.GenerateKey(rand.Reader, rsaBits)
+	case "ECDSA":
+		c, err := ellipticCurve(curve)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(c, rand.Reader)
+	case "ED25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+}
+
+func ellipticCurve(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P256":
+		return elliptic.P256(), nil
+	case "P384":
+		return elliptic.P384(), nil
+	case "P521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported ECDSA curve %q", name)
+	}
+}
+
+// DescribePublicKey returns the algorithm, as named in KeyAlgorithms, and the
+// size in bits of key.
+func DescribePublicKey(key crypto.PublicKey) (string, int, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return "RSA", k.N.BitLen(), nil
+	case *ecdsa.PublicKey:
+		return "ECDSA", k.Curve.Params().BitSize, nil
+	case ed25519.PublicKey:
+		return "ED25519", 256, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// EncodePrivateKeyPEM encodes key as a PKCS#8 "PRIVATE KEY" PEM block.
+func EncodePrivateKeyPEM(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// EncodePublicKeyPEM encodes the public half of key as a PKIX "PUBLIC KEY"
+// PEM block.
+func EncodePublicKeyPEM(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// PublicKeyFingerprintSHA256 returns the hex SHA-256 digest of the DER
+// encoded SubjectPublicKeyInfo of key.
+func PublicKeyFingerprintSHA256(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SPKIPin returns the base64 SHA-256 digest of the SubjectPublicKeyInfo in
+// the first CERTIFICATE or PUBLIC KEY PEM block of data, as used for HPKP
+// style pinning.
+func SPKIPin(data string) (string, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return "", errors.New("no PEM block found")
+	}
+
+	var spki []byte
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		spki = cert.RawSubjectPublicKeyInfo
+	case "PUBLIC KEY":
+		if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return "", fmt.Errorf("failed to parse public key: %w", err)
+		}
+		spki = block.Bytes
+	default:
+		return "", fmt.Errorf("expected a CERTIFICATE or PUBLIC KEY PEM block, got %q", block.Type)
+	}
+
+	sum := sha256.Sum256(spki)
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// ParsePrivateKeyPEM decodes a PKCS#8, PKCS#1 or SEC 1 private key.
+func ParsePrivateKeyPEM(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported private key in %q PEM block", block.Type)
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKeyRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		algorithm string
+		curve     string
+	}{
+		{algorithm: "RSA"},
+		{algorithm: "ECDSA", curve: "P384"},
+		{algorithm: "ED25519"},
+	} {
+		key, err := pki.GenerateKey(tc.algorithm, 2048, tc.curve)
+		require.NoError(t, err, tc.algorithm)
+
+		encoded, err := pki.EncodePrivateKeyPEM(key)
+		require.NoError(t, err, tc.algorithm)
+
+		parsed, err := pki.ParsePrivateKeyPEM(encoded)
+		require.NoError(t, err, tc.algorithm)
+
+		want, err := pki.PublicKeyFingerprintSHA256(key.Public())
+		require.NoError(t, err)
+		got, err := pki.PublicKeyFingerprintSHA256(parsed.Public())
+		require.NoError(t, err)
+		require.Equal(t, want, got, tc.algorithm)
+	}
+}
+
+func TestGenerateKeyRejectsWeakParameters(t *testing.T) {
+	_, err := pki.GenerateKey("RSA", 1024, "")
+	require.Error(t, err)
+
+	_, err = pki.GenerateKey("ECDSA", 0, "P224")
+	require.Error(t, err)
+
+	_, err = pki.GenerateKey("DSA", 0, "")
+	require.Error(t, err)
+}
+
+func TestSPKIPinMatchesPublicKey(t *testing.T) {
+	key, err := pki.GenerateKey("ED25519", 0, "")
+	require.NoError(t, err)
+
+	publicPEM, err := pki.EncodePublicKeyPEM(key.Public())
+	require.NoError(t, err)
+	pin, err := pki.SPKIPin(publicPEM)
+	require.NoError(t, err)
+	require.Len(t, pin, 44)
+
+	privatePEM, err := pki.EncodePrivateKeyPEM(key)
+	require.NoError(t, err)
+	_, err = pki.SPKIPin(privatePEM)
+	require.Error(t, err)
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// OCSPStatus is the outcome of an OCSP check.
+type OCSPStatus struct {
+	// Status is one of "good", "revoked" or "unknown".
+	Status     string
+	ThisUpdate time.Time
+	NextUpdate time.Time
+	RevokedAt  time.Time
+}
+
+// maxOCSPResponseSize bounds the responses read from OCSP responders and
+// issuer URLs.
+const maxOCSPResponseSize = 1 << 20
+
+// CheckOCSP asks the OCSP responder named in cert for its revocation status.
+// issuer must be the certificate that signed cert.
+func CheckOCSP(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate) (*OCSPStatus, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, errors.New("certificate names no OCSP responder")
+	}
+
+	request, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCSP request: %w", err)
+	}
+
+	body, err := fetch(ctx, client, http.MethodPost, cert.OCSPServer[0], request)
+	if err != nil {
+		return nil, fmt.Errorf("OCSP request failed: %w", err)
+	}
+
+	response, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCSP response: %w", err)
+	}
+
+	status := &OCSPStatus{
+		ThisUpdate: response.ThisUpdate,
+		NextUpdate: response.NextUpdate,
+	}
+	switch response.Status {
+	case ocsp.Good:
+		status.Status = "good"
+	case ocsp.Revoked:
+		status.Status = "revoked"
+		status.RevokedAt = response.RevokedAt
+	default:
+		status.Status = "unknown"
+	}
+	return status, nil
+}
+
+// FetchIssuer downloads the issuer of cert from its Authority Information
+// Access URL.
+func FetchIssuer(ctx context.Context, client *http.Client, cert *x509.Certificate) (*x509.Certificate, error) {
+	if len(cert.IssuingCertificateURL) == 0 {
+		return nil, errors.New("certificate names no issuer URL")
+	}
+
+	body, err := fetch(ctx, client, http.MethodGet, cert.IssuingCertificateURL[0], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issuer: %w", err)
+	}
+
+	// Issuers are usually published as DER but some CAs serve PEM.
+	if issuer, err := x509.ParseCertificate(body); err == nil {
+		return issuer, nil
+	}
+	return ParseCertificatePEM(string(body))
+}
+
+func fetch(ctx context.Context, client *http.Client, method, url string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/ocsp-request")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxOCSPResponseSize))
+}
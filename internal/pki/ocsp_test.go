@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestCheckOCSP(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca := issueCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, caKey, caKey)
+
+	revokedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		request, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		response, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       ocsp.Revoked,
+			SerialNumber: request.SerialNumber,
+			ThisUpdate:   revokedAt,
+			NextUpdate:   revokedAt.Add(24 * time.Hour),
+			RevokedAt:    revokedAt,
+		}, caKey)
+		require.NoError(t, err)
+		_, _ = w.Write(response)
+	}))
+	defer responder.Close()
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leaf := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "host.example.org"},
+		OCSPServer:   []string{responder.URL},
+	}, ca, leafKey, caKey)
+
+	status, err := pki.CheckOCSP(context.Background(), responder.Client(), leaf, ca)
+	require.NoError(t, err)
+	require.Equal(t, "revoked", status.Status)
+	require.True(t, revokedAt.Equal(status.RevokedAt))
+	require.True(t, revokedAt.Add(24*time.Hour).Equal(status.NextUpdate))
+}
+
+func TestCheckOCSPWithoutResponder(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert := issueCertificate(t, &x509.Certificate{SerialNumber: big.NewInt(1)}, nil, key, key)
+
+	_, err = pki.CheckOCSP(context.Background(), http.DefaultClient, cert, cert)
+	require.Error(t, err)
+}
+
+func issueCertificate(t *testing.T, template, parent *x509.Certificate, key *ecdsa.PrivateKey, signer crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		parent = template
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// PEMTypes lists the kinds of material understood by ValidatePEM.
+var PEMTypes = []string{"CERTIFICATE", "PRIVATE KEY", "CERTIFICATE REQUEST"}
+
+// ValidatePEM reports why data is not well-formed PEM of the given kind, or
+// nil if it is. data must consist of one or more PEM blocks of that kind and
+// nothing else; for "PRIVATE KEY", PKCS#1 and SEC 1 keys are accepted too.
+func ValidatePEM(data, kind string) error {
+	rest := bytes.TrimSpace([]byte(data))
+	if len(rest) == 0 {
+		return errors.New("no PEM block found")
+	}
+
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return errors.New("unexpected data outside PEM blocks")
+		}
+		if err := validateBlock(block, kind); err != nil {
+			return err
+		}
+		rest = bytes.TrimSpace(rest)
+	}
+	return nil
+}
+
+func validateBlock(block *pem.Block, kind string) error {
+	switch kind {
+	case "CERTIFICATE":
+		if block.Type != kind {
+			return fmt.Errorf("expected a %s PEM block, got %q", kind, block.Type)
+		}
+		_, err := x509.ParseCertificate(block.Bytes)
+		return err
+	case "CERTIFICATE REQUEST":
+		if block.Type != kind && block.Type != "NEW CERTIFICATE REQUEST" {
+			return fmt.Errorf("expected a %s PEM block, got %q", kind, block.Type)
+		}
+		_, err := x509.ParseCertificateRequest(block.Bytes)
+		return err
+	case "PRIVATE KEY":
+		_, err := ParsePrivateKeyPEM(string(pem.EncodeToMemory(block)))
+		return err
+	default:
+		return fmt.Errorf("unsupported PEM type %q", kind)
+	}
+}
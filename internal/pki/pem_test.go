@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePEM(t *testing.T) {
+	key, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	keyPEM, err := pki.EncodePrivateKeyPEM(key)
+	require.NoError(t, err)
+	csrPEM, err := pki.CreateCSR(key, pki.CSRSubject{CommonName: "host.example.org"})
+	require.NoError(t, err)
+
+	require.NoError(t, pki.ValidatePEM(keyPEM, "PRIVATE KEY"))
+	require.NoError(t, pki.ValidatePEM("\n"+csrPEM+"\n", "CERTIFICATE REQUEST"))
+
+	require.Error(t, pki.ValidatePEM(keyPEM, "CERTIFICATE"))
+	require.Error(t, pki.ValidatePEM(csrPEM+"trailing", "CERTIFICATE REQUEST"))
+	require.Error(t, pki.ValidatePEM("", "PRIVATE KEY"))
+	require.Error(t, pki.ValidatePEM(keyPEM, "PUBLIC KEY"))
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"fmt"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// EncodePKCS12 bundles a certificate, its chain and private key into a
+// password protected PKCS#12 archive. chainPEM may be empty or hold several
+// certificates.
+func EncodePKCS12(certPEM, chainPEM, keyPEM, password string) ([]byte, error) {
+	cert, err := ParseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := ParseCertificatesPEM(chainPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := pkcs12.Modern.Encode(key, cert, chain, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode PKCS#12: %w", err)
+	}
+	return archive, nil
+}
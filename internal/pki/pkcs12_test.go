@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestEncodePKCS12RoundTrip(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca := issueCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, caKey, caKey)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leaf := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "host.example.org"},
+	}, ca, leafKey, caKey)
+
+	keyPEM, err := pki.EncodePrivateKeyPEM(leafKey)
+	require.NoError(t, err)
+
+	archive, err := pki.EncodePKCS12(encodeCertificatePEM(leaf), encodeCertificatePEM(ca), keyPEM, "changeit")
+	require.NoError(t, err)
+
+	key, cert, chain, err := pkcs12.DecodeChain(archive, "changeit")
+	require.NoError(t, err)
+	require.Equal(t, leaf.Raw, cert.Raw)
+	require.Len(t, chain, 1)
+	require.Equal(t, ca.Raw, chain[0].Raw)
+	require.True(t, leafKey.Equal(key))
+}
+
+func encodeCertificatePEM(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
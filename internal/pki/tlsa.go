@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+)
+
+// TLSARecord returns the RDATA of a DANE TLSA record (RFC 6698) for cert,
+// such as "3 1 1 <hex>".
+func TLSARecord(cert *x509.Certificate, usage, selector, matchingType int) (string, error) {
+	if usage < 0 || usage > 3 {
+		return "", fmt.Errorf("certificate usage must be between 0 and 3, got %d", usage)
+	}
+
+	var data []byte
+	switch selector {
+	case 0:
+		data = cert.Raw
+	case 1:
+		data = cert.RawSubjectPublicKeyInfo
+	default:
+		return "", fmt.Errorf("selector must be 0 or 1, got %d", selector)
+	}
+
+	switch matchingType {
+	case 0:
+	case 1:
+		sum := sha256.Sum256(data)
+		data = sum[:]
+	case 2:
+		sum := sha512.Sum512(data)
+		data = sum[:]
+	default:
+		return "", fmt.Errorf("matching type must be between 0 and 2, got %d", matchingType)
+	}
+
+	return fmt.Sprintf("%d %d %d %s", usage, selector, matchingType, hex.EncodeToString(data)), nil
+}
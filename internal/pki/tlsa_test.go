@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pki_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSARecord(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert := issueCertificate(t, &x509.Certificate{SerialNumber: big.NewInt(1)}, nil, key, key)
+
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	record, err := pki.TLSARecord(cert, 3, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, "3 1 1 "+hex.EncodeToString(sum[:]), record)
+
+	record, err = pki.TLSARecord(cert, 2, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "2 0 0 "+hex.EncodeToString(cert.Raw), record)
+
+	_, err = pki.TLSARecord(cert, 4, 1, 1)
+	require.Error(t, err)
+	_, err = pki.TLSARecord(cert, 3, 2, 1)
+	require.Error(t, err)
+	_, err = pki.TLSARecord(cert, 3, 1, 3)
+	require.Error(t, err)
+}
@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                   = &aclResource{}
+	_ resource.ResourceWithConfigure      = &aclResource{}
+	_ resource.ResourceWithImportState    = &aclResource{}
+	_ resource.ResourceWithValidateConfig = &aclResource{}
+)
+
+func NewACLResource() resource.Resource {
+	return &aclResource{}
+}
+
+type aclResourceModel struct {
+	ID            types.Int64   `tfsdk:"id"`
+	Hostname      hostnameValue `tfsdk:"hostname"`
+	Hostgroup     types.String  `tfsdk:"hostgroup"`
+	Principal     types.String  `tfsdk:"principal"`
+	PrincipalType types.String  `tfsdk:"principal_type"`
+	Permissions   types.Set     `tfsdk:"permissions"`
+	Organization  types.String  `tfsdk:"organization"`
+}
+
+type aclResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *aclResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl"
+}
+
+func (r *aclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages who may request or renew certificates for a hostname or hostgroup.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the ACL entry.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname the grant applies to. Exactly one of `hostname` or `hostgroup` must be set.",
+				Optional:    true,
+				CustomType:  hostnameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						hostnameChanged,
+						"Changing the hostname forces a new ACL entry.",
+						"Changing the hostname forces a new ACL entry.",
+					),
+				},
+			},
+			"hostgroup": schema.StringAttribute{
+				Description: "Hostgroup the grant applies to. Exactly one of `hostname` or `hostgroup` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Description: "User or e-group receiving the grant.",
+				Required:    true,
+			},
+			"principal_type": schema.StringAttribute{
+				Description: "Kind of principal, one of: " + strings.Join(certMgr.ACLPrincipalTypes, ", ") + ".",
+				Required:    true,
+			},
+			"permissions": schema.SetAttribute{
+				Description: "Granted permissions, any of: " + strings.Join(certMgr.ACLPermissions, ", ") + ".",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the ACL entry is managed in, overriding the provider's organization. " +
+					"Changing this forces a new ACL entry.",
+			),
+		},
+	}
+}
+
+func (r *aclResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config aclResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Hostname.IsUnknown() && !config.Hostgroup.IsUnknown() &&
+		config.Hostname.IsNull() == config.Hostgroup.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Invalid ACL Target",
+			"Exactly one of hostname or hostgroup must be set.",
+		)
+	}
+
+	if !config.PrincipalType.IsNull() && !config.PrincipalType.IsUnknown() &&
+		!slices.Contains(certMgr.ACLPrincipalTypes, config.PrincipalType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("principal_type"),
+			"Invalid Principal Type",
+			fmt.Sprintf("principal_type must be one of: %s. Got: %q", strings.Join(certMgr.ACLPrincipalTypes, ", "), config.PrincipalType.ValueString()),
+		)
+	}
+
+	if config.Permissions.IsNull() || config.Permissions.IsUnknown() {
+		return
+	}
+	var permissions []types.String
+	resp.Diagnostics.Append(config.Permissions.ElementsAs(ctx, &permissions, false)...)
+	for _, permission := range permissions {
+		if permission.IsUnknown() {
+			continue
+		}
+		if !slices.Contains(certMgr.ACLPermissions, permission.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("permissions"),
+				"Invalid Permission",
+				fmt.Sprintf("Permissions must be any of: %s. Got: %q", strings.Join(certMgr.ACLPermissions, ", "), permission.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *aclResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan aclResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	acl, diags := plan.toACL(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateACL(ctx, acl)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ACL",
+			"Could not create ACL entry: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(created.ID))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state aclResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	acl, err := r.client.GetACL(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoACL) {
+			resp.Diagnostics.AddWarning(
+				"ACL Not Found",
+				fmt.Sprintf("No ACL entry found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading ACL",
+			fmt.Sprintf("Could not read ACL entry %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fromACL(ctx, acl)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state aclResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	plan.ID = state.ID
+	acl, diags := plan.toACL(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateACL(ctx, acl); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ACL",
+			"Could not update ACL entry: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *aclResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state aclResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	if err := r.client.DeleteACL(ctx, int(id)); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting ACL",
+			fmt.Sprintf("Could not delete ACL entry %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *aclResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *aclResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportID(req.ID, "ACL entry")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Could not import %s: %s.", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (m aclResourceModel) toACL(ctx context.Context) (certMgr.ACL, diag.Diagnostics) {
+	acl := certMgr.ACL{
+		ID:            int(m.ID.ValueInt64()),
+		Hostname:      m.Hostname.ValueString(),
+		Hostgroup:     m.Hostgroup.ValueString(),
+		Principal:     m.Principal.ValueString(),
+		PrincipalType: m.PrincipalType.ValueString(),
+	}
+	diags := m.Permissions.ElementsAs(ctx, &acl.Permissions, false)
+	return acl, diags
+}
+
+func (m *aclResourceModel) fromACL(ctx context.Context, acl *certMgr.ACL) diag.Diagnostics {
+	if acl.Hostname != "" {
+		m.Hostname = newHostnameValue(acl.Hostname)
+	}
+	if acl.Hostgroup != "" {
+		m.Hostgroup = types.StringValue(acl.Hostgroup)
+	}
+	m.Principal = types.StringValue(acl.Principal)
+	m.PrincipalType = types.StringValue(acl.PrincipalType)
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, acl.Permissions)
+	m.Permissions = permissions
+	return diags
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&aclResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	permissions := func(values ...string) types.Set {
+		elements := make([]attr.Value, len(values))
+		for i, value := range values {
+			elements[i] = types.StringValue(value)
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+	valid := aclResourceModel{
+		Hostname:      newHostnameValue("tf-test.cern.ch"),
+		Principal:     types.StringValue("jdoe"),
+		PrincipalType: types.StringValue("user"),
+		Permissions:   permissions("request", "renew"),
+	}
+
+	tests := []struct {
+		name    string
+		config  func(aclResourceModel) aclResourceModel
+		summary string
+	}{
+		{
+			name:   "hostname",
+			config: func(m aclResourceModel) aclResourceModel { return m },
+		},
+		{
+			name: "hostgroup",
+			config: func(m aclResourceModel) aclResourceModel {
+				m.Hostname = hostnameValue{StringValue: types.StringNull()}
+				m.Hostgroup = types.StringValue("cms/web")
+				return m
+			},
+		},
+		{
+			name: "unknown hostgroup",
+			config: func(m aclResourceModel) aclResourceModel {
+				m.Hostgroup = types.StringUnknown()
+				return m
+			},
+		},
+		{
+			name: "hostname and hostgroup",
+			config: func(m aclResourceModel) aclResourceModel {
+				m.Hostgroup = types.StringValue("cms/web")
+				return m
+			},
+			summary: "Invalid ACL Target",
+		},
+		{
+			name: "no target",
+			config: func(m aclResourceModel) aclResourceModel {
+				m.Hostname = hostnameValue{StringValue: types.StringNull()}
+				return m
+			},
+			summary: "Invalid ACL Target",
+		},
+		{
+			name: "principal type",
+			config: func(m aclResourceModel) aclResourceModel {
+				m.PrincipalType = types.StringValue("group")
+				return m
+			},
+			summary: "Invalid Principal Type",
+		},
+		{
+			name: "permission",
+			config: func(m aclResourceModel) aclResourceModel {
+				m.Permissions = permissions("request", "revoke")
+				return m
+			},
+			summary: "Invalid Permission",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, tt.config(valid)).HasError())
+
+			var resp resource.ValidateConfigResponse
+			(&aclResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, &resp)
+			if tt.summary == "" {
+				require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				return
+			}
+			require.Len(t, resp.Diagnostics.Errors(), 1)
+			require.Equal(t, tt.summary, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+// TestACLHostgroup checks that an ACL entry for a hostgroup keeps hostname
+// null, and that an update sends the ID recorded in state.
+func TestACLHostgroup(t *testing.T) {
+	ctx := context.Background()
+	stored := certMgr.ACL{
+		ID:            3,
+		Hostgroup:     "cms/web",
+		Principal:     "cms-admins",
+		PrincipalType: "egroup",
+		Permissions:   []string{"renew", "request"},
+	}
+	var updated certMgr.ACL
+	r := &aclResource{client: &clientmock.Client{
+		GetACLFunc: func(_ context.Context, id int) (*certMgr.ACL, error) {
+			require.Equal(t, 3, id)
+			return &stored, nil
+		},
+		UpdateACLFunc: func(_ context.Context, acl certMgr.ACL) error {
+			updated = acl
+			return nil
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, aclResourceModel{
+		ID:          types.Int64Value(3),
+		Hostname:    hostnameValue{StringValue: types.StringNull()},
+		Permissions: types.SetNull(types.StringType),
+	}).HasError())
+	readResp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	var read aclResourceModel
+	require.False(t, readResp.State.Get(ctx, &read).HasError())
+	require.True(t, read.Hostname.IsNull())
+	require.Equal(t, "cms/web", read.Hostgroup.ValueString())
+	require.Equal(t, "egroup", read.PrincipalType.ValueString())
+	require.Len(t, read.Permissions.Elements(), 2)
+
+	planned := read
+	planned.ID = types.Int64Unknown()
+	planned.Permissions = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("renew")})
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, planned).HasError())
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, certMgr.ACL{
+		ID:            3,
+		Hostgroup:     "cms/web",
+		Principal:     "cms-admins",
+		PrincipalType: "egroup",
+		Permissions:   []string{"renew"},
+	}, updated)
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &apiTokenEphemeralResource{}
+)
+
+// defaultTokenLifetime is used when lifetime_seconds is not configured.
+const defaultTokenLifetime = time.Hour
+
+func NewAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &apiTokenEphemeralResource{}
+}
+
+type apiTokenEphemeralResourceModel struct {
+	LifetimeSeconds types.Int64  `tfsdk:"lifetime_seconds"`
+	Token           types.String `tfsdk:"token"`
+	ExpiresAt       types.String `tfsdk:"expires_at"`
+}
+
+type apiTokenEphemeralResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *apiTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *apiTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Obtains a short-lived certMgr API token through the provider's Kerberos session, for example to " +
+			"configure other providers or tools. The token is never stored in state or plan files.",
+		Attributes: map[string]schema.Attribute{
+			"lifetime_seconds": schema.Int64Attribute{
+				Description: "Requested lifetime of the token in seconds. Defaults to one hour; certMgr may issue shorter-lived tokens.",
+				Optional:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "Bearer token for the certMgr API.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Expiry of the token as reported by certMgr.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *apiTokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config apiTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.LifetimeSeconds.IsNull() && !config.LifetimeSeconds.IsUnknown() && config.LifetimeSeconds.ValueInt64() < 60 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("lifetime_seconds"),
+			"Invalid Token Lifetime",
+			fmt.Sprintf("lifetime_seconds must be at least 60, got: %d", config.LifetimeSeconds.ValueInt64()),
+		)
+	}
+}
+
+func (r *apiTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config apiTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lifetime := defaultTokenLifetime
+	if !config.LifetimeSeconds.IsNull() {
+		lifetime = time.Duration(config.LifetimeSeconds.ValueInt64()) * time.Second
+	}
+
+	token, err := r.client.CreateAPIToken(ctx, lifetime)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating API token",
+			"Could not obtain a certMgr API token: "+err.Error(),
+		)
+		return
+	}
+
+	config.Token = types.StringValue(token.Token)
+	config.ExpiresAt = types.StringValue(token.Expires)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
+
+func (r *apiTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
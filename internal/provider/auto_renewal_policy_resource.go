@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                = &autoRenewalPolicyResource{}
+	_ resource.ResourceWithConfigure   = &autoRenewalPolicyResource{}
+	_ resource.ResourceWithImportState = &autoRenewalPolicyResource{}
+)
+
+func NewAutoRenewalPolicyResource() resource.Resource {
+	return &autoRenewalPolicyResource{}
+}
+
+type autoRenewalPolicyResourceModel struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	Hostnames          types.Set    `tfsdk:"hostnames"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	LeadTimeDays       types.Int64  `tfsdk:"lead_time_days"`
+	NotificationTarget types.String `tfsdk:"notification_target"`
+	Organization       types.String `tfsdk:"organization"`
+}
+
+type autoRenewalPolicyResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *autoRenewalPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auto_renewal_policy"
+}
+
+func (r *autoRenewalPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures server-side automatic renewal of certificates for a set of hostnames.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the policy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostnames": schema.SetAttribute{
+				Description: "Hostnames whose certificates are renewed automatically.",
+				ElementType: hostnameType{},
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether automatic renewal is active. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"lead_time_days": schema.Int64Attribute{
+				Description: "Number of days before expiry at which certificates are renewed. Defaults to `30`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(30),
+			},
+			"notification_target": schema.StringAttribute{
+				Description: "E-mail address or e-group notified about renewals and renewal failures.",
+				Optional:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the policy is managed in, overriding the provider's organization. " +
+					"Changing this forces a new policy.",
+			),
+		},
+	}
+}
+
+func (r *autoRenewalPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan autoRenewalPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	policy, diags := plan.toPolicy(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateAutoRenewalPolicy(ctx, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating auto-renewal policy",
+			"Could not create auto-renewal policy: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(created.ID))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *autoRenewalPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state autoRenewalPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	policy, err := r.client.GetAutoRenewalPolicy(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoAutoRenewalPolicy) {
+			resp.Diagnostics.AddWarning(
+				"Auto-Renewal Policy Not Found",
+				fmt.Sprintf("No auto-renewal policy found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Auto-Renewal Policy",
+			fmt.Sprintf("Could not read auto-renewal policy %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fromPolicy(ctx, policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *autoRenewalPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state autoRenewalPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	plan.ID = state.ID
+	policy, diags := plan.toPolicy(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateAutoRenewalPolicy(ctx, policy); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating auto-renewal policy",
+			"Could not update auto-renewal policy: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *autoRenewalPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state autoRenewalPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	if err := r.client.DeleteAutoRenewalPolicy(ctx, int(id)); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting auto-renewal policy",
+			fmt.Sprintf("Could not delete auto-renewal policy %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *autoRenewalPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *autoRenewalPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportID(req.ID, "auto-renewal policy")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Could not import %s: %s.", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (m autoRenewalPolicyResourceModel) toPolicy(ctx context.Context) (certMgr.AutoRenewalPolicy, diag.Diagnostics) {
+	policy := certMgr.AutoRenewalPolicy{
+		ID:                 int(m.ID.ValueInt64()),
+		Enabled:            m.Enabled.ValueBool(),
+		LeadTimeDays:       int(m.LeadTimeDays.ValueInt64()),
+		NotificationTarget: m.NotificationTarget.ValueString(),
+	}
+	diags := m.Hostnames.ElementsAs(ctx, &policy.Hostnames, false)
+	return policy, diags
+}
+
+func (m *autoRenewalPolicyResourceModel) fromPolicy(ctx context.Context, policy *certMgr.AutoRenewalPolicy) diag.Diagnostics {
+	m.Enabled = types.BoolValue(policy.Enabled)
+	m.LeadTimeDays = types.Int64Value(int64(policy.LeadTimeDays))
+	m.NotificationTarget = optionalString(m.NotificationTarget, policy.NotificationTarget)
+
+	hostnames, diags := hostnameSetValue(ctx, policy.Hostnames)
+	m.Hostnames = hostnames
+	return diags
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutoRenewalPolicyRead checks that a policy changed in certMgr, here
+// disabled and given a shorter lead time, shows up as drift, and that a
+// deleted policy is removed from state.
+func TestAutoRenewalPolicyRead(t *testing.T) {
+	ctx := context.Background()
+	policy := &certMgr.AutoRenewalPolicy{
+		ID:           8,
+		Hostnames:    []string{"tf-test.cern.ch", "tf-test2.cern.ch"},
+		Enabled:      false,
+		LeadTimeDays: 14,
+	}
+	r := &autoRenewalPolicyResource{client: &clientmock.Client{
+		GetAutoRenewalPolicyFunc: func(_ context.Context, id int) (*certMgr.AutoRenewalPolicy, error) {
+			require.Equal(t, 8, id)
+			if policy == nil {
+				return nil, certMgr.ErrNoAutoRenewalPolicy
+			}
+			return policy, nil
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	hostnames, diags := hostnameSetValue(ctx, []string{"tf-test.cern.ch"})
+	require.False(t, diags.HasError())
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, autoRenewalPolicyResourceModel{
+		ID:                 types.Int64Value(8),
+		Hostnames:          hostnames,
+		Enabled:            types.BoolValue(true),
+		LeadTimeDays:       types.Int64Value(30),
+		NotificationTarget: types.StringNull(),
+	}).HasError())
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var read autoRenewalPolicyResourceModel
+	require.False(t, resp.State.Get(ctx, &read).HasError())
+	require.False(t, read.Enabled.ValueBool())
+	require.Equal(t, int64(14), read.LeadTimeDays.ValueInt64())
+	require.True(t, read.NotificationTarget.IsNull())
+	var readHostnames []string
+	require.False(t, read.Hostnames.ElementsAs(ctx, &readHostnames, false).HasError())
+	require.ElementsMatch(t, policy.Hostnames, readHostnames)
+
+	policy = nil
+	resp = resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	require.True(t, resp.State.Raw.IsNull())
+}
+
+// TestAutoRenewalPolicyDisable checks that disabling a policy sends the whole
+// policy, as certMgr replaces it on update.
+func TestAutoRenewalPolicyDisable(t *testing.T) {
+	ctx := context.Background()
+	var updated certMgr.AutoRenewalPolicy
+	r := &autoRenewalPolicyResource{client: &clientmock.Client{
+		UpdateAutoRenewalPolicyFunc: func(_ context.Context, policy certMgr.AutoRenewalPolicy) error {
+			updated = policy
+			return nil
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	hostnames, diags := hostnameSetValue(ctx, []string{"tf-test.cern.ch"})
+	require.False(t, diags.HasError())
+	model := autoRenewalPolicyResourceModel{
+		ID:                 types.Int64Value(8),
+		Hostnames:          hostnames,
+		Enabled:            types.BoolValue(true),
+		LeadTimeDays:       types.Int64Value(30),
+		NotificationTarget: types.StringValue("cert-admins@cern.ch"),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, model).HasError())
+	model.Enabled = types.BoolValue(false)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, model).HasError())
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, certMgr.AutoRenewalPolicy{
+		ID:                 8,
+		Hostnames:          []string{"tf-test.cern.ch"},
+		Enabled:            false,
+		LeadTimeDays:       30,
+		NotificationTarget: "cert-admins@cern.ch",
+	}, updated)
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"certMgr/internal/acme"
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+)
+
+// Values of the issuer attribute of certmgr_certificate.
+const (
+	issuerCertMgr = "certmgr"
+	issuerACME    = "acme"
+)
+
+type acmeFallbackModel struct {
+	DirectoryURL    types.String `tfsdk:"directory_url"`
+	Email           types.String `tfsdk:"email"`
+	Webroot         types.String `tfsdk:"webroot"`
+	AccountKeyPEMWO types.String `tfsdk:"account_key_pem_wo"`
+}
+
+// certMgrRefused reports whether certMgr rejected the request for the
+// hostname, as opposed to failing or being unreachable, so that falling back
+// to ACME is appropriate.
+func certMgrRefused(err error) bool {
+	var statusErr *certMgr.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.StatusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
+// issueFromACME requests a certificate for hostname from the ACME directory
+// of fallback, talking to it with httpClient. fallback comes from the
+// configuration, as the account key is write-only. Without csr, a key pair is
+// generated locally and returned with the certificate, like certMgr does.
+func issueFromACME(ctx context.Context, httpClient *http.Client, fallback *acmeFallbackModel, hostname, csr string) (*certMgr.Certificate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	accountKey, err := pki.ParsePrivateKeyPEM(fallback.AccountKeyPEMWO.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("acme_fallback").AtName("account_key_pem_wo"), "Invalid ACME Account Key", err.Error())
+		return nil, diags
+	}
+	issuer, err := acme.NewIssuer(fallback.DirectoryURL.ValueString(), fallback.Email.ValueString(), fallback.Webroot.ValueString(), accountKey)
+	if err != nil {
+		diags.AddAttributeError(path.Root("acme_fallback"), "Invalid ACME Fallback", err.Error())
+		return nil, diags
+	}
+	issuer.HTTPClient = httpClient
+
+	if normalized, err := certMgr.NormalizeHostname(hostname); err == nil {
+		hostname = normalized
+	}
+	certificate := &certMgr.Certificate{Hostname: hostname}
+	if csr == "" {
+		key, err := pki.GenerateKey("ECDSA", 0, "P256")
+		if err != nil {
+			diags.AddError("Error Generating Private Key", err.Error())
+			return nil, diags
+		}
+		certificate.PrivateKeyPEM, err = pki.EncodePrivateKeyPEM(key)
+		if err != nil {
+			diags.AddError("Error Encoding Private Key", err.Error())
+			return nil, diags
+		}
+		csr, err = pki.CreateCSR(key, pki.CSRSubject{CommonName: hostname, DNSNames: []string{hostname}})
+		if err != nil {
+			diags.AddError("Error Creating Certificate Signing Request", err.Error())
+			return nil, diags
+		}
+	}
+
+	certificate.CertificatePEM, err = issuer.Issue(ctx, hostname, csr)
+	if err != nil {
+		diags.AddError(
+			"Error Issuing Certificate From ACME",
+			fmt.Sprintf("Could not issue certificate for %s from %s: %s", hostname, fallback.DirectoryURL.ValueString(), err),
+		)
+		return nil, diags
+	}
+	return certificate, diags
+}
+
+// acmeCertificate rebuilds the certificate issued from ACME from state, as
+// certMgr holds no copy of it.
+func acmeCertificate(state certificateResourceModel) *certMgr.Certificate {
+	return &certMgr.Certificate{
+		Hostname:       state.Hostname.ValueString(),
+		CertificatePEM: state.ACMCertificateBody.ValueString() + state.ACMCertificateChain.ValueString(),
+		PrivateKeyPEM:  state.ACMPrivateKey.ValueString(),
+	}
+}
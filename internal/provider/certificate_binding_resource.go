@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                   = &certificateBindingResource{}
+	_ resource.ResourceWithConfigure      = &certificateBindingResource{}
+	_ resource.ResourceWithImportState    = &certificateBindingResource{}
+	_ resource.ResourceWithValidateConfig = &certificateBindingResource{}
+)
+
+func NewCertificateBindingResource() resource.Resource {
+	return &certificateBindingResource{}
+}
+
+type certificateBindingResourceModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	CertificateID    types.Int64  `tfsdk:"certificate_id"`
+	ServiceName      types.String `tfsdk:"service_name"`
+	Port             types.Int64  `tfsdk:"port"`
+	DeploymentTarget types.String `tfsdk:"deployment_target"`
+	Organization     types.String `tfsdk:"organization"`
+}
+
+type certificateBindingResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *certificateBindingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_binding"
+}
+
+func (r *certificateBindingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Records in the certMgr inventory which service consumes an issued certificate.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the binding.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_id": schema.Int64Attribute{
+				Description: "Certificate that is deployed. Changing this forces a new binding.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"service_name": schema.StringAttribute{
+				Description: "Name of the service using the certificate.",
+				Required:    true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "Port on which the service presents the certificate.",
+				Optional:    true,
+			},
+			"deployment_target": schema.StringAttribute{
+				Description: "Where the service runs, for example a hostgroup, cluster or load balancer.",
+				Optional:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the binding is managed in, overriding the provider's organization. " +
+					"Changing this forces a new binding.",
+			),
+		},
+	}
+}
+
+func (r *certificateBindingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config certificateBindingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Port.IsNull() || config.Port.IsUnknown() {
+		return
+	}
+	if port := config.Port.ValueInt64(); port < 1 || port > 65535 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("port"),
+			"Invalid Port",
+			fmt.Sprintf("port must be between 1 and 65535, got: %d", port),
+		)
+	}
+}
+
+func (r *certificateBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan certificateBindingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	binding, err := r.client.CreateBinding(ctx, plan.toBinding())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating certificate binding",
+			"Could not create certificate binding: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(binding.ID))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state certificateBindingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	binding, err := r.client.GetBinding(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoBinding) {
+			resp.Diagnostics.AddWarning(
+				"Certificate Binding Not Found",
+				fmt.Sprintf("No certificate binding found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Certificate Binding",
+			fmt.Sprintf("Could not read certificate binding %d: %s", id, err),
+		)
+		return
+	}
+
+	state.CertificateID = types.Int64Value(int64(binding.CertificateID))
+	state.ServiceName = types.StringValue(binding.ServiceName)
+	state.Port = optionalInt64(state.Port, binding.Port)
+	state.DeploymentTarget = optionalString(state.DeploymentTarget, binding.DeploymentTarget)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state certificateBindingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	plan.ID = state.ID
+	if err := r.client.UpdateBinding(ctx, plan.toBinding()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating certificate binding",
+			"Could not update certificate binding: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state certificateBindingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	if err := r.client.DeleteBinding(ctx, int(id)); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting certificate binding",
+			fmt.Sprintf("Could not delete certificate binding %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *certificateBindingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *certificateBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportID(req.ID, "certificate binding")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Could not import %s: %s.", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (m certificateBindingResourceModel) toBinding() certMgr.Binding {
+	return certMgr.Binding{
+		ID:               int(m.ID.ValueInt64()),
+		CertificateID:    int(m.CertificateID.ValueInt64()),
+		ServiceName:      m.ServiceName.ValueString(),
+		Port:             int(m.Port.ValueInt64()),
+		DeploymentTarget: m.DeploymentTarget.ValueString(),
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateBindingValidatePort(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&certificateBindingResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name      string
+		port      types.Int64
+		summaries []string
+	}{
+		{name: "unset", port: types.Int64Null()},
+		{name: "unknown", port: types.Int64Unknown()},
+		{name: "lowest", port: types.Int64Value(1)},
+		{name: "highest", port: types.Int64Value(65535)},
+		{name: "zero", port: types.Int64Value(0), summaries: []string{"Invalid Port"}},
+		{name: "too high", port: types.Int64Value(65536), summaries: []string{"Invalid Port"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, certificateBindingResourceModel{
+				CertificateID: types.Int64Value(42),
+				ServiceName:   types.StringValue("web"),
+				Port:          tt.port,
+			}).HasError())
+
+			var resp resource.ValidateConfigResponse
+			(&certificateBindingResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, &resp)
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			require.Equal(t, tt.summaries, summaries)
+		})
+	}
+}
+
+// TestCertificateBindingImport checks that a binding imported by ID is
+// filled in from certMgr on the following read.
+func TestCertificateBindingImport(t *testing.T) {
+	ctx := context.Background()
+	r := &certificateBindingResource{client: &clientmock.Client{
+		GetBindingFunc: func(_ context.Context, id int) (*certMgr.Binding, error) {
+			require.Equal(t, 13, id)
+			return &certMgr.Binding{ID: 13, CertificateID: 42, ServiceName: "web", Port: 8443, DeploymentTarget: "cms/web"}, nil
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	importResp := resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "13"}, &importResp)
+	require.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	var state certificateBindingResourceModel
+	require.False(t, readResp.State.Get(ctx, &state).HasError())
+	require.Equal(t, certificateBindingResourceModel{
+		ID:               types.Int64Value(13),
+		CertificateID:    types.Int64Value(42),
+		ServiceName:      types.StringValue("web"),
+		Port:             types.Int64Value(8443),
+		DeploymentTarget: types.StringValue("cms/web"),
+		Organization:     types.StringNull(),
+	}, state)
+
+	importResp.State.Raw = tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "web"}, &importResp)
+	require.True(t, importResp.Diagnostics.HasError())
+}
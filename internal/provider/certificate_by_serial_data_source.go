@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ datasource.DataSource              = &certificateBySerialDataSource{}
+	_ datasource.DataSourceWithConfigure = &certificateBySerialDataSource{}
+)
+
+func NewCertificateBySerialDataSource() datasource.DataSource {
+	return &certificateBySerialDataSource{}
+}
+
+type certificateBySerialDataSourceModel struct {
+	Serial         types.String  `tfsdk:"serial"`
+	ID             types.Int64   `tfsdk:"id"`
+	Hostname       hostnameValue `tfsdk:"hostname"`
+	Requestor      types.String  `tfsdk:"requestor"`
+	Start          types.String  `tfsdk:"start"`
+	End            types.String  `tfsdk:"end"`
+	CertificatePEM types.String  `tfsdk:"certificate_pem"`
+}
+
+type certificateBySerialDataSource struct {
+	client certMgr.ClientAPI
+}
+
+func (d *certificateBySerialDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_by_serial"
+}
+
+func (d *certificateBySerialDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a certificate by its serial number, for example to trace a serial found in logs back to its host.",
+		Attributes: map[string]schema.Attribute{
+			"serial": schema.StringAttribute{
+				Description: "Serial number to look up, as reported by certMgr.",
+				Required:    true,
+			},
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate.",
+				Computed:    true,
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname the certificate was issued for.",
+				Computed:    true,
+				CustomType:  hostnameType{},
+			},
+			"requestor": schema.StringAttribute{
+				Description: "Account that requested the certificate.",
+				Computed:    true,
+			},
+			"start": schema.StringAttribute{
+				Description: "Start of the validity of the certificate.",
+				Computed:    true,
+			},
+			"end": schema.StringAttribute{
+				Description: "End of the validity of the certificate.",
+				Computed:    true,
+			},
+			"certificate_pem": schema.StringAttribute{
+				Description: "PEM-encoded certificate.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *certificateBySerialDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var config certificateBySerialDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serial := config.Serial.ValueString()
+	cert, err := d.client.GetCertificateBySerial(ctx, serial)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not read certificate with serial %s: %s", serial, err),
+		)
+		return
+	}
+
+	config.ID = types.Int64Value(int64(cert.ID))
+	config.Hostname = newHostnameValue(cert.Hostname)
+	config.Requestor = types.StringValue(cert.Requestor)
+	config.Start = types.StringValue(cert.Start)
+	config.End = types.StringValue(cert.End)
+	config.CertificatePEM = types.StringValue(cert.CertificatePEM)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *certificateBySerialDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateBySerialDataSource(t *testing.T) {
+	ctx := context.Background()
+	server := fakecertmgr.NewServer(t)
+	client := server.NewClient()
+	d := &certificateBySerialDataSource{client: client}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	_, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	cert, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test2.cern.ch"})
+	require.NoError(t, err)
+
+	read := func(serial string) datasource.ReadResponse {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, config.Set(ctx, certificateBySerialDataSourceModel{
+			Serial:         types.StringValue(serial),
+			ID:             types.Int64Null(),
+			Hostname:       hostnameValue{StringValue: types.StringNull()},
+			Requestor:      types.StringNull(),
+			Start:          types.StringNull(),
+			End:            types.StringNull(),
+			CertificatePEM: types.StringNull(),
+		}).HasError())
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+		return resp
+	}
+
+	// Serials match case-insensitively and are kept as configured.
+	serial := strings.ToLower(cert.Serial)
+	resp := read(serial)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var state certificateBySerialDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Equal(t, serial, state.Serial.ValueString())
+	require.Equal(t, int64(cert.ID), state.ID.ValueInt64())
+	require.Equal(t, "tf-test2.cern.ch", state.Hostname.ValueString())
+	require.Equal(t, cert.CertificatePEM, state.CertificatePEM.ValueString())
+
+	resp = read("0")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Error Reading Certificate", resp.Diagnostics.Errors()[0].Summary())
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ datasource.DataSource              = &certificateDataSource{}
+	_ datasource.DataSourceWithConfigure = &certificateDataSource{}
+)
+
+func NewCertificateDataSource() datasource.DataSource {
+	return &certificateDataSource{}
+}
+
+type certificateDataSourceModel struct {
+	Hostname       hostnameValue `tfsdk:"hostname"`
+	ID             types.Int64   `tfsdk:"id"`
+	Requestor      types.String  `tfsdk:"requestor"`
+	Serial         types.String  `tfsdk:"serial"`
+	Start          types.String  `tfsdk:"start"`
+	End            types.String  `tfsdk:"end"`
+	CertificatePEM types.String  `tfsdk:"certificate_pem"`
+}
+
+type certificateDataSource struct {
+	client certMgr.ClientAPI
+}
+
+func (d *certificateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate"
+}
+
+func (d *certificateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up the latest certificate of a hostname.",
+		Attributes: map[string]schema.Attribute{
+			"hostname": schema.StringAttribute{
+				Description: "Hostname to look up.",
+				Required:    true,
+				CustomType:  hostnameType{},
+			},
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate.",
+				Computed:    true,
+			},
+			"requestor": schema.StringAttribute{
+				Description: "Account that requested the certificate.",
+				Computed:    true,
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the certificate.",
+				Computed:    true,
+			},
+			"start": schema.StringAttribute{
+				Description: "Start of the validity of the certificate.",
+				Computed:    true,
+			},
+			"end": schema.StringAttribute{
+				Description: "End of the validity of the certificate.",
+				Computed:    true,
+			},
+			"certificate_pem": schema.StringAttribute{
+				Description: "PEM-encoded certificate.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *certificateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var config certificateDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostname := config.Hostname.ValueString()
+	cert, err := d.client.GetCertificate(ctx, hostname)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not read certificate for hostname %s: %s", hostname, err),
+		)
+		return
+	}
+
+	config.ID = types.Int64Value(int64(cert.ID))
+	config.Requestor = types.StringValue(cert.Requestor)
+	config.Serial = types.StringValue(cert.Serial)
+	config.Start = types.StringValue(cert.Start)
+	config.End = types.StringValue(cert.End)
+	config.CertificatePEM = types.StringValue(cert.CertificatePEM)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *certificateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
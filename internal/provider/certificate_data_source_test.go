@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateDataSourceLatest(t *testing.T) {
+	ctx := context.Background()
+	server := fakecertmgr.NewServer(t)
+	client := server.NewClient()
+	d := &certificateDataSource{client: client}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	_, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	latest, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+
+	read := func(hostname string) datasource.ReadResponse {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, config.Set(ctx, certificateDataSourceModel{
+			Hostname:       newHostnameValue(hostname),
+			ID:             types.Int64Null(),
+			Requestor:      types.StringNull(),
+			Serial:         types.StringNull(),
+			Start:          types.StringNull(),
+			End:            types.StringNull(),
+			CertificatePEM: types.StringNull(),
+		}).HasError())
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+		return resp
+	}
+
+	resp := read("TF-Test.cern.ch")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var state certificateDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Equal(t, int64(latest.ID), state.ID.ValueInt64())
+	require.Equal(t, latest.Serial, state.Serial.ValueString())
+	require.Equal(t, latest.CertificatePEM, state.CertificatePEM.ValueString())
+	require.Equal(t, "TF-Test.cern.ch", state.Hostname.ValueString())
+
+	resp = read("tf-test2.cern.ch")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Error Reading Certificate", resp.Diagnostics.Errors()[0].Summary())
+}
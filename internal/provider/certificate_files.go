@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+)
+
+const defaultFileMode = "0600"
+
+// certificateFilePaths returns where the certificate and private key of
+// hostname are written inside dir. File names always use the normalized
+// hostname so that the Unicode and punycode spellings map to the same files.
+func certificateFilePaths(dir, hostname string) (string, string) {
+	if normalized, err := certMgr.NormalizeHostname(hostname); err == nil {
+		hostname = normalized
+	}
+	return filepath.Join(dir, hostname+".crt"), filepath.Join(dir, hostname+".key")
+}
+
+// writeCertificateFiles writes the certificate and private key of cert into
+// dir. Each file is replaced atomically so readers never observe a partially
+// written certificate.
+func writeCertificateFiles(dir, mode, owner string, cert *certMgr.Certificate) error {
+	perm, err := parseFileMode(mode)
+	if err != nil {
+		return err
+	}
+
+	uid, gid, err := lookupOwner(owner)
+	if err != nil {
+		return err
+	}
+
+	certPath, keyPath := certificateFilePaths(dir, cert.Hostname)
+	if err := writeFileAtomic(certPath, []byte(cert.CertificatePEM), perm, uid, gid); err != nil {
+		return err
+	}
+	if cert.PrivateKeyPEM != "" {
+		if err := writeFileAtomic(keyPath, []byte(cert.PrivateKeyPEM), perm, uid, gid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pkcs12FilePath returns where the PKCS#12 archive of hostname is written
+// inside dir.
+func pkcs12FilePath(dir, hostname string) string {
+	certPath, _ := certificateFilePaths(dir, hostname)
+	return strings.TrimSuffix(certPath, ".crt") + ".p12"
+}
+
+// writePKCS12File writes the certificate, its chain and private key of cert
+// into dir as a PKCS#12 archive protected by password.
+func writePKCS12File(dir, mode, owner, password string, cert *certMgr.Certificate) error {
+	perm, err := parseFileMode(mode)
+	if err != nil {
+		return err
+	}
+
+	uid, gid, err := lookupOwner(owner)
+	if err != nil {
+		return err
+	}
+
+	if cert.PrivateKeyPEM == "" {
+		return errors.New("a PKCS#12 archive needs the private key, but none is available")
+	}
+	leaf, chain, err := leafAndChain(cert.CertificatePEM)
+	if err != nil {
+		return err
+	}
+	archive, err := pki.EncodePKCS12(leaf, chain, cert.PrivateKeyPEM, password)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(pkcs12FilePath(dir, cert.Hostname), archive, perm, uid, gid)
+}
+
+// removeCertificateFiles deletes the files written by writeCertificateFiles
+// and writePKCS12File, ignoring files that are already gone.
+func removeCertificateFiles(dir, hostname string) error {
+	certPath, keyPath := certificateFilePaths(dir, hostname)
+	for _, path := range []string{certPath, keyPath, pkcs12FilePath(dir, hostname)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode, uid, gid int) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once the rename succeeded
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set mode on %s: %w", path, err)
+	}
+	if uid >= 0 || gid >= 0 {
+		if err := tmp.Chown(uid, gid); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to set owner on %s: %w", path, err)
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move certificate into place at %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseFileMode parses an octal permission string such as "0640".
+func parseFileMode(mode string) (os.FileMode, error) {
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0o777 {
+		return 0, fmt.Errorf("invalid file mode %q: expected an octal permission such as \"0600\"", mode)
+	}
+	return os.FileMode(perm), nil
+}
+
+// lookupOwner resolves "user" or "user:group" (names or numeric IDs) to
+// numeric IDs. An empty owner keeps the IDs of the Terraform process and is
+// reported as -1.
+func lookupOwner(owner string) (int, int, error) {
+	if owner == "" {
+		return -1, -1, nil
+	}
+
+	userName, groupName, hasGroup := strings.Cut(owner, ":")
+
+	uid := -1
+	if userName != "" {
+		u, err := user.Lookup(userName)
+		if err != nil {
+			u, err = user.LookupId(userName)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("unknown user %q", userName)
+		}
+		uid, _ = strconv.Atoi(u.Uid)
+	}
+
+	gid := -1
+	if hasGroup && groupName != "" {
+		g, err := user.LookupGroup(groupName)
+		if err != nil {
+			g, err = user.LookupGroupId(groupName)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("unknown group %q", groupName)
+		}
+		gid, _ = strconv.Atoi(g.Gid)
+	}
+
+	return uid, gid, nil
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"certMgr/internal/pki"
+)
+
+var _ resource.ResourceWithMoveState = &certificateResource{}
+
+// movableCertificateResources maps the resource types certificates can be
+// moved from, keyed by source provider address, to the attribute of their
+// state holding the PEM encoded certificate.
+var movableCertificateResources = map[string]map[string]string{
+	"registry.terraform.io/hashicorp/tls": {
+		"tls_locally_signed_cert": "cert_pem",
+	},
+	"registry.terraform.io/hashicorp/vault": {
+		"vault_pki_secret_backend_cert": "certificate",
+	},
+}
+
+// MoveState adopts certificates tracked by tls_locally_signed_cert or
+// vault_pki_secret_backend_cert through a moved block. Only the hostname is
+// taken from the source certificate; the staged entry for it is looked up in
+// certMgr by the refresh that follows the move, so nothing is reissued.
+func (r *certificateResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: moveCertificateState},
+	}
+}
+
+func moveCertificateState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	attribute, ok := movableCertificateResources[req.SourceProviderAddress][req.SourceTypeName]
+	if !ok {
+		return
+	}
+
+	var source map[string]any
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Certificate",
+			fmt.Sprintf("Could not decode the state of %s: %s", req.SourceTypeName, err),
+		)
+		return
+	}
+
+	certPEM, _ := source[attribute].(string)
+	if certPEM == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Move Certificate",
+			fmt.Sprintf("The state of %s holds no certificate in %q; apply it before moving it.", req.SourceTypeName, attribute),
+		)
+		return
+	}
+
+	cert, err := pki.ParseCertificatePEM(certPEM)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Certificate",
+			fmt.Sprintf("Could not parse %q of %s: %s", attribute, req.SourceTypeName, err),
+		)
+		return
+	}
+
+	hostname := certificateHostname(cert)
+	if hostname == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Move Certificate",
+			fmt.Sprintf("The certificate of %s (serial %s) names no hostname in its common name or DNS names.",
+				req.SourceTypeName, cert.SerialNumber.Text(16)),
+		)
+		return
+	}
+
+	state := certificateResourceModel{
+		ID:          types.Int64Null(),
+		Hostname:    newHostnameValue(hostname),
+		Requestor:   types.StringNull(),
+		CSRPEM:      types.StringNull(),
+		WriteToPath: types.StringNull(),
+		FileMode:    types.StringValue(defaultFileMode),
+		Owner:       types.StringNull(),
+		Tags:        types.MapNull(types.StringType),
+		LastUpdated: types.StringNull(),
+
+		PrivateKeyPEMWO:  types.StringNull(),
+		PKCS12PasswordWO: types.StringNull(),
+
+		ACMCertificateBody:  types.StringNull(),
+		ACMCertificateChain: types.StringNull(),
+		ACMPrivateKey:       types.StringNull(),
+
+		BundleOrder:       types.StringValue("leaf_first"),
+		BundleKeyPosition: types.StringValue("last"),
+		BundlePEM:         types.StringNull(),
+		BundleWithKeyPEM:  types.StringNull(),
+	}
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, state)...)
+}
+
+// certificateHostname returns the hostname a certificate was issued for: its
+// common name, or its first DNS name when the common name is empty.
+func certificateHostname(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
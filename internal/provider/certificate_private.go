@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	certMgr "certMgr/internal/client"
+)
+
+// issuancePrivateKey is the private state key under which certmgr_certificate
+// keeps its issuanceMetadata.
+const issuancePrivateKey = "issuance"
+
+// issuanceMetadata is what certmgr_certificate remembers about the entries it
+// issued, without exposing it as attributes.
+type issuanceMetadata struct {
+	// Serial of the certificate last seen, to detect reissuance outside
+	// Terraform.
+	Serial string `json:"serial,omitempty"`
+	ETag   string `json:"etag,omitempty"`
+	// StagedIDs lists the staged entries created by this resource instance,
+	// the only ones it deletes.
+	StagedIDs []int `json:"staged_ids,omitempty"`
+}
+
+// privateStateGetter is implemented by the Private fields of the framework's
+// requests and responses.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getIssuance returns the metadata in private, which is empty for resources
+// created by older provider versions or imported.
+func getIssuance(ctx context.Context, private privateStateGetter) (issuanceMetadata, diag.Diagnostics) {
+	var metadata issuanceMetadata
+	data, diags := private.GetKey(ctx, issuancePrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return metadata, diags
+	}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		diags.AddError("Invalid Private State", "Could not decode issuance metadata: "+err.Error())
+	}
+	return metadata, diags
+}
+
+func setIssuance(ctx context.Context, private privateStateSetter, metadata issuanceMetadata) diag.Diagnostics {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid Private State", "Could not encode issuance metadata: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, issuancePrivateKey, data)
+}
+
+// observe records certificate, and reports whether its serial differs from
+// the one seen before.
+func (m *issuanceMetadata) observe(certificate *certMgr.Certificate) bool {
+	serial := certificateSerial(certificate.CertificatePEM)
+	reissued := m.Serial != "" && serial != "" && serial != m.Serial
+	if serial != "" {
+		m.Serial = serial
+	}
+	m.ETag = certificate.ETag
+	return reissued
+}
+
+// owns records id as created by this resource instance.
+func (m *issuanceMetadata) owns(id int) {
+	if !slices.Contains(m.StagedIDs, id) {
+		m.StagedIDs = append(m.StagedIDs, id)
+	}
+}
+
+// deletable returns the staged entries Delete removes: those created by the
+// resource instance or, without metadata, the entry tracked in state.
+func (m issuanceMetadata) deletable(stateID int) []int {
+	if len(m.StagedIDs) > 0 {
+		return m.StagedIDs
+	}
+	return []int{stateID}
+}
@@ -6,24 +6,38 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"certMgr/internal/acme"
 	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
 )
 
 var (
-	_ resource.Resource                = &certificateResource{}
-	_ resource.ResourceWithConfigure   = &certificateResource{}
-	_ resource.ResourceWithImportState = &certificateResource{}
+	_ resource.Resource                     = &certificateResource{}
+	_ resource.ResourceWithConfigure        = &certificateResource{}
+	_ resource.ResourceWithImportState      = &certificateResource{}
+	_ resource.ResourceWithValidateConfig   = &certificateResource{}
+	_ resource.ResourceWithModifyPlan       = &certificateResource{}
+	_ resource.ResourceWithConfigValidators = &certificateResource{}
 )
 
 func NewCertificateResource() resource.Resource {
@@ -31,13 +45,47 @@ func NewCertificateResource() resource.Resource {
 }
 
 type certificateResourceModel struct {
-	ID          types.Int64  `tfsdk:"id"`
-	Hostname    types.String `tfsdk:"hostname"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID           types.Int64   `tfsdk:"id"`
+	Hostname     hostnameValue `tfsdk:"hostname"`
+	Requestor    types.String  `tfsdk:"requestor"`
+	Organization types.String  `tfsdk:"organization"`
+	CSRPEM       types.String  `tfsdk:"csr_pem"`
+	WriteToPath  types.String  `tfsdk:"write_to_path"`
+	FileMode     types.String  `tfsdk:"file_mode"`
+	Owner        types.String  `tfsdk:"owner"`
+	Tags         types.Map     `tfsdk:"tags"`
+	LastUpdated  types.String  `tfsdk:"last_updated"`
+
+	PrivateKeyPEMWO  types.String `tfsdk:"private_key_pem_wo"`
+	PKCS12PasswordWO types.String `tfsdk:"pkcs12_password_wo"`
+
+	ACMCertificateBody  types.String `tfsdk:"acm_certificate_body"`
+	ACMCertificateChain types.String `tfsdk:"acm_certificate_chain"`
+	ACMPrivateKey       types.String `tfsdk:"acm_private_key"`
+
+	BundleOrder       types.String `tfsdk:"bundle_order"`
+	BundleKeyPosition types.String `tfsdk:"bundle_key_position"`
+	BundlePEM         types.String `tfsdk:"bundle_pem"`
+	BundleWithKeyPEM  types.String `tfsdk:"bundle_with_key_pem"`
+
+	CertificateDERBase64 types.String `tfsdk:"certificate_der_base64"`
+	ChainDERBase64       types.String `tfsdk:"chain_der_base64"`
+
+	ACMEFallback *acmeFallbackModel `tfsdk:"acme_fallback"`
+	Issuer       types.String       `tfsdk:"issuer"`
+
+	Revoked             types.Bool `tfsdk:"revoked"`
+	ReplaceOnRevocation types.Bool `tfsdk:"replace_on_revocation"`
 }
 
+// Values of bundle_order and bundle_key_position.
+var (
+	bundleOrders       = []string{"leaf_first", "root_first"}
+	bundleKeyPositions = []string{"last", "first"}
+)
+
 type certificateResource struct {
-	client *certMgr.Client
+	client certMgr.ClientAPI
 }
 
 func (r *certificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,22 +108,386 @@ func (r *certificateResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Computed:    true,
 			},
 			"hostname": schema.StringAttribute{
-				Description: "Hostname that the certificate belongs to.",
+				Description: "Hostname that the certificate belongs to. Internationalized hostnames are normalized to punycode. Changing this forces a new certificate.",
 				Required:    true,
+				CustomType:  hostnameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						hostnameChanged,
+						"Changing the hostname forces a new certificate.",
+						"Changing the hostname forces a new certificate.",
+					),
+				},
+			},
+			"requestor": schema.StringAttribute{
+				Description: "Requestor recorded for the certificate. Defaults to the requestor assigned by certMgr.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is managed in, overriding the provider's organization. " +
+					"Changing this forces a new certificate.",
+			),
+			"csr_pem": schema.StringAttribute{
+				Description: "PEM encoded certificate signing request to submit instead of letting certMgr generate the key pair, " +
+					"for example `certmgr_csr.example.csr_pem`. Changing this forces a new certificate. Conflicts with `private_key_pem_wo`.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"write_to_path": schema.StringAttribute{
+				Description: "Directory into which the issued certificate (`<hostname>.crt`) and private key (`<hostname>.key`) are written atomically during apply. " +
+					"Useful when Terraform runs on the target host itself. The files are removed when the resource is destroyed.",
+				Optional: true,
+			},
+			"file_mode": schema.StringAttribute{
+				Description: "Octal permissions of the files written to `write_to_path`. Defaults to `" + defaultFileMode + "`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultFileMode),
+			},
+			"owner": schema.StringAttribute{
+				Description: "Owner of the files written to `write_to_path`, as `user` or `user:group`. Defaults to the user running Terraform.",
+				Optional:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Key/value labels attached to the certificate, for example to group certificates per team. " +
+					"See the `certmgr_certificates_by_tag` data source.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"private_key_pem_wo": schema.StringAttribute{
+				Description: "PEM encoded private key to request the certificate for, for example `certmgr_private_key.example.private_key_pem`. " +
+					"A certificate signing request for the hostname is " +
+					"derived from it locally, so the key is never sent to certMgr nor stored in state or plan. " +
+					"It is also written to `write_to_path`. Conflicts with `csr_pem`.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"pkcs12_password_wo": schema.StringAttribute{
+				Description: "Password of a PKCS#12 archive (`<hostname>.p12`) additionally written to `write_to_path`. " +
+					"Never stored in state or plan.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"acm_certificate_body": schema.StringAttribute{
+				Description: "Issued leaf certificate alone, as expected by `certificate_body` of `aws_acm_certificate`. " +
+					"Null until the certificate is issued.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"acm_certificate_chain": schema.StringAttribute{
+				Description: "Intermediate and root certificates of the issued certificate, leaf excluded, as expected by " +
+					"`certificate_chain` of `aws_acm_certificate`. Null when certMgr returned no chain.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"acm_private_key": schema.StringAttribute{
+				Description: "Private key generated by certMgr, as expected by `private_key` of `aws_acm_certificate`. " +
+					"Null when the certificate was requested with `csr_pem` or `private_key_pem_wo`, whose keys certMgr does not hold.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bundle_order": schema.StringAttribute{
+				Description: "Order of the certificates in `bundle_pem` and `bundle_with_key_pem`, one of: " +
+					strings.Join(bundleOrders, ", ") + ". Defaults to `leaf_first`, as expected by nginx and HAProxy.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("leaf_first"),
+			},
+			"bundle_key_position": schema.StringAttribute{
+				Description: "Position of the private key in `bundle_with_key_pem` relative to the certificates, one of: " +
+					strings.Join(bundleKeyPositions, ", ") + ". Defaults to `last`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("last"),
+			},
+			"bundle_pem": schema.StringAttribute{
+				Description: "Issued certificate concatenated with its chain in `bundle_order`, for example for `ssl_certificate` of nginx. " +
+					"Null until the certificate is issued.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bundle_with_key_pem": schema.StringAttribute{
+				Description: "`bundle_pem` with the private key generated by certMgr at `bundle_key_position`, for example for `crt` of HAProxy. " +
+					"Null when certMgr does not hold the private key.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_der_base64": schema.StringAttribute{
+				Description: "Issued leaf certificate DER encoded and then base64 encoded, for appliances that only accept DER uploads " +
+					"such as F5 BIG-IP. Null until the certificate is issued.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chain_der_base64": schema.StringAttribute{
+				Description: "Intermediate and root certificates of the issued certificate, leaf excluded, DER encoded, concatenated " +
+					"and then base64 encoded. Null when certMgr returned no chain.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"acme_fallback": schema.SingleNestedAttribute{
+				Description: "ACME directory, such as Let's Encrypt or an internal ACME CA, from which the certificate is issued instead " +
+					"when certMgr refuses to issue for the hostname. The http-01 challenge is answered by writing to `webroot`, " +
+					"so Terraform must run where the hostname is served. Certificates issued from ACME are only tracked in state.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"directory_url": schema.StringAttribute{
+						Description: "URL of the ACME directory, for example `" + acme.LetsEncryptURL + "`.",
+						Required:    true,
+					},
+					"email": schema.StringAttribute{
+						Description: "Contact address registered with the ACME account.",
+						Optional:    true,
+					},
+					"webroot": schema.StringAttribute{
+						Description: "Directory served at `http://<hostname>/`, below which http-01 challenge responses are written.",
+						Required:    true,
+					},
+					"account_key_pem_wo": schema.StringAttribute{
+						Description: "PEM encoded private key of the ACME account. The key must be kept across runs: ACME CAs " +
+							"rate limit account registrations, and every new key registers a new account. Never stored in state or plan.",
+						Required:  true,
+						Sensitive: true,
+						WriteOnly: true,
+					},
+				},
+			},
+			"issuer": schema.StringAttribute{
+				Description: "Issuer of the certificate, `" + issuerCertMgr + "` or `" + issuerACME + "` when it was issued through `acme_fallback`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"revoked": schema.BoolAttribute{
+				Description: "Whether the certificate was revoked in certMgr, as found on the last refresh.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"replace_on_revocation": schema.BoolAttribute{
+				Description: "Plan a new certificate once the current one was found revoked, so that the next apply reissues it. " +
+					"Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
 		},
 	}
 }
 
+// ConfigValidators selects exactly one source of key material: a CSR, a
+// locally generated private key to derive a CSR from, for example that of a
+// certmgr_private_key, or, with neither set, a key pair generated by certMgr.
+func (r *certificateResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		conflictingAttributes{
+			summary:  "Conflicting Key Material",
+			paths:    []path.Path{path.Root("csr_pem"), path.Root("private_key_pem_wo")},
+			fallback: "certMgr generates the key pair",
+		},
+	}
+}
+
+func (r *certificateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config certificateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.FileMode.IsNull() && !config.FileMode.IsUnknown() {
+		if _, err := parseFileMode(config.FileMode.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("file_mode"), "Invalid File Mode", err.Error())
+		}
+	}
+
+	if !config.BundleOrder.IsNull() && !config.BundleOrder.IsUnknown() &&
+		!slices.Contains(bundleOrders, config.BundleOrder.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bundle_order"),
+			"Invalid Bundle Order",
+			fmt.Sprintf("bundle_order must be one of: %s. Got: %q", strings.Join(bundleOrders, ", "), config.BundleOrder.ValueString()),
+		)
+	}
+
+	if !config.BundleKeyPosition.IsNull() && !config.BundleKeyPosition.IsUnknown() &&
+		!slices.Contains(bundleKeyPositions, config.BundleKeyPosition.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bundle_key_position"),
+			"Invalid Bundle Key Position",
+			fmt.Sprintf("bundle_key_position must be one of: %s. Got: %q", strings.Join(bundleKeyPositions, ", "), config.BundleKeyPosition.ValueString()),
+		)
+	}
+
+	if !config.PrivateKeyPEMWO.IsNull() && !config.PrivateKeyPEMWO.IsUnknown() {
+		if _, err := pki.ParsePrivateKeyPEM(config.PrivateKeyPEMWO.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("private_key_pem_wo"), "Invalid Private Key", err.Error())
+		}
+	}
+
+	if config.ACMEFallback != nil && !config.ACMEFallback.AccountKeyPEMWO.IsNull() && !config.ACMEFallback.AccountKeyPEMWO.IsUnknown() {
+		if _, err := pki.ParsePrivateKeyPEM(config.ACMEFallback.AccountKeyPEMWO.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("acme_fallback").AtName("account_key_pem_wo"), "Invalid ACME Account Key", err.Error())
+		}
+	}
+
+	if config.WriteToPath.IsNull() && !config.PKCS12PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("pkcs12_password_wo"),
+			"PKCS#12 Password Without write_to_path",
+			"pkcs12_password_wo only takes effect when write_to_path is set.",
+		)
+	}
+
+	if config.WriteToPath.IsNull() && (!config.Owner.IsNull() || !config.FileMode.IsNull()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("write_to_path"),
+			"File Settings Without write_to_path",
+			"file_mode and owner only take effect when write_to_path is set.",
+		)
+	}
+}
+
+// ModifyPlan marks the bundles unknown when their layout changes, so that
+// Update assembles them again, and replaces revoked certificates unless
+// replace_on_revocation is disabled. A hostname that is only known after apply,
+// because it depends on a resource not created yet, plans a new certificate
+// whose every computed attribute is unknown, rather than one claiming the
+// material of the certificate it replaces.
+func (r *certificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state certificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Hostname.IsUnknown() {
+		plan.markIssuanceUnknown()
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("hostname"))
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	// The replacement is not revoked; planning the change is what lets
+	// Terraform replace the certificate.
+	if state.Revoked.ValueBool() && plan.ReplaceOnRevocation.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revoked"), types.BoolValue(false))...)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("revoked"))
+	}
+
+	if plan.BundleOrder.Equal(state.BundleOrder) && plan.BundleKeyPosition.Equal(state.BundleKeyPosition) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("bundle_pem"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("bundle_with_key_pem"), types.StringUnknown())...)
+}
+
+// revoked reports whether certMgr revoked certificate. Entries not issued yet
+// have no serial and cannot be revoked.
+func (r *certificateResource) revoked(ctx context.Context, certificate *certMgr.Certificate) (bool, error) {
+	serial := issuedSerial(certificate)
+	if serial == "" {
+		return false, nil
+	}
+
+	return r.client.CertificateRevoked(ctx, serial)
+}
+
+// markIssuanceUnknown marks the attributes set from the issued certificate
+// unknown.
+func (m *certificateResourceModel) markIssuanceUnknown() {
+	m.ID = types.Int64Unknown()
+	m.LastUpdated = types.StringUnknown()
+	m.Issuer = types.StringUnknown()
+	m.ACMCertificateBody = types.StringUnknown()
+	m.ACMCertificateChain = types.StringUnknown()
+	m.ACMPrivateKey = types.StringUnknown()
+	m.BundlePEM = types.StringUnknown()
+	m.BundleWithKeyPEM = types.StringUnknown()
+	m.CertificateDERBase64 = types.StringUnknown()
+	m.ChainDERBase64 = types.StringUnknown()
+	m.Revoked = types.BoolUnknown()
+}
+
 func (r *certificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan certificateResourceModel
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config certificateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	// Write-only values are only present in the configuration.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withOrganization(ctx, plan.Organization)
 
-	certificate, err := r.client.CreateCertificate(plan.Hostname.ValueString())
+	csr := plan.CSRPEM.ValueString()
+	if !config.PrivateKeyPEMWO.IsNull() {
+		csr, diags = csrForPrivateKey(plan.Hostname.ValueString(), config.PrivateKeyPEMWO.ValueString(), r.client.FIPSMode())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	certificate, err := r.client.CreateCertificate(ctx, certMgr.CertificateRequest{
+		Hostname: plan.Hostname.ValueString(),
+		CSR:      csr,
+	})
+	if err != nil && plan.ACMEFallback != nil && certMgrRefused(err) {
+		resp.Diagnostics.AddWarning(
+			"Certificate Issued From ACME",
+			fmt.Sprintf("certMgr refused to issue a certificate for %s (%s); falling back to %s.",
+				plan.Hostname.ValueString(), err, plan.ACMEFallback.DirectoryURL.ValueString()),
+		)
+		certificate, diags = issueFromACME(ctx, r.client.ExternalClient(), config.ACMEFallback, plan.Hostname.ValueString(), csr)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		plan.ID = types.Int64Value(0)
+		plan.Issuer = types.StringValue(issuerACME)
+		plan.Revoked = types.BoolValue(false)
+		if plan.Requestor.IsUnknown() {
+			plan.Requestor = types.StringNull()
+		}
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+		resp.Diagnostics.Append(plan.setMaterial(certificate)...)
+		resp.Diagnostics.Append(writeCertificateOutput(plan, config, certificate)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating certificate",
@@ -84,110 +496,465 @@ func (r *certificateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Requestor and tags cannot be set when staging, so apply them as a
+	// follow-up update against the freshly created entry.
+	created := certificateResourceModel{
+		ID:        types.Int64Value(int64(certificate.ID)),
+		Hostname:  newHostnameValue(certificate.Hostname),
+		Requestor: types.StringValue(certificate.Requestor),
+		Tags:      types.MapNull(types.StringType),
+	}
+	update, changed, diags := certificateUpdate(ctx, plan, created)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if changed {
+		if err := r.client.UpdateCertificate(ctx, update); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating certificate",
+				"Could not set requestor and tags on created certificate: "+err.Error(),
+			)
+			return
+		}
+	}
+	if plan.Requestor.IsUnknown() {
+		plan.Requestor = created.Requestor
+	}
+
 	plan.ID = types.Int64Value(int64(certificate.ID))
+	plan.Issuer = types.StringValue(issuerCertMgr)
+	plan.Revoked = types.BoolValue(false)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	resp.Diagnostics.Append(plan.setMaterial(certificate)...)
+
+	var metadata issuanceMetadata
+	metadata.owns(certificate.ID)
+	metadata.observe(certificate)
+	resp.Diagnostics.Append(setIssuance(ctx, resp.Private, metadata)...)
+
+	resp.Diagnostics.Append(writeCertificateOutput(plan, config, certificate)...)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// setMaterial sets the attributes derived from the certificate material
+// returned by certMgr. They stay null until the certificate is issued.
+func (m *certificateResourceModel) setMaterial(certificate *certMgr.Certificate) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ACMCertificateBody = types.StringNull()
+	m.ACMCertificateChain = types.StringNull()
+	m.ACMPrivateKey = types.StringNull()
+	m.BundlePEM = types.StringNull()
+	m.BundleWithKeyPEM = types.StringNull()
+	m.CertificateDERBase64 = types.StringNull()
+	m.ChainDERBase64 = types.StringNull()
+	if certificate.CertificatePEM == "" {
+		return diags
+	}
+
+	blocks, err := pki.SplitChain(certificate.CertificatePEM)
+	if err != nil || len(blocks) == 0 {
+		diags.AddWarning(
+			"Unreadable Certificate",
+			fmt.Sprintf("Could not split the certificate of %s returned by certMgr: %v", certificate.Hostname, err),
+		)
+		return diags
+	}
+
+	m.ACMCertificateBody = types.StringValue(blocks[0])
+	m.CertificateDERBase64 = types.StringValue(derBase64(blocks[:1]))
+	if len(blocks) > 1 {
+		m.ACMCertificateChain = types.StringValue(strings.Join(blocks[1:], ""))
+		m.ChainDERBase64 = types.StringValue(derBase64(blocks[1:]))
+	}
+
+	if m.BundleOrder.ValueString() == "root_first" {
+		blocks = slices.Clone(blocks)
+		slices.Reverse(blocks)
+	}
+	bundle := strings.Join(blocks, "")
+	m.BundlePEM = types.StringValue(bundle)
+
+	if certificate.PrivateKeyPEM == "" {
+		return diags
+	}
+	m.ACMPrivateKey = types.StringValue(certificate.PrivateKeyPEM)
+
+	key := certificate.PrivateKeyPEM
+	if !strings.HasSuffix(key, "\n") {
+		key += "\n"
+	}
+	if m.BundleKeyPosition.ValueString() == "first" {
+		m.BundleWithKeyPEM = types.StringValue(key + bundle)
+	} else {
+		m.BundleWithKeyPEM = types.StringValue(bundle + key)
+	}
+	return diags
+}
+
+// derBase64 returns the DER encodings of the PEM blocks, as returned by
+// pki.SplitChain, concatenated and base64 encoded.
+func derBase64(blocks []string) string {
+	var der []byte
+	for _, block := range blocks {
+		if decoded, _ := pem.Decode([]byte(block)); decoded != nil {
+			der = append(der, decoded.Bytes...)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+// csrForPrivateKey derives a certificate signing request for hostname from a
+// customer-supplied private key.
+func csrForPrivateKey(hostname, privateKeyPEM string, fips bool) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	key, err := pki.ParsePrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		diags.AddAttributeError(path.Root("private_key_pem_wo"), "Invalid Private Key", err.Error())
+		return "", diags
+	}
+	if fips {
+		if err := pki.CheckFIPSKey(key.Public()); err != nil {
+			diags.AddAttributeError(path.Root("private_key_pem_wo"), "Private Key Not Allowed in FIPS Mode", err.Error())
+			return "", diags
+		}
+	}
+
+	if normalized, err := certMgr.NormalizeHostname(hostname); err == nil {
+		hostname = normalized
+	}
+	csr, err := pki.CreateCSR(key, pki.CSRSubject{CommonName: hostname, DNSNames: []string{hostname}})
+	if err != nil {
+		diags.AddAttributeError(path.Root("private_key_pem_wo"), "Error Creating Certificate Signing Request", err.Error())
+	}
+	return csr, diags
+}
+
+// writeCertificateOutput writes the certificate material to write_to_path
+// when the attribute is set. The write-only private key and PKCS#12 password
+// are taken from config.
+func writeCertificateOutput(plan, config certificateResourceModel, certificate *certMgr.Certificate) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.WriteToPath.IsNull() {
+		return diags
+	}
+
+	dir := plan.WriteToPath.ValueString()
+	if certificate.CertificatePEM == "" {
+		diags.AddAttributeWarning(
+			path.Root("write_to_path"),
+			"Certificate Not Yet Issued",
+			fmt.Sprintf("certMgr returned no certificate material for %s; nothing was written to %s.", certificate.Hostname, dir),
+		)
+		return diags
+	}
+
+	if !config.PrivateKeyPEMWO.IsNull() {
+		withKey := *certificate
+		withKey.PrivateKeyPEM = config.PrivateKeyPEMWO.ValueString()
+		certificate = &withKey
+	}
+
+	if err := writeCertificateFiles(dir, plan.FileMode.ValueString(), plan.Owner.ValueString(), certificate); err != nil {
+		diags.AddAttributeError(
+			path.Root("write_to_path"),
+			"Error Writing Certificate Files",
+			fmt.Sprintf("Could not write certificate files to %s: %s", dir, err),
+		)
+		return diags
+	}
+
+	if !config.PKCS12PasswordWO.IsNull() {
+		if err := writePKCS12File(dir, plan.FileMode.ValueString(), plan.Owner.ValueString(), config.PKCS12PasswordWO.ValueString(), certificate); err != nil {
+			diags.AddAttributeError(
+				path.Root("pkcs12_password_wo"),
+				"Error Writing PKCS#12 Archive",
+				fmt.Sprintf("Could not write PKCS#12 archive to %s: %s", dir, err),
+			)
+		}
+	}
+	return diags
+}
+
 func (r *certificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
 	var state certificateResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	// certMgr holds no copy of certificates issued from ACME, state is all
+	// there is.
+	if state.Issuer.ValueString() == issuerACME {
+		return
+	}
 
 	hostname := state.Hostname.ValueString()
-	certificate, err := r.client.GetCertificate(hostname)
+	metadata, diags := getIssuance(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+
+	// An entry that still carries the ETag it had when state was written has
+	// not changed, so only its revocation needs to be looked up. Errors are
+	// left to the full read below to report.
+	if id := state.ID.ValueInt64(); id != 0 && metadata.Serial != "" {
+		unchanged, err := r.client.CertificateUnchanged(ctx, int(id), metadata.ETag)
+		if err == nil && unchanged {
+			if r.refreshRevoked(ctx, &state, &certMgr.Certificate{Serial: metadata.Serial}, &resp.Diagnostics) {
+				state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+				resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			}
+			return
+		}
+	}
+
+	certificate, err := r.readCertificate(ctx, state)
 	if err != nil {
-        if errors.Is(err, certMgr.ErrNoCertificates) {
-            resp.Diagnostics.AddWarning(
-                "Certificate Not Found",
-                fmt.Sprintf(
-                    "No certificate found for hostname %s; removing resource from state.",
-                    hostname,
-                ),
-            )
-            resp.State.RemoveResource(ctx)
-            return
-        }
-		resp.Diagnostics.AddError(
-		  "Error Reading Certificate",
-		  fmt.Sprintf("Could not read certificate for hostname %s: %s", hostname, err),
+		if errors.Is(err, certMgr.ErrNoCertificates) {
+			resp.Diagnostics.AddWarning(
+				"Certificate Not Found",
+				fmt.Sprintf(
+					"No certificate found for hostname %s; removing resource from state.",
+					hostname,
+				),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not read certificate for hostname %s: %s", hostname, err),
 		)
 		return
-	  }
+	}
+
+	if !r.refreshRevoked(ctx, &state, certificate, &resp.Diagnostics) {
+		return
+	}
+
+	if metadata.observe(certificate) {
+		resp.Diagnostics.AddWarning(
+			"Certificate Reissued Outside Terraform",
+			fmt.Sprintf("The certificate for hostname %s was reissued in certMgr since the last refresh; its serial is now %s.",
+				hostname, metadata.Serial),
+		)
+	}
+	resp.Diagnostics.Append(setIssuance(ctx, resp.Private, metadata)...)
 
 	state.ID = types.Int64Value(int64(certificate.ID))
+	state.Hostname = newHostnameValue(certificate.Hostname)
+	state.Requestor = types.StringValue(certificate.Requestor)
+	state.Issuer = types.StringValue(issuerCertMgr)
+	if len(certificate.Tags) > 0 || !state.Tags.IsNull() {
+		state.Tags, diags = types.MapValueFrom(ctx, types.StringType, certificate.Tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	resp.Diagnostics.Append(state.setMaterial(certificate)...)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
+// refreshRevoked sets the revoked attribute of state from whether certificate
+// was revoked, warning when it was since the last refresh. It returns false
+// when the revocations could not be looked up.
+func (r *certificateResource) refreshRevoked(ctx context.Context, state *certificateResourceModel, certificate *certMgr.Certificate, diags *diag.Diagnostics) bool {
+	hostname := state.Hostname.ValueString()
+	revoked, err := r.revoked(ctx, certificate)
+	if err != nil {
+		addReadError(
+			diags, r.client, err,
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not check whether the certificate for hostname %s was revoked: %s", hostname, err),
+		)
+		return false
+	}
+	if revoked && !state.Revoked.ValueBool() {
+		detail := "The next apply replaces it with a new certificate."
+		if !state.ReplaceOnRevocation.IsNull() && !state.ReplaceOnRevocation.ValueBool() {
+			detail = "It is kept, as replace_on_revocation is disabled."
+		}
+		diags.AddWarning(
+			"Certificate Revoked",
+			fmt.Sprintf("The certificate for hostname %s was revoked in certMgr. %s", hostname, detail),
+		)
+	}
+	state.Revoked = types.BoolValue(revoked)
+	return true
+}
+
+// readCertificate looks up the staged entry tracked in state. Entries are
+// fetched by ID so that other entries staged for the same hostname, such as a
+// create_before_destroy replacement, are never adopted by this instance, and
+// so that imported entries are found before their hostname is known.
+func (r *certificateResource) readCertificate(ctx context.Context, state certificateResourceModel) (*certMgr.Certificate, error) {
+	if state.ID.IsNull() || state.ID.IsUnknown() || state.ID.ValueInt64() == 0 {
+		return r.client.GetCertificate(ctx, state.Hostname.ValueString())
+	}
+	return r.client.GetCertificateByID(ctx, int(state.ID.ValueInt64()))
+}
+
 func (r *certificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan certificateResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state, config certificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withOrganization(ctx, state.Organization)
 
-	certificate, err := r.client.GetCertificate(plan.Hostname.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error fetching certificate",
-			"Could not fetch certificate for update: "+err.Error(),
-		)
+	acmeIssued := state.Issuer.ValueString() == issuerACME
+	update, changed, diags := certificateUpdate(ctx, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	err = r.client.UpdateCertificate(*certificate)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating certificate",
-			"Could not update certificate: "+err.Error(),
-		)
-		return
+	if changed && !acmeIssued {
+		if err := r.client.UpdateCertificate(ctx, update); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating certificate",
+				"Could not update certificate: "+err.Error(),
+			)
+			return
+		}
 	}
 
+	plan.ID = state.ID
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	if !plan.WriteToPath.IsNull() || plan.BundlePEM.IsUnknown() {
+		certificate := acmeCertificate(state)
+		if !acmeIssued {
+			var err error
+			certificate, err = r.readCertificate(ctx, state)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error fetching certificate",
+					"Could not fetch certificate: "+err.Error(),
+				)
+				return
+			}
+		}
+		if plan.BundlePEM.IsUnknown() {
+			resp.Diagnostics.Append(plan.setMaterial(certificate)...)
+		}
+		if !acmeIssued {
+			metadata, diags := getIssuance(ctx, req.Private)
+			resp.Diagnostics.Append(diags...)
+			metadata.observe(certificate)
+			resp.Diagnostics.Append(setIssuance(ctx, resp.Private, metadata)...)
+		}
+		resp.Diagnostics.Append(writeCertificateOutput(plan, config, certificate)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !state.WriteToPath.IsNull() && !state.WriteToPath.Equal(plan.WriteToPath) {
+		if err := removeCertificateFiles(state.WriteToPath.ValueString(), state.Hostname.ValueString()); err != nil {
+			resp.Diagnostics.AddWarning("Error Removing Certificate Files", err.Error())
+		}
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// certificateUpdate builds the update payload from the attributes that differ
+// between plan and state. The second return value reports whether anything
+// needs to be sent to certMgr at all.
+func certificateUpdate(ctx context.Context, plan, state certificateResourceModel) (certMgr.CertificateUpdate, bool, diag.Diagnostics) {
+	update := certMgr.CertificateUpdate{
+		ID:       int(state.ID.ValueInt64()),
+		Hostname: state.Hostname.ValueString(),
+	}
+	changed := false
+
+	if !plan.Requestor.IsUnknown() && !plan.Requestor.Equal(state.Requestor) {
+		requestor := plan.Requestor.ValueString()
+		update.Requestor = &requestor
+		changed = true
+	}
+
+	var diags diag.Diagnostics
+	if !plan.Tags.IsUnknown() && !plan.Tags.Equal(state.Tags) {
+		tags := map[string]string{}
+		diags = plan.Tags.ElementsAs(ctx, &tags, false)
+		update.Tags = &tags
+		changed = true
+	}
+
+	return update, changed, diags
+}
+
 func (r *certificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
 	var state certificateResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withOrganization(ctx, state.Organization)
 
+	// Only delete the staged entries owned by this resource instance, so a
+	// replacement created first under create_before_destroy survives.
+	// Certificates issued from ACME have no entry in certMgr, and entries
+	// already deleted in the certMgr UI are gone as desired.
 	hostname := state.Hostname.ValueString()
-	if err := r.client.DeleteCertificate(hostname); err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting certificate",
-			fmt.Sprintf("Could not delete certificate for hostname %s: %s", hostname, err),
-		)
-		return
+	if state.Issuer.ValueString() != issuerACME {
+		metadata, diags := getIssuance(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if err := r.deleteOwned(ctx, metadata.deletable(int(state.ID.ValueInt64()))); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting certificate",
+				fmt.Sprintf("Could not delete certificate for hostname %s: %s", hostname, err),
+			)
+			return
+		}
+	}
+
+	if !state.WriteToPath.IsNull() {
+		if err := removeCertificateFiles(state.WriteToPath.ValueString(), hostname); err != nil {
+			resp.Diagnostics.AddWarning("Error Removing Certificate Files", err.Error())
+		}
 	}
 
 	resp.State.RemoveResource(ctx)
 }
 
+func (r *certificateResource) deleteOwned(ctx context.Context, ids []int) error {
+	if len(ids) > 1 {
+		return r.client.DeleteStagedEntries(ctx, ids)
+	}
+	if err := r.client.DeleteStaged(ctx, ids[0]); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
 func (r *certificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*certMgr.Client)
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected client type",
-			fmt.Sprintf("Expected *certMgr.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
 		)
 		return
 	}
@@ -195,6 +962,22 @@ func (r *certificateResource) Configure(_ context.Context, req resource.Configur
 	r.client = client
 }
 
+// ImportState accepts either the ID of a staged entry or a hostname, whose
+// latest staged entry is adopted.
 func (r *certificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	raw := req.ID
+	if organization, rest, ok := strings.Cut(raw, "/"); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organization)...)
+		raw = rest
+	}
+	id, hostname, err := parseIDOrHostname(raw, "certificate")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Could not import %s: %s.", req.ID, err))
+		return
+	}
+	if hostname != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hostname"), hostname)...)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -0,0 +1,578 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+	"certMgr/internal/pki"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	acc "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCertificateByID(t *testing.T) {
+	client := &clientmock.Client{
+		GetCertificateByIDFunc: func(_ context.Context, id int) (*certMgr.Certificate, error) {
+			return &certMgr.Certificate{ID: id, Hostname: "tf-test.cern.ch"}, nil
+		},
+	}
+	r := &certificateResource{client: client}
+
+	certificate, err := r.readCertificate(context.Background(), certificateResourceModel{
+		ID:       types.Int64Value(42),
+		Hostname: newHostnameValue("tf-test.cern.ch"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, certificate.ID)
+}
+
+func TestReadCertificateByHostname(t *testing.T) {
+	client := &clientmock.Client{
+		GetCertificateFunc: func(_ context.Context, hostname string) (*certMgr.Certificate, error) {
+			return &certMgr.Certificate{ID: 7, Hostname: hostname}, nil
+		},
+	}
+	r := &certificateResource{client: client}
+
+	certificate, err := r.readCertificate(context.Background(), certificateResourceModel{
+		ID:       types.Int64Null(),
+		Hostname: newHostnameValue("tf-test.cern.ch"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tf-test.cern.ch", certificate.Hostname)
+}
+
+func TestReadCertificateNotMocked(t *testing.T) {
+	r := &certificateResource{client: &clientmock.Client{}}
+
+	_, err := r.readCertificate(context.Background(), certificateResourceModel{
+		ID: types.Int64Value(42),
+	})
+	require.ErrorIs(t, err, clientmock.ErrNotMocked)
+}
+
+func TestMoveCertificateStateFromTLS(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&certificateResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	key, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tf-test.cern.ch"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	source, err := json.Marshal(map[string]any{
+		"cert_pem": string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	})
+	require.NoError(t, err)
+
+	req := resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/hashicorp/tls",
+		SourceTypeName:        "tls_locally_signed_cert",
+		SourceRawState:        &tfprotov6.RawState{JSON: source},
+	}
+	resp := resource.MoveStateResponse{
+		TargetState: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	moveCertificateState(ctx, req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state certificateResourceModel
+	require.False(t, resp.TargetState.Get(ctx, &state).HasError())
+	require.Equal(t, "tf-test.cern.ch", state.Hostname.ValueString())
+	require.True(t, state.ID.IsNull())
+
+	// Resources of other providers are left to other movers.
+	req.SourceProviderAddress = "registry.terraform.io/hashicorp/aws"
+	resp.TargetState.Raw = tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+	moveCertificateState(ctx, req, &resp)
+	require.True(t, resp.TargetState.Raw.IsNull())
+}
+
+func TestSetMaterial(t *testing.T) {
+	leafPEM, rootPEM, keyPEM := newTestChain(t)
+
+	var model certificateResourceModel
+	require.False(t, model.setMaterial(&certMgr.Certificate{Hostname: "tf-test.cern.ch"}).HasError())
+	require.True(t, model.ACMCertificateBody.IsNull())
+
+	require.False(t, model.setMaterial(&certMgr.Certificate{
+		Hostname:       "tf-test.cern.ch",
+		CertificatePEM: leafPEM + rootPEM,
+		PrivateKeyPEM:  keyPEM,
+	}).HasError())
+	require.Equal(t, leafPEM, model.ACMCertificateBody.ValueString())
+	require.Equal(t, rootPEM, model.ACMCertificateChain.ValueString())
+	require.Equal(t, keyPEM, model.ACMPrivateKey.ValueString())
+
+	leafDER, _ := pem.Decode([]byte(leafPEM))
+	rootDER, _ := pem.Decode([]byte(rootPEM))
+	require.Equal(t, base64.StdEncoding.EncodeToString(leafDER.Bytes), model.CertificateDERBase64.ValueString())
+	require.Equal(t, base64.StdEncoding.EncodeToString(rootDER.Bytes), model.ChainDERBase64.ValueString())
+
+	require.False(t, model.setMaterial(&certMgr.Certificate{
+		Hostname:       "tf-test.cern.ch",
+		CertificatePEM: leafPEM,
+	}).HasError())
+	require.True(t, model.ACMCertificateChain.IsNull())
+	require.True(t, model.ChainDERBase64.IsNull())
+	require.True(t, model.ACMPrivateKey.IsNull())
+}
+
+func TestSetMaterialBundles(t *testing.T) {
+	leafPEM, rootPEM, keyPEM := newTestChain(t)
+	certificate := &certMgr.Certificate{
+		Hostname:       "tf-test.cern.ch",
+		CertificatePEM: leafPEM + rootPEM,
+		PrivateKeyPEM:  keyPEM,
+	}
+
+	model := certificateResourceModel{
+		BundleOrder:       types.StringValue("leaf_first"),
+		BundleKeyPosition: types.StringValue("last"),
+	}
+	require.False(t, model.setMaterial(certificate).HasError())
+	require.Equal(t, leafPEM+rootPEM, model.BundlePEM.ValueString())
+	require.Equal(t, leafPEM+rootPEM+keyPEM, model.BundleWithKeyPEM.ValueString())
+
+	model.BundleOrder = types.StringValue("root_first")
+	model.BundleKeyPosition = types.StringValue("first")
+	require.False(t, model.setMaterial(certificate).HasError())
+	require.Equal(t, rootPEM+leafPEM, model.BundlePEM.ValueString())
+	require.Equal(t, keyPEM+rootPEM+leafPEM, model.BundleWithKeyPEM.ValueString())
+}
+
+func TestCertMgrRefused(t *testing.T) {
+	require.True(t, certMgrRefused(&certMgr.StatusError{StatusCode: 403}))
+	require.True(t, certMgrRefused(fmt.Errorf("create: %w", &certMgr.StatusError{StatusCode: 422})))
+	require.False(t, certMgrRefused(&certMgr.StatusError{StatusCode: 503}))
+	require.False(t, certMgrRefused(&certMgr.StatusError{StatusCode: 401}))
+	require.False(t, certMgrRefused(errors.New("connection refused")))
+}
+
+// TestCreateACMEFallbackAccountKey checks that the write-only account key is
+// taken from the configuration, as Terraform leaves it out of the plan. The
+// context is cancelled so that the order fails without an ACME directory.
+func TestCreateACMEFallbackAccountKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	key, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	keyPEM, err := pki.EncodePrivateKeyPEM(key)
+	require.NoError(t, err)
+
+	r := &certificateResource{client: &clientmock.Client{
+		CreateCertificateFunc: func(context.Context, certMgr.CertificateRequest) (*certMgr.Certificate, error) {
+			return nil, &certMgr.StatusError{StatusCode: http.StatusForbidden}
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := certificateResourceModel{
+		ID:       types.Int64Unknown(),
+		Hostname: newHostnameValue("tf-test.cern.ch"),
+		Tags:     types.MapNull(types.StringType),
+		ACMEFallback: &acmeFallbackModel{
+			DirectoryURL: types.StringValue("https://acme.invalid/directory"),
+			Webroot:      types.StringValue(t.TempDir()),
+		},
+	}
+	req := resource.CreateRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema},
+	}
+	require.False(t, req.Plan.Set(ctx, model).HasError())
+	model.ACMEFallback.AccountKeyPEMWO = types.StringValue(keyPEM)
+	config := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, config.Set(ctx, model).HasError())
+	req.Config.Raw = config.Raw
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, req, &resp)
+	require.True(t, resp.Diagnostics.HasError())
+	for _, d := range resp.Diagnostics.Errors() {
+		require.Equal(t, "Error Issuing Certificate From ACME", d.Summary(), d.Detail())
+	}
+}
+
+func TestParseIDOrHostname(t *testing.T) {
+	id, hostname, err := parseIDOrHostname(" 42 ", "certificate")
+	require.NoError(t, err)
+	require.Equal(t, int64(42), id)
+	require.Empty(t, hostname)
+
+	id, hostname, err = parseIDOrHostname("TF-Test.cern.ch.", "certificate")
+	require.NoError(t, err)
+	require.Zero(t, id)
+	require.Equal(t, "tf-test.cern.ch", hostname)
+
+	for _, raw := range []string{"", "-1", "0", "tf-test.cern.ch/42", "not a host"} {
+		_, _, err := parseIDOrHostname(raw, "certificate")
+		require.Error(t, err, raw)
+		require.ErrorContains(t, err, "expected", raw)
+	}
+
+	_, err = parseImportID("abc", "template")
+	require.ErrorContains(t, err, `expected the numeric template ID, for example 42, got: "abc"`)
+}
+
+// testPrivateState stands in for the framework's private state data.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestIssuanceMetadata(t *testing.T) {
+	ctx := context.Background()
+	leafPEM, rootPEM, _ := newTestChain(t)
+	private := testPrivateState{}
+
+	metadata, diags := getIssuance(ctx, private)
+	require.False(t, diags.HasError())
+	require.Equal(t, []int{42}, metadata.deletable(42))
+
+	metadata.owns(7)
+	require.False(t, metadata.observe(&certMgr.Certificate{ID: 7, CertificatePEM: leafPEM, ETag: `"v1"`}))
+	require.False(t, setIssuance(ctx, private, metadata).HasError())
+
+	metadata, diags = getIssuance(ctx, private)
+	require.False(t, diags.HasError())
+	require.Equal(t, []int{7}, metadata.deletable(42))
+	require.Equal(t, `"v1"`, metadata.ETag)
+	require.False(t, metadata.observe(&certMgr.Certificate{ID: 7, CertificatePEM: leafPEM, ETag: `"v2"`}))
+	require.True(t, metadata.observe(&certMgr.Certificate{ID: 7, CertificatePEM: rootPEM}))
+}
+
+func TestCertificateUpdate(t *testing.T) {
+	ctx := context.Background()
+	tags := func(m map[string]string) types.Map {
+		return types.MapValueMust(types.StringType, func() map[string]attr.Value {
+			values := map[string]attr.Value{}
+			for k, v := range m {
+				values[k] = types.StringValue(v)
+			}
+			return values
+		}())
+	}
+	state := certificateResourceModel{
+		ID:        types.Int64Value(42),
+		Hostname:  newHostnameValue("tf-test.cern.ch"),
+		Requestor: types.StringValue("alice"),
+		Tags:      tags(map[string]string{"env": "dev"}),
+	}
+	requestor := "bob"
+
+	tests := []struct {
+		name    string
+		plan    func(certificateResourceModel) certificateResourceModel
+		changed bool
+		want    certMgr.CertificateUpdate
+	}{
+		{
+			name:    "unchanged",
+			plan:    func(m certificateResourceModel) certificateResourceModel { return m },
+			changed: false,
+		},
+		{
+			name: "requestor",
+			plan: func(m certificateResourceModel) certificateResourceModel {
+				m.Requestor = types.StringValue(requestor)
+				return m
+			},
+			changed: true,
+			want:    certMgr.CertificateUpdate{Requestor: &requestor},
+		},
+		{
+			name: "tags",
+			plan: func(m certificateResourceModel) certificateResourceModel {
+				m.Tags = tags(map[string]string{"env": "prod"})
+				return m
+			},
+			changed: true,
+			want:    certMgr.CertificateUpdate{Tags: &map[string]string{"env": "prod"}},
+		},
+		{
+			name: "tags removed",
+			plan: func(m certificateResourceModel) certificateResourceModel {
+				m.Tags = tags(map[string]string{})
+				return m
+			},
+			changed: true,
+			want:    certMgr.CertificateUpdate{Tags: &map[string]string{}},
+		},
+		{
+			name: "unknown",
+			plan: func(m certificateResourceModel) certificateResourceModel {
+				m.Requestor = types.StringUnknown()
+				m.Tags = types.MapUnknown(types.StringType)
+				return m
+			},
+			changed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, changed, diags := certificateUpdate(ctx, tt.plan(state), state)
+			require.False(t, diags.HasError())
+			require.Equal(t, tt.changed, changed)
+			tt.want.ID = 42
+			tt.want.Hostname = "tf-test.cern.ch"
+			require.Equal(t, tt.want, update)
+		})
+	}
+}
+
+func TestModifyPlanUnknownHostname(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&certificateResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := certificateResourceModel{
+		ID:                   types.Int64Value(42),
+		Hostname:             newHostnameValue("tf-test.cern.ch"),
+		Tags:                 types.MapNull(types.StringType),
+		CertificateDERBase64: types.StringValue("MIIB"),
+		Issuer:               types.StringValue(issuerCertMgr),
+	}
+	plan := state
+	plan.Hostname = hostnameValue{StringValue: types.StringUnknown()}
+
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+	}
+	require.False(t, req.State.Set(ctx, state).HasError())
+	require.False(t, req.Plan.Set(ctx, plan).HasError())
+	resp := resource.ModifyPlanResponse{Plan: req.Plan}
+
+	(&certificateResource{}).ModifyPlan(ctx, req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var planned certificateResourceModel
+	require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+	require.True(t, planned.ID.IsUnknown())
+	require.True(t, planned.CertificateDERBase64.IsUnknown())
+	require.True(t, planned.Issuer.IsUnknown())
+	require.Len(t, resp.RequiresReplace, 1)
+}
+
+func TestConflictingKeyMaterial(t *testing.T) {
+	ctx := context.Background()
+	r := &certificateResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	validate := func(model certificateResourceModel) resource.ValidateConfigResponse {
+		req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema}}
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, model).HasError())
+		req.Config.Raw = state.Raw
+		var resp resource.ValidateConfigResponse
+		for _, validator := range r.ConfigValidators(ctx) {
+			validator.ValidateResource(ctx, req, &resp)
+		}
+		return resp
+	}
+
+	// Each key source on its own is valid, including certMgr generating the
+	// key pair when neither is set.
+	model := certificateResourceModel{
+		Hostname: newHostnameValue("tf-test.cern.ch"),
+		Tags:     types.MapNull(types.StringType),
+	}
+	require.False(t, validate(model).Diagnostics.HasError())
+	model.PrivateKeyPEMWO = types.StringValue("key")
+	require.False(t, validate(model).Diagnostics.HasError())
+	model.PrivateKeyPEMWO = types.StringNull()
+	model.CSRPEM = types.StringUnknown()
+	require.False(t, validate(model).Diagnostics.HasError())
+
+	model.PrivateKeyPEMWO = types.StringValue("key")
+	resp := validate(model)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "csr_pem and private_key_pem_wo select different modes")
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "or none of them if certMgr generates the key pair")
+}
+
+func TestAddReadError(t *testing.T) {
+	warn := &clientmock.Client{ReadErrorsAsWarningsFunc: func() bool { return true }}
+	unavailable := &certMgr.StatusError{StatusCode: 503}
+
+	var diags diag.Diagnostics
+	addReadError(&diags, warn, unavailable, "Error Reading Certificate", "Could not read certificate")
+	require.False(t, diags.HasError())
+	require.Equal(t, 1, diags.WarningsCount())
+
+	diags = nil
+	addReadError(&diags, warn, &certMgr.StatusError{StatusCode: 400}, "Error Reading Certificate", "Could not read certificate")
+	require.True(t, diags.HasError())
+
+	diags = nil
+	addReadError(&diags, &clientmock.Client{}, unavailable, "Error Reading Certificate", "Could not read certificate")
+	require.True(t, diags.HasError())
+}
+
+func TestModifyPlanRevoked(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&certificateResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := func(replace bool) resource.ModifyPlanResponse {
+		state := certificateResourceModel{
+			ID:                  types.Int64Value(42),
+			Hostname:            newHostnameValue("tf-test.cern.ch"),
+			Tags:                types.MapNull(types.StringType),
+			Revoked:             types.BoolValue(true),
+			ReplaceOnRevocation: types.BoolValue(replace),
+		}
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: schemaResp.Schema},
+			Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+		}
+		require.False(t, req.State.Set(ctx, state).HasError())
+		require.False(t, req.Plan.Set(ctx, state).HasError())
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		(&certificateResource{}).ModifyPlan(ctx, req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		return resp
+	}
+
+	resp := plan(true)
+	var planned certificateResourceModel
+	require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+	require.False(t, planned.Revoked.ValueBool())
+	require.Len(t, resp.RequiresReplace, 1)
+
+	require.Empty(t, plan(false).RequiresReplace)
+}
+
+func TestRevoked(t *testing.T) {
+	leafPEM, _, _ := newTestChain(t)
+	var queried []string
+	r := &certificateResource{client: &clientmock.Client{
+		CertificateRevokedFunc: func(_ context.Context, serial string) (bool, error) {
+			queried = append(queried, serial)
+			return serial == "0a", nil
+		},
+	}}
+	ctx := context.Background()
+
+	revoked, err := r.revoked(ctx, &certMgr.Certificate{Serial: "0a"})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = r.revoked(ctx, &certMgr.Certificate{CertificatePEM: leafPEM})
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	// Entries not issued yet are not looked up.
+	revoked, err = r.revoked(ctx, &certMgr.Certificate{})
+	require.NoError(t, err)
+	require.False(t, revoked)
+	require.Len(t, queried, 2)
+}
+
+func TestAccCertificateResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	hostname := testAccHostnamePrefix + acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum) + ".cern.ch"
+	config := providerConfig + fmt.Sprintf(`
+resource "certmgr_certificate" "test" {
+  hostname = %q
+  tags = {
+    team = "tf-test"
+  }
+}
+`, hostname)
+	var firstID int
+
+	acc.Test(t, acc.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if certs := server.Certificates(); len(certs) > 0 {
+				return fmt.Errorf("%d staged entries left after destroy", len(certs))
+			}
+			return nil
+		},
+		Steps: []acc.TestStep{
+			{
+				Config: config,
+				Check: acc.ComposeAggregateTestCheckFunc(
+					acc.TestCheckResourceAttr("certmgr_certificate.test", "hostname", hostname),
+					acc.TestCheckResourceAttr("certmgr_certificate.test", "issuer", issuerCertMgr),
+					acc.TestCheckResourceAttr("certmgr_certificate.test", "revoked", "false"),
+					acc.TestCheckResourceAttr("certmgr_certificate.test", "tags.team", "tf-test"),
+					acc.TestCheckResourceAttrSet("certmgr_certificate.test", "bundle_pem"),
+					func(*terraform.State) error {
+						certs := server.Certificates()
+						if len(certs) != 1 {
+							return fmt.Errorf("expected one staged entry, got %d", len(certs))
+						}
+						firstID = certs[0].ID
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "certmgr_certificate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Settings only held in configuration are not imported.
+				ImportStateVerifyIgnore: []string{"last_updated", "file_mode", "bundle_order", "bundle_key_position", "replace_on_revocation"},
+			},
+			{
+				// An entry deleted in the certMgr UI is requested again.
+				PreConfig: func() { server.Remove(firstID) },
+				Config:    config,
+				Check: func(*terraform.State) error {
+					certs := server.Certificates()
+					if len(certs) != 1 || certs[0].ID == firstID {
+						return fmt.Errorf("expected a new staged entry replacing %d, got %v", firstID, certs)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
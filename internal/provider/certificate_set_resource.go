@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource              = &certificateSetResource{}
+	_ resource.ResourceWithConfigure = &certificateSetResource{}
+)
+
+func NewCertificateSetResource() resource.Resource {
+	return &certificateSetResource{}
+}
+
+type certificateSetResourceModel struct {
+	Hostnames    types.Set    `tfsdk:"hostnames"`
+	Certificates types.Map    `tfsdk:"certificates"`
+	Organization types.String `tfsdk:"organization"`
+}
+
+type certificateSetEntryModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Requestor types.String `tfsdk:"requestor"`
+	Start     types.String `tfsdk:"start"`
+	End       types.String `tfsdk:"end"`
+}
+
+var certificateSetEntryAttrTypes = map[string]attr.Type{
+	"id":        types.Int64Type,
+	"requestor": types.StringType,
+	"start":     types.StringType,
+	"end":       types.StringType,
+}
+
+type certificateSetResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *certificateSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_set"
+}
+
+func (r *certificateSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages certificates for a set of hostnames as one unit, using bulk API calls. " +
+			"Adding or removing hostnames only stages or deletes the certificates of those hostnames.",
+		Attributes: map[string]schema.Attribute{
+			"hostnames": schema.SetAttribute{
+				Description: "Hostnames to manage certificates for.",
+				ElementType: hostnameType{},
+				Required:    true,
+			},
+			"certificates": schema.MapNestedAttribute{
+				Description: "Certificates keyed by normalized hostname.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "Numeric identifier of the certificate.",
+							Computed:    true,
+						},
+						"requestor": schema.StringAttribute{
+							Description: "Requestor recorded for the certificate.",
+							Computed:    true,
+						},
+						"start": schema.StringAttribute{
+							Description: "Start of the validity of the certificate.",
+							Computed:    true,
+						},
+						"end": schema.StringAttribute{
+							Description: "End of the validity of the certificate.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificates are managed in, overriding the provider's organization. " +
+					"Changing this forces a new set.",
+			),
+		},
+	}
+}
+
+func (r *certificateSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan certificateSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	hostnames, diags := normalizedHostnames(ctx, plan.Hostnames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := map[string]certificateSetEntryModel{}
+	resp.Diagnostics.Append(r.stage(ctx, hostnames, entries)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Certificates, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: certificateSetEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state certificateSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	entries := map[string]certificateSetEntryModel{}
+	resp.Diagnostics.Append(state.Certificates.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostnames := make([]string, 0, len(entries))
+	for hostname := range entries {
+		hostnames = append(hostnames, hostname)
+	}
+
+	staged, err := r.client.ListStagedForHostnames(ctx, hostnames)
+	if err != nil {
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Certificate Set",
+			"Could not list certificates of the set: "+err.Error(),
+		)
+		return
+	}
+
+	byID := make(map[int]certMgr.Certificate, len(staged))
+	for _, cert := range staged {
+		byID[cert.ID] = cert
+	}
+
+	// Hostnames whose certificate disappeared are dropped from both
+	// attributes, so the next plan stages them again.
+	present := make([]string, 0, len(entries))
+	for hostname, entry := range entries {
+		cert, ok := byID[int(entry.ID.ValueInt64())]
+		if !ok {
+			delete(entries, hostname)
+			continue
+		}
+		entries[hostname] = newCertificateSetEntry(cert)
+		present = append(present, hostname)
+	}
+
+	state.Hostnames, diags = hostnameSetValue(ctx, present)
+	resp.Diagnostics.Append(diags...)
+	state.Certificates, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: certificateSetEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state certificateSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	hostnames, diags := normalizedHostnames(ctx, plan.Hostnames)
+	resp.Diagnostics.Append(diags...)
+
+	entries := map[string]certificateSetEntryModel{}
+	resp.Diagnostics.Append(state.Certificates.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := make(map[string]bool, len(hostnames))
+	var added []string
+	for _, hostname := range hostnames {
+		wanted[hostname] = true
+		if _, ok := entries[hostname]; !ok {
+			added = append(added, hostname)
+		}
+	}
+
+	var removed []int
+	for hostname, entry := range entries {
+		if !wanted[hostname] {
+			removed = append(removed, int(entry.ID.ValueInt64()))
+			delete(entries, hostname)
+		}
+	}
+
+	if err := r.client.DeleteStagedEntries(ctx, removed); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating certificate set",
+			"Could not delete certificates of removed hostnames: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.stage(ctx, added, entries)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Certificates, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: certificateSetEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state certificateSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	entries := map[string]certificateSetEntryModel{}
+	resp.Diagnostics.Append(state.Certificates.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, int(entry.ID.ValueInt64()))
+	}
+
+	if err := r.client.DeleteStagedEntries(ctx, ids); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting certificate set",
+			"Could not delete certificates of the set: "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *certificateSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// stage creates certificates for hostnames in one bulk call and records them
+// in entries.
+func (r *certificateSetResource) stage(ctx context.Context, hostnames []string, entries map[string]certificateSetEntryModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(hostnames) == 0 {
+		return diags
+	}
+
+	created, err := r.client.CreateCertificates(ctx, hostnames)
+	if err != nil {
+		diags.AddError(
+			"Error staging certificates",
+			"Could not stage certificates for the set: "+err.Error(),
+		)
+		return diags
+	}
+
+	for _, cert := range created {
+		entries[cert.Hostname] = newCertificateSetEntry(cert)
+	}
+	return diags
+}
+
+func newCertificateSetEntry(cert certMgr.Certificate) certificateSetEntryModel {
+	return certificateSetEntryModel{
+		ID:        types.Int64Value(int64(cert.ID)),
+		Requestor: types.StringValue(cert.Requestor),
+		Start:     types.StringValue(cert.Start),
+		End:       types.StringValue(cert.End),
+	}
+}
+
+// normalizedHostnames returns the normalized elements of a set of hostnames.
+func normalizedHostnames(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	var hostnames []string
+	diags := set.ElementsAs(ctx, &hostnames, false)
+	for i, hostname := range hostnames {
+		normalized, err := certMgr.NormalizeHostname(hostname)
+		if err != nil {
+			diags.AddError("Invalid Hostname", err.Error())
+			continue
+		}
+		hostnames[i] = normalized
+	}
+	return hostnames, diags
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCertificateSetMembership checks that changing the hostnames of a set
+// only stages and deletes the entries of added and removed hostnames, and
+// that an entry deleted in certMgr drops its hostname on refresh.
+func TestCertificateSetMembership(t *testing.T) {
+	ctx := context.Background()
+	server := fakecertmgr.NewServer(t)
+	r := &certificateSetResource{client: server.NewClient()}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := func(hostnames ...string) tfsdk.Plan {
+		set, diags := hostnameSetValue(ctx, hostnames)
+		require.False(t, diags.HasError())
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(ctx, certificateSetResourceModel{
+			Hostnames:    set,
+			Certificates: types.MapUnknown(types.ObjectType{AttrTypes: certificateSetEntryAttrTypes}),
+			Organization: types.StringNull(),
+		}).HasError())
+		return plan
+	}
+	ids := func(state tfsdk.State) map[string]int {
+		var model certificateSetResourceModel
+		require.False(t, state.Get(ctx, &model).HasError())
+		entries := map[string]certificateSetEntryModel{}
+		require.False(t, model.Certificates.ElementsAs(ctx, &entries, false).HasError())
+		require.Len(t, model.Hostnames.Elements(), len(entries))
+		ids := map[string]int{}
+		for hostname, entry := range entries {
+			ids[hostname] = int(entry.ID.ValueInt64())
+		}
+		return ids
+	}
+	staged := func() map[string]int {
+		ids := map[string]int{}
+		for _, cert := range server.Certificates() {
+			ids[cert.Hostname] = cert.ID
+		}
+		return ids
+	}
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan("TF-Test-A.cern.ch", "tf-test-b.cern.ch")}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	created := ids(createResp.State)
+	require.Equal(t, staged(), created)
+	require.Contains(t, created, "tf-test-a.cern.ch")
+
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  plan("tf-test-a.cern.ch", "tf-test-c.cern.ch"),
+		State: createResp.State,
+	}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	updated := ids(updateResp.State)
+	require.Equal(t, staged(), updated)
+	require.Equal(t, created["tf-test-a.cern.ch"], updated["tf-test-a.cern.ch"])
+	require.NotContains(t, updated, "tf-test-b.cern.ch")
+
+	server.Remove(updated["tf-test-c.cern.ch"])
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.Equal(t, map[string]int{"tf-test-a.cern.ch": created["tf-test-a.cern.ch"]}, ids(readResp.State))
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Empty(t, server.Certificates())
+}
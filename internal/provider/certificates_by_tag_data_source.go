@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ datasource.DataSource              = &certificatesByTagDataSource{}
+	_ datasource.DataSourceWithConfigure = &certificatesByTagDataSource{}
+)
+
+func NewCertificatesByTagDataSource() datasource.DataSource {
+	return &certificatesByTagDataSource{}
+}
+
+type certificatesByTagDataSourceModel struct {
+	Tags         types.Map                `tfsdk:"tags"`
+	Certificates []taggedCertificateModel `tfsdk:"certificates"`
+}
+
+type taggedCertificateModel struct {
+	ID        types.Int64   `tfsdk:"id"`
+	Hostname  hostnameValue `tfsdk:"hostname"`
+	Requestor types.String  `tfsdk:"requestor"`
+	Serial    types.String  `tfsdk:"serial"`
+	Start     types.String  `tfsdk:"start"`
+	End       types.String  `tfsdk:"end"`
+	Tags      types.Map     `tfsdk:"tags"`
+}
+
+type certificatesByTagDataSource struct {
+	client certMgr.ClientAPI
+}
+
+func (d *certificatesByTagDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificates_by_tag"
+}
+
+func (d *certificatesByTagDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the certificates carrying all of the given tags.",
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.MapAttribute{
+				Description: "Tags a certificate must carry to be listed, for example `{ team = \"db\" }`.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"certificates": certificateListAttribute(),
+		},
+	}
+}
+
+func (d *certificatesByTagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var config certificatesByTagDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selector := map[string]string{}
+	resp.Diagnostics.Append(config.Tags.ElementsAs(ctx, &selector, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certs, err := d.client.ListStagedByTags(ctx, selector)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Certificates",
+			"Could not list certificates by tag: "+err.Error(),
+		)
+		return
+	}
+
+	config.Certificates, diags = newTaggedCertificateModels(ctx, certs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *certificatesByTagDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// certificateListAttribute is the schema of the certificates listed by the
+// plural certificate data sources.
+func certificateListAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "Matching certificates.",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.Int64Attribute{
+					Description: "Numeric identifier of the certificate.",
+					Computed:    true,
+				},
+				"hostname": schema.StringAttribute{
+					Description: "Hostname the certificate was issued for.",
+					Computed:    true,
+					CustomType:  hostnameType{},
+				},
+				"requestor": schema.StringAttribute{
+					Description: "Account that requested the certificate.",
+					Computed:    true,
+				},
+				"serial": schema.StringAttribute{
+					Description: "Serial number of the certificate.",
+					Computed:    true,
+				},
+				"start": schema.StringAttribute{
+					Description: "Start of the validity of the certificate.",
+					Computed:    true,
+				},
+				"end": schema.StringAttribute{
+					Description: "End of the validity of the certificate.",
+					Computed:    true,
+				},
+				"tags": schema.MapAttribute{
+					Description: "All tags of the certificate.",
+					ElementType: types.StringType,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func newTaggedCertificateModels(ctx context.Context, certs []certMgr.Certificate) ([]taggedCertificateModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	models := make([]taggedCertificateModel, 0, len(certs))
+	for _, cert := range certs {
+		tags, d := types.MapValueFrom(ctx, types.StringType, cert.Tags)
+		diags.Append(d...)
+		models = append(models, taggedCertificateModel{
+			ID:        types.Int64Value(int64(cert.ID)),
+			Hostname:  newHostnameValue(cert.Hostname),
+			Requestor: types.StringValue(cert.Requestor),
+			Serial:    types.StringValue(cert.Serial),
+			Start:     types.StringValue(cert.Start),
+			End:       types.StringValue(cert.End),
+			Tags:      tags,
+		})
+	}
+	return models, diags
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCertificatesByTagDataSource checks that only entries carrying every
+// selected tag are listed.
+func TestCertificatesByTagDataSource(t *testing.T) {
+	ctx := context.Background()
+	server := fakecertmgr.NewServer(t)
+	client := server.NewClient()
+	d := &certificatesByTagDataSource{client: client}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	tagged := map[string]map[string]string{
+		"tf-test-a.cern.ch": {"env": "prod", "team": "web"},
+		"tf-test-b.cern.ch": {"env": "prod"},
+		"tf-test-c.cern.ch": {"env": "dev", "team": "web"},
+	}
+	for hostname, tags := range tagged {
+		cert, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: hostname})
+		require.NoError(t, err)
+		require.NoError(t, client.UpdateCertificate(ctx, certMgr.CertificateUpdate{ID: cert.ID, Hostname: hostname, Tags: &tags}))
+	}
+
+	tests := []struct {
+		name     string
+		selector map[string]string
+		want     []string
+	}{
+		{name: "all tags", selector: map[string]string{"env": "prod", "team": "web"}, want: []string{"tf-test-a.cern.ch"}},
+		{name: "one tag", selector: map[string]string{"env": "prod"}, want: []string{"tf-test-a.cern.ch", "tf-test-b.cern.ch"}},
+		{name: "none", selector: map[string]string{"env": "staging"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, diags := types.MapValueFrom(ctx, types.StringType, tt.selector)
+			require.False(t, diags.HasError())
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, certificatesByTagDataSourceModel{Tags: selector}).HasError())
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var state certificatesByTagDataSourceModel
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+			hostnames := []string{}
+			for _, cert := range state.Certificates {
+				hostname := cert.Hostname.ValueString()
+				hostnames = append(hostnames, hostname)
+
+				tags := map[string]string{}
+				require.False(t, cert.Tags.ElementsAs(ctx, &tags, false).HasError())
+				require.Equal(t, tagged[hostname], tags)
+			}
+			require.ElementsMatch(t, tt.want, hostnames)
+		})
+	}
+}
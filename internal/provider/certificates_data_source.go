@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ datasource.DataSource                   = &certificatesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &certificatesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &certificatesDataSource{}
+)
+
+func NewCertificatesDataSource() datasource.DataSource {
+	return &certificatesDataSource{}
+}
+
+type certificatesDataSourceModel struct {
+	HostnamePrefix types.String             `tfsdk:"hostname_prefix"`
+	Requestor      types.String             `tfsdk:"requestor"`
+	Tags           types.Map                `tfsdk:"tags"`
+	StartAfter     types.String             `tfsdk:"start_after"`
+	StartBefore    types.String             `tfsdk:"start_before"`
+	Certificates   []taggedCertificateModel `tfsdk:"certificates"`
+}
+
+type certificatesDataSource struct {
+	client certMgr.ClientAPI
+}
+
+func (d *certificatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificates"
+}
+
+func (d *certificatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the certificates matching all of the given filters, which are evaluated by certMgr. At least one filter is required.",
+		Attributes: map[string]schema.Attribute{
+			"hostname_prefix": schema.StringAttribute{
+				Description: "Prefix the hostname of a certificate must start with, for example `db-`.",
+				Optional:    true,
+			},
+			"requestor": schema.StringAttribute{
+				Description: "Account that requested the certificate.",
+				Optional:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags a certificate must carry to be listed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"start_after": schema.StringAttribute{
+				Description: "RFC 3339 timestamp; only certificates valid from this time or later are listed.",
+				Optional:    true,
+			},
+			"start_before": schema.StringAttribute{
+				Description: "RFC 3339 timestamp; only certificates valid from this time or earlier are listed.",
+				Optional:    true,
+			},
+			"certificates": certificateListAttribute(),
+		},
+	}
+}
+
+func (d *certificatesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config certificatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.HostnamePrefix.IsNull() && config.Requestor.IsNull() && config.Tags.IsNull() &&
+		config.StartAfter.IsNull() && config.StartBefore.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing Certificate Filter",
+			"At least one of hostname_prefix, requestor, tags, start_after or start_before must be set.",
+		)
+	}
+
+	for name, value := range map[string]types.String{"start_after": config.StartAfter, "start_before": config.StartBefore} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if _, err := certMgr.ParseTimestamp(value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid Timestamp", err.Error())
+		}
+	}
+}
+
+func (d *certificatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var config certificatesDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := certMgr.CertificateFilter{
+		HostnamePrefix: config.HostnamePrefix.ValueString(),
+		Requestor:      config.Requestor.ValueString(),
+	}
+	if !config.Tags.IsNull() {
+		resp.Diagnostics.Append(config.Tags.ElementsAs(ctx, &filter.Tags, false)...)
+	}
+	if !config.StartAfter.IsNull() {
+		filter.StartAfter, _ = certMgr.ParseTimestamp(config.StartAfter.ValueString())
+	}
+	if !config.StartBefore.IsNull() {
+		filter.StartBefore, _ = certMgr.ParseTimestamp(config.StartBefore.ValueString())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certs, err := d.client.ListCertificates(ctx, filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Certificates",
+			"Could not list certificates: "+err.Error(),
+		)
+		return
+	}
+
+	config.Certificates, diags = newTaggedCertificateModels(ctx, certs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *certificatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// certificatesConfig is a certificates data source configuration with every
+// filter unset.
+func certificatesConfig() certificatesDataSourceModel {
+	return certificatesDataSourceModel{
+		HostnamePrefix: types.StringNull(),
+		Requestor:      types.StringNull(),
+		Tags:           types.MapNull(types.StringType),
+		StartAfter:     types.StringNull(),
+		StartBefore:    types.StringNull(),
+	}
+}
+
+func TestCertificatesDataSourceFilter(t *testing.T) {
+	ctx := context.Background()
+	d := &certificatesDataSource{client: &clientmock.Client{
+		ListCertificatesFunc: func(_ context.Context, filter certMgr.CertificateFilter) ([]certMgr.Certificate, error) {
+			require.Equal(t, certMgr.CertificateFilter{
+				HostnamePrefix: "tf-test",
+				Tags:           map[string]string{"env": "prod"},
+				StartAfter:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			}, filter)
+			return []certMgr.Certificate{
+				{ID: 4, Hostname: "tf-test.cern.ch", Serial: "2A", Tags: map[string]string{"env": "prod"}},
+			}, nil
+		},
+	}}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	model := certificatesConfig()
+	model.HostnamePrefix = types.StringValue("tf-test")
+	model.Tags = types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("prod")})
+	model.StartAfter = types.StringValue("2025-01-01T00:00:00Z")
+	config := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, config.Set(ctx, model).HasError())
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state certificatesDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Len(t, state.Certificates, 1)
+	require.Equal(t, int64(4), state.Certificates[0].ID.ValueInt64())
+	require.Equal(t, "2A", state.Certificates[0].Serial.ValueString())
+	require.Equal(t, model.Tags, state.Certificates[0].Tags)
+}
+
+func TestCertificatesDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	(&certificatesDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name      string
+		modify    func(*certificatesDataSourceModel)
+		summaries []string
+	}{
+		{name: "no filter", summaries: []string{"Missing Certificate Filter"}},
+		{name: "requestor", modify: func(m *certificatesDataSourceModel) { m.Requestor = types.StringValue("jdoe") }},
+		{name: "start before", modify: func(m *certificatesDataSourceModel) { m.StartBefore = types.StringValue("2025-06-30T12:00:00Z") }},
+		{name: "unknown start", modify: func(m *certificatesDataSourceModel) { m.StartAfter = types.StringUnknown() }},
+		{
+			name:      "invalid start",
+			modify:    func(m *certificatesDataSourceModel) { m.StartAfter = types.StringValue("last week") },
+			summaries: []string{"Invalid Timestamp"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := certificatesConfig()
+			if tt.modify != nil {
+				tt.modify(&model)
+			}
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, model).HasError())
+
+			var resp datasource.ValidateConfigResponse
+			(&certificatesDataSource{}).ValidateConfig(ctx, datasource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, &resp)
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			require.Equal(t, tt.summaries, summaries)
+		})
+	}
+}
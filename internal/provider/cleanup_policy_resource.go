@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                   = &cleanupPolicyResource{}
+	_ resource.ResourceWithConfigure      = &cleanupPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &cleanupPolicyResource{}
+)
+
+func NewCleanupPolicyResource() resource.Resource {
+	return &cleanupPolicyResource{}
+}
+
+type cleanupPolicyResourceModel struct {
+	Hostname      hostnameValue `tfsdk:"hostname"`
+	Requestor     types.String  `tfsdk:"requestor"`
+	OlderThanDays types.Int64   `tfsdk:"older_than_days"`
+	KeepLatest    types.Bool    `tfsdk:"keep_latest"`
+	Triggers      types.Map     `tfsdk:"triggers"`
+	DeletedIDs    types.List    `tfsdk:"deleted_ids"`
+	LastRun       types.String  `tfsdk:"last_run"`
+	Organization  types.String  `tfsdk:"organization"`
+}
+
+type cleanupPolicyResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *cleanupPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cleanup_policy"
+}
+
+func (r *cleanupPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Prunes stale staged entries of a hostname or requestor. The pruning runs on create and whenever an argument " +
+			"(including `triggers`) changes; destroying the resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"hostname": schema.StringAttribute{
+				Description: "Hostname whose staged entries are pruned. Exactly one of `hostname` or `requestor` must be set.",
+				Optional:    true,
+				CustomType:  hostnameType{},
+			},
+			"requestor": schema.StringAttribute{
+				Description: "Requestor whose staged entries are pruned. Exactly one of `hostname` or `requestor` must be set.",
+				Optional:    true,
+			},
+			"older_than_days": schema.Int64Attribute{
+				Description: "Only entries that started more than this many days ago are pruned.",
+				Required:    true,
+			},
+			"keep_latest": schema.BoolAttribute{
+				Description: "Never prune the newest entry of a hostname, so only superseded entries are removed. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that, when changed, run the pruning again.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"deleted_ids": schema.ListAttribute{
+				Description: "Identifiers of the staged entries deleted by the last run.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"last_run": schema.StringAttribute{
+				Description: "Time of the last pruning run in RFC 3339 format.",
+				Computed:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance whose staged entries are pruned, overriding the provider's organization. " +
+					"Changing this forces a new policy.",
+			),
+		},
+	}
+}
+
+func (r *cleanupPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config cleanupPolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Hostname.IsUnknown() && !config.Requestor.IsUnknown() &&
+		config.Hostname.IsNull() == config.Requestor.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Invalid Cleanup Scope",
+			"Exactly one of hostname or requestor must be set.",
+		)
+	}
+
+	if !config.OlderThanDays.IsNull() && !config.OlderThanDays.IsUnknown() && config.OlderThanDays.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("older_than_days"),
+			"Invalid Age",
+			fmt.Sprintf("older_than_days must be at least 1, got: %d", config.OlderThanDays.ValueInt64()),
+		)
+	}
+}
+
+func (r *cleanupPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan cleanupPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.prune(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state as is; the outcome of the last run cannot be
+// re-derived from certMgr.
+func (r *cleanupPolicyResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+func (r *cleanupPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan cleanupPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.prune(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *cleanupPolicyResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *cleanupPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// prune deletes the stale entries selected by m and records the outcome.
+func (r *cleanupPolicyResource) prune(ctx context.Context, m *cleanupPolicyResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var entries []certMgr.Certificate
+	var err error
+	if !m.Hostname.IsNull() {
+		entries, err = r.client.ListStaged(ctx, m.Hostname.ValueString())
+	} else {
+		entries, err = r.client.ListStagedByRequestor(ctx, m.Requestor.ValueString())
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Listing Staged Entries",
+			"Could not list staged entries to prune: "+err.Error(),
+		)
+		return diags
+	}
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -int(m.OlderThanDays.ValueInt64()))
+	stale := certMgr.StaleEntries(entries, cutoff, m.KeepLatest.ValueBool())
+
+	ids := make([]int, 0, len(stale))
+	for _, entry := range stale {
+		ids = append(ids, entry.ID)
+	}
+	if err := r.client.DeleteStagedEntries(ctx, ids); err != nil {
+		diags.AddError(
+			"Error Pruning Staged Entries",
+			fmt.Sprintf("Could not delete %d stale staged entries: %s", len(ids), err),
+		)
+		return diags
+	}
+
+	m.DeletedIDs, diags = types.ListValueFrom(ctx, types.Int64Type, ids)
+	m.LastRun = types.StringValue(now.UTC().Format(time.RFC3339))
+	return diags
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupPolicyPrune(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	day := func(n int) string { return now.AddDate(0, 0, -n).Format(time.RFC3339) }
+	entries := []certMgr.Certificate{
+		{ID: 1, Hostname: "tf-test.cern.ch", Start: day(90)},
+		{ID: 2, Hostname: "tf-test.cern.ch", Start: day(60)},
+		{ID: 3, Hostname: "tf-test2.cern.ch", Start: day(40)},
+		{ID: 4, Hostname: "tf-test2.cern.ch", Start: day(5)},
+	}
+
+	tests := []struct {
+		name       string
+		hostname   string
+		requestor  string
+		keepLatest bool
+		want       []int64
+	}{
+		{name: "hostname", hostname: "tf-test.cern.ch", want: []int64{1, 2, 3}},
+		{name: "keep latest", hostname: "tf-test.cern.ch", keepLatest: true, want: []int64{1, 3}},
+		{name: "requestor", requestor: "jdoe", want: []int64{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []int
+			r := &cleanupPolicyResource{client: &clientmock.Client{
+				ListStagedFunc: func(_ context.Context, hostname string) ([]certMgr.Certificate, error) {
+					require.Equal(t, tt.hostname, hostname)
+					return entries, nil
+				},
+				ListStagedByRequestorFunc: func(_ context.Context, requestor string) ([]certMgr.Certificate, error) {
+					require.Equal(t, tt.requestor, requestor)
+					return entries, nil
+				},
+				DeleteStagedEntriesFunc: func(_ context.Context, ids []int) error {
+					deleted = ids
+					return nil
+				},
+			}}
+
+			model := cleanupPolicyResourceModel{
+				Hostname:      hostnameValue{StringValue: types.StringNull()},
+				Requestor:     types.StringNull(),
+				OlderThanDays: types.Int64Value(30),
+				KeepLatest:    types.BoolValue(tt.keepLatest),
+			}
+			if tt.hostname != "" {
+				model.Hostname = newHostnameValue(tt.hostname)
+			} else {
+				model.Requestor = types.StringValue(tt.requestor)
+			}
+			diags := r.prune(ctx, &model)
+			require.False(t, diags.HasError(), diags)
+
+			var ids []int64
+			require.False(t, model.DeletedIDs.ElementsAs(ctx, &ids, false).HasError())
+			require.ElementsMatch(t, tt.want, ids)
+			require.Len(t, deleted, len(tt.want))
+
+			lastRun, err := time.Parse(time.RFC3339, model.LastRun.ValueString())
+			require.NoError(t, err)
+			require.WithinDuration(t, now, lastRun, time.Minute)
+		})
+	}
+}
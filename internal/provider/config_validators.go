@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var _ resource.ConfigValidator = conflictingAttributes{}
+
+// conflictingAttributes selects exactly one mode of a resource: each of a set
+// of attributes selects a mode, and configuring none of them selects the
+// default mode, described by fallback. Configuring several is an error.
+type conflictingAttributes struct {
+	summary  string
+	paths    []path.Path
+	fallback string
+}
+
+func (v conflictingAttributes) Description(_ context.Context) string {
+	return fmt.Sprintf("Set one of %s, or none of them if %s.", v.names(), v.fallback)
+}
+
+func (v conflictingAttributes) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v conflictingAttributes) names() string {
+	names := make([]string, len(v.paths))
+	for i, p := range v.paths {
+		names[i] = p.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func (v conflictingAttributes) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configured []path.Path
+	for _, p := range v.paths {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &value)...)
+		if value != nil && !value.IsNull() {
+			configured = append(configured, p)
+		}
+	}
+	if len(configured) < 2 {
+		return
+	}
+
+	names := make([]string, len(configured))
+	for i, p := range configured {
+		names[i] = p.String()
+	}
+	resp.Diagnostics.AddAttributeError(
+		configured[len(configured)-1],
+		v.summary,
+		fmt.Sprintf("%s select different modes. %s", strings.Join(names, " and "), v.Description(ctx)),
+	)
+}
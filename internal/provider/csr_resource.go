@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+)
+
+var (
+	_ resource.Resource              = &csrResource{}
+	_ resource.ResourceWithConfigure = &csrResource{}
+)
+
+func NewCSRResource() resource.Resource {
+	return &csrResource{}
+}
+
+type csrResourceModel struct {
+	PrivateKeyPEM      types.String `tfsdk:"private_key_pem"`
+	CommonName         types.String `tfsdk:"common_name"`
+	Organization       types.String `tfsdk:"organization"`
+	OrganizationalUnit types.String `tfsdk:"organizational_unit"`
+	Country            types.String `tfsdk:"country"`
+	Province           types.String `tfsdk:"province"`
+	Locality           types.String `tfsdk:"locality"`
+	DNSNames           types.List   `tfsdk:"dns_names"`
+	IPAddresses        types.List   `tfsdk:"ip_addresses"`
+	EmailAddresses     types.List   `tfsdk:"email_addresses"`
+	CSRPEM             types.String `tfsdk:"csr_pem"`
+}
+
+// csrResource generates certificate signing requests locally; it never talks
+// to certMgr. The provider is only consulted for FIPS mode.
+type csrResource struct {
+	fips bool
+}
+
+func (r *csrResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_csr"
+}
+
+func (r *csrResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replaceString := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	replaceList := []planmodifier.List{listplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Generates a certificate signing request locally from a private key, for use with the `csr_pem` argument of certmgr_certificate. " +
+			"Changing any argument generates a new request.",
+		Attributes: map[string]schema.Attribute{
+			"private_key_pem": schema.StringAttribute{
+				Description:   "Private key signing the request, for example `certmgr_private_key.example.private_key_pem`.",
+				Required:      true,
+				Sensitive:     true,
+				PlanModifiers: replaceString,
+			},
+			"common_name": schema.StringAttribute{
+				Description:   "Subject common name.",
+				Required:      true,
+				PlanModifiers: replaceString,
+			},
+			"organization": schema.StringAttribute{
+				Description:   "Subject organization.",
+				Optional:      true,
+				PlanModifiers: replaceString,
+			},
+			"organizational_unit": schema.StringAttribute{
+				Description:   "Subject organizational unit.",
+				Optional:      true,
+				PlanModifiers: replaceString,
+			},
+			"country": schema.StringAttribute{
+				Description:   "Subject country code.",
+				Optional:      true,
+				PlanModifiers: replaceString,
+			},
+			"province": schema.StringAttribute{
+				Description:   "Subject state or province.",
+				Optional:      true,
+				PlanModifiers: replaceString,
+			},
+			"locality": schema.StringAttribute{
+				Description:   "Subject locality.",
+				Optional:      true,
+				PlanModifiers: replaceString,
+			},
+			"dns_names": schema.ListAttribute{
+				Description:   "DNS subject alternative names.",
+				ElementType:   types.StringType,
+				Optional:      true,
+				PlanModifiers: replaceList,
+			},
+			"ip_addresses": schema.ListAttribute{
+				Description:   "IP address subject alternative names.",
+				ElementType:   types.StringType,
+				Optional:      true,
+				PlanModifiers: replaceList,
+			},
+			"email_addresses": schema.ListAttribute{
+				Description:   "E-mail subject alternative names.",
+				ElementType:   types.StringType,
+				Optional:      true,
+				PlanModifiers: replaceList,
+			},
+			"csr_pem": schema.StringAttribute{
+				Description: "Certificate signing request in PEM format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *csrResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.fips = client.FIPSMode()
+}
+
+func (r *csrResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan csrResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := pki.ParsePrivateKeyPEM(plan.PrivateKeyPEM.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing private key",
+			"Could not parse private_key_pem: "+err.Error(),
+		)
+		return
+	}
+	if r.fips {
+		if err := pki.CheckFIPSKey(key.Public()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("private_key_pem"),
+				"Private Key Not Allowed in FIPS Mode",
+				"The provider is configured with fips_mode: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	subject := pki.CSRSubject{
+		CommonName:         plan.CommonName.ValueString(),
+		Organization:       plan.Organization.ValueString(),
+		OrganizationalUnit: plan.OrganizationalUnit.ValueString(),
+		Country:            plan.Country.ValueString(),
+		Province:           plan.Province.ValueString(),
+		Locality:           plan.Locality.ValueString(),
+	}
+	resp.Diagnostics.Append(plan.DNSNames.ElementsAs(ctx, &subject.DNSNames, false)...)
+	resp.Diagnostics.Append(plan.IPAddresses.ElementsAs(ctx, &subject.IPAddresses, false)...)
+	resp.Diagnostics.Append(plan.EmailAddresses.ElementsAs(ctx, &subject.EmailAddresses, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	csr, err := pki.CreateCSR(key, subject)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating certificate request",
+			"Could not create certificate signing request: "+err.Error(),
+		)
+		return
+	}
+
+	plan.CSRPEM = types.StringValue(csr)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state as is; the request only exists in state.
+func (r *csrResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+func (r *csrResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan csrResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *csrResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
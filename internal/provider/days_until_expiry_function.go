@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &daysUntilExpiryFunction{}
+
+func NewDaysUntilExpiryFunction() function.Function {
+	return &daysUntilExpiryFunction{}
+}
+
+type daysUntilExpiryFunction struct{}
+
+func (f *daysUntilExpiryFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "days_until_expiry"
+}
+
+func (f *daysUntilExpiryFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Count the days until a certificate expires",
+		Description: "Returns the number of whole days until the given certificate or end timestamp, rounded down. " +
+			"The result is negative once the certificate has expired. Like `timestamp()`, the result depends on " +
+			"the time the function is evaluated.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "value",
+				Description: "PEM-encoded certificate, or its end of validity as an RFC 3339 timestamp such as " +
+					"the `end` attribute of the `certmgr_certificate` data source.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *daysUntilExpiryFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	end, err := expiryTime(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, daysUntil(end, time.Now()))
+}
+
+// expiryTime returns the end of validity described by value, which is either
+// a PEM-encoded certificate or a timestamp.
+func expiryTime(value string) (time.Time, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		cert, err := pki.ParseCertificatePEM(value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return cert.NotAfter, nil
+	}
+	return certMgr.ParseTimestamp(strings.TrimSpace(value))
+}
+
+// daysUntil returns the whole days from now until end, rounded down.
+func daysUntil(end, now time.Time) int64 {
+	return int64(math.Floor(end.Sub(now).Hours() / 24))
+}
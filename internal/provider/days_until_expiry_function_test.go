@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaysUntil(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, int64(30), daysUntil(now.Add(30*24*time.Hour+time.Minute), now))
+	require.Equal(t, int64(0), daysUntil(now.Add(23*time.Hour), now))
+	require.Equal(t, int64(-1), daysUntil(now.Add(-time.Hour), now))
+}
+
+func TestExpiryTimeFromTimestamp(t *testing.T) {
+	end, err := expiryTime("2025-07-01T00:00:00Z")
+	require.NoError(t, err)
+	require.True(t, end.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = expiryTime("next tuesday")
+	require.Error(t, err)
+}
@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                = &dnsAliasResource{}
+	_ resource.ResourceWithConfigure   = &dnsAliasResource{}
+	_ resource.ResourceWithImportState = &dnsAliasResource{}
+)
+
+func NewDNSAliasResource() resource.Resource {
+	return &dnsAliasResource{}
+}
+
+type dnsAliasResourceModel struct {
+	ID           types.Int64   `tfsdk:"id"`
+	Hostname     hostnameValue `tfsdk:"hostname"`
+	Alias        hostnameValue `tfsdk:"alias"`
+	Organization types.String  `tfsdk:"organization"`
+}
+
+type dnsAliasResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *dnsAliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_alias"
+}
+
+func (r *dnsAliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Registers an additional DNS alias for a host. Aliases are included as subject alternative names " +
+			"in certificates issued for the host afterwards.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the alias.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Registered host the alias belongs to. Changing this forces a new alias.",
+				Required:    true,
+				CustomType:  hostnameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						hostnameChanged,
+						"Changing the hostname forces a new alias.",
+						"Changing the hostname forces a new alias.",
+					),
+				},
+			},
+			"alias": schema.StringAttribute{
+				Description: "Additional DNS name of the host. Changing this forces a new alias.",
+				Required:    true,
+				CustomType:  hostnameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						hostnameChanged,
+						"Changing the alias forces a new alias.",
+						"Changing the alias forces a new alias.",
+					),
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the alias is managed in, overriding the provider's organization. " +
+					"Changing this forces a new alias.",
+			),
+		},
+	}
+}
+
+func (r *dnsAliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan dnsAliasResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	alias, err := r.client.CreateDNSAlias(ctx, certMgr.DNSAlias{
+		Hostname: plan.Hostname.ValueString(),
+		Alias:    plan.Alias.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating DNS alias",
+			"Could not register DNS alias: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(alias.ID))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsAliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state dnsAliasResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	alias, err := r.client.GetDNSAlias(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoDNSAlias) {
+			resp.Diagnostics.AddWarning(
+				"DNS Alias Not Found",
+				fmt.Sprintf("No DNS alias found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading DNS Alias",
+			fmt.Sprintf("Could not read DNS alias %d: %s", id, err),
+		)
+		return
+	}
+
+	state.Hostname = newHostnameValue(alias.Hostname)
+	state.Alias = newHostnameValue(alias.Alias)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsAliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dnsAliasResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsAliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state dnsAliasResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	if err := r.client.DeleteDNSAlias(ctx, int(id)); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting DNS alias",
+			fmt.Sprintf("Could not delete DNS alias %s: %s", state.Alias.ValueString(), err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *dnsAliasResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *dnsAliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportID(req.ID, "DNS alias")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Could not import %s: %s.", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDNSAliasReplace checks that only a change to the normalized alias
+// forces a new alias, not a change of case or a trailing dot.
+func TestDNSAliasReplace(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&dnsAliasResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	modifier := schemaResp.Schema.Attributes["alias"].(schema.StringAttribute).PlanModifiers[0]
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, dnsAliasResourceModel{
+		ID:           types.Int64Value(17),
+		Hostname:     newHostnameValue("tf-test.cern.ch"),
+		Alias:        newHostnameValue("www.tf-test.cern.ch"),
+		Organization: types.StringNull(),
+	}).HasError())
+
+	tests := []struct {
+		alias hostnameValue
+		want  bool
+	}{
+		{alias: newHostnameValue("www.tf-test.cern.ch"), want: false},
+		{alias: newHostnameValue("WWW.tf-test.cern.ch."), want: false},
+		{alias: newHostnameValue("web.tf-test.cern.ch"), want: true},
+		{alias: hostnameValue{StringValue: types.StringUnknown()}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alias.String(), func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, dnsAliasResourceModel{
+				ID:           types.Int64Value(17),
+				Hostname:     newHostnameValue("tf-test.cern.ch"),
+				Alias:        tt.alias,
+				Organization: types.StringNull(),
+			}).HasError())
+
+			resp := planmodifier.StringResponse{PlanValue: tt.alias.StringValue}
+			modifier.PlanModifyString(ctx, planmodifier.StringRequest{
+				Path:       path.Root("alias"),
+				State:      state,
+				Plan:       plan,
+				StateValue: types.StringValue("www.tf-test.cern.ch"),
+				PlanValue:  tt.alias.StringValue,
+			}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			require.Equal(t, tt.want, resp.RequiresReplace)
+		})
+	}
+}
+
+func TestDNSAliasRemoved(t *testing.T) {
+	ctx := context.Background()
+	r := &dnsAliasResource{client: &clientmock.Client{
+		GetDNSAliasFunc: func(context.Context, int) (*certMgr.DNSAlias, error) {
+			return nil, certMgr.ErrNoDNSAlias
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, dnsAliasResourceModel{
+		ID:           types.Int64Value(17),
+		Hostname:     newHostnameValue("tf-test.cern.ch"),
+		Alias:        newHostnameValue("www.tf-test.cern.ch"),
+		Organization: types.StringNull(),
+	}).HasError())
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	require.Equal(t, "DNS Alias Not Found", resp.Diagnostics.Warnings()[0].Summary())
+	require.True(t, resp.State.Raw.IsNull())
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &fingerprintFunction{}
+
+func NewFingerprintFunction() function.Function {
+	return &fingerprintFunction{}
+}
+
+type fingerprintFunction struct{}
+
+func (f *fingerprintFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "fingerprint"
+}
+
+func (f *fingerprintFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute the fingerprint of a PEM-encoded certificate",
+		Description: "Returns the lower-case hex digest of the DER encoding of the first certificate in a PEM string, " +
+			"as used for certificate pinning.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "PEM-encoded certificate.",
+			},
+			function.StringParameter{
+				Name:        "algorithm",
+				Description: "Digest algorithm: one of `" + strings.Join(pki.FingerprintAlgorithms, "`, `") + "`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *fingerprintFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data, algorithm string
+	resp.Error = req.Arguments.Get(ctx, &data, &algorithm)
+	if resp.Error != nil {
+		return
+	}
+
+	cert, err := pki.ParseCertificatePEM(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	fingerprint, err := pki.Fingerprint(cert, algorithm)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, fingerprint)
+}
@@ -0,0 +1,469 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/gcp"
+)
+
+var (
+	_ resource.Resource                   = &gcpExportResource{}
+	_ resource.ResourceWithConfigure      = &gcpExportResource{}
+	_ resource.ResourceWithValidateConfig = &gcpExportResource{}
+	_ resource.ResourceWithModifyPlan     = &gcpExportResource{}
+)
+
+func NewGCPExportResource() resource.Resource {
+	return &gcpExportResource{}
+}
+
+// Values of the service attribute of certmgr_gcp_export.
+const (
+	gcpCertificateManager = "certificate_manager"
+	gcpCompute            = "compute"
+)
+
+var gcpServices = []string{gcpCertificateManager, gcpCompute}
+
+type gcpExportResourceModel struct {
+	CertificateID   types.Int64  `tfsdk:"certificate_id"`
+	Project         types.String `tfsdk:"project"`
+	Location        types.String `tfsdk:"location"`
+	Name            types.String `tfsdk:"name"`
+	Service         types.String `tfsdk:"service"`
+	Description     types.String `tfsdk:"description"`
+	AccessToken     types.String `tfsdk:"access_token"`
+	PrivateKeyPEMWO types.String `tfsdk:"private_key_pem_wo"`
+	Serial          types.String `tfsdk:"serial"`
+	GCPID           types.String `tfsdk:"gcp_id"`
+	Organization    types.String `tfsdk:"organization"`
+}
+
+type gcpExportResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *gcpExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gcp_export"
+}
+
+func (r *gcpExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads an issued certificate, its chain and private key to Google Cloud, as a self-managed certificate of " +
+			"Certificate Manager or as a Compute Engine SSL certificate, for use by Google Cloud load balancers. " +
+			"Certificate Manager certificates are updated in place when certMgr reissues the certificate. Compute Engine SSL " +
+			"certificates are immutable, so they are named `<name>-<serial prefix>` and the resource is replaced instead; " +
+			"use `create_before_destroy` so load balancers are switched over before the old certificate is deleted.",
+		Attributes: map[string]schema.Attribute{
+			"certificate_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate to upload.",
+				Required:    true,
+			},
+			"project": schema.StringAttribute{
+				Description: "Google Cloud project to upload the certificate to. Changing this forces a new upload.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "Location of the certificate, `global` or a region such as `europe-west1` for regional load balancers. " +
+					"Defaults to `global`. Changing this forces a new upload.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("global"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the certificate in Google Cloud. Changing this forces a new upload.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Description: "API to upload the certificate to, one of: " + strings.Join(gcpServices, ", ") +
+					". Defaults to `" + gcpCertificateManager + "`. Changing this forces a new upload.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(gcpCertificateManager),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the certificate in Google Cloud.",
+				Optional:    true,
+			},
+			"access_token": schema.StringAttribute{
+				Description: "OAuth 2.0 access token allowed to manage certificates in the project. " +
+					"May also be provided via GOOGLE_OAUTH_ACCESS_TOKEN environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"private_key_pem_wo": schema.StringAttribute{
+				Description: "PEM encoded private key to upload, for certificates requested with a CSR whose key certMgr does not hold. " +
+					"Never stored in state or plan.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the uploaded certificate.",
+				Computed:    true,
+			},
+			"gcp_id": schema.StringAttribute{
+				Description: "Identifier of the uploaded certificate to reference from the google provider: the resource name " +
+					"`projects/<project>/locations/<location>/certificates/<name>` for Certificate Manager, the self link for Compute Engine.",
+				Computed: true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is read from, overriding the provider's organization. " +
+					"Changing this forces a new export.",
+			),
+		},
+	}
+}
+
+func (r *gcpExportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config gcpExportResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Service.IsNull() && !config.Service.IsUnknown() && !slices.Contains(gcpServices, config.Service.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("service"),
+			"Invalid Service",
+			fmt.Sprintf("service must be one of: %s. Got: %q", strings.Join(gcpServices, ", "), config.Service.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan plans a new upload when certMgr reissued the certificate since
+// the last one. Compute Engine SSL certificates cannot be changed, so they
+// are replaced instead.
+func (r *gcpExportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state gcpExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	compute := plan.Service.ValueString() == gcpCompute
+	if compute && !plan.Description.Equal(state.Description) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("description"))
+	}
+
+	if r.client == nil || plan.CertificateID.IsUnknown() {
+		return
+	}
+	certificate, err := r.client.GetCertificateByID(ctx, int(plan.CertificateID.ValueInt64()))
+	if err != nil {
+		// Read reports the certificate as gone; the plan proceeds on state.
+		return
+	}
+	if certificateSerial(certificate.CertificatePEM) == state.Serial.ValueString() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("serial"), types.StringUnknown())...)
+	if compute {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("gcp_id"), types.StringUnknown())...)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("serial"))
+	}
+}
+
+func (r *gcpExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config gcpExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// export uploads the certificate to Google Cloud, replacing the Certificate
+// Manager certificate in place when update is set, and records its serial
+// and identifier in model.
+func (r *gcpExportResource) export(ctx context.Context, model *gcpExportResourceModel, config gcpExportResourceModel, update bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client, err := model.gcpClient(r.client.ExternalClient())
+	if err != nil {
+		diags.AddError("Invalid Google Cloud Configuration", err.Error())
+		return diags
+	}
+
+	id := int(model.CertificateID.ValueInt64())
+	certificate, err := r.client.GetCertificateByID(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not read certificate %d: %s", id, err),
+		)
+		return diags
+	}
+	if certificate.CertificatePEM == "" {
+		diags.AddAttributeError(
+			path.Root("certificate_id"),
+			"Certificate Not Issued",
+			fmt.Sprintf("Certificate %d for %s has not been issued yet, so there is nothing to upload.", id, certificate.Hostname),
+		)
+		return diags
+	}
+
+	leaf, chain, err := leafAndChain(certificate.CertificatePEM)
+	if err != nil {
+		diags.AddError("Invalid Certificate", fmt.Sprintf("Could not split the chain of certificate %d: %s", id, err))
+		return diags
+	}
+
+	upload := gcp.Certificate{
+		CertificatePEM: leaf + chain,
+		PrivateKeyPEM:  certificate.PrivateKeyPEM,
+		Description:    model.Description.ValueString(),
+	}
+	if !config.PrivateKeyPEMWO.IsNull() {
+		upload.PrivateKeyPEM = config.PrivateKeyPEMWO.ValueString()
+	}
+	if upload.PrivateKeyPEM == "" {
+		diags.AddAttributeError(
+			path.Root("private_key_pem_wo"),
+			"Private Key Unavailable",
+			fmt.Sprintf("certMgr does not hold the private key of certificate %d; set private_key_pem_wo to upload it.", id),
+		)
+		return diags
+	}
+
+	serial := certificateSerial(leaf)
+	project, location := model.Project.ValueString(), model.Location.ValueString()
+	name := model.gcpName(serial)
+	switch {
+	case model.Service.ValueString() == gcpCompute:
+		err = client.CreateSSLCertificate(ctx, project, location, name, upload)
+	case update:
+		err = client.UpdateManagedCertificate(ctx, project, location, name, upload)
+	default:
+		err = client.CreateManagedCertificate(ctx, project, location, name, upload)
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Uploading Certificate to Google Cloud",
+			fmt.Sprintf("Could not upload certificate %d as %s in project %s: %s", id, name, project, err),
+		)
+		return diags
+	}
+
+	model.Serial = types.StringValue(serial)
+	model.GCPID = types.StringValue(model.gcpID(client, serial))
+	return diags
+}
+
+func (m gcpExportResourceModel) gcpClient(httpClient *http.Client) (*gcp.Client, error) {
+	client, err := gcp.NewClient(stringOrEnv(m.AccessToken, "GOOGLE_OAUTH_ACCESS_TOKEN"))
+	if err != nil {
+		return nil, err
+	}
+	client.HTTPClient = httpClient
+	return client, nil
+}
+
+// gcpName returns the name of the certificate in Google Cloud. Compute
+// Engine SSL certificates are immutable, so the serial distinguishes the
+// certificate of a reissue from the one it replaces.
+func (m gcpExportResourceModel) gcpName(serial string) string {
+	if m.Service.ValueString() != gcpCompute {
+		return m.Name.ValueString()
+	}
+	if len(serial) > 8 {
+		serial = serial[:8]
+	}
+	return m.Name.ValueString() + "-" + strings.ToLower(serial)
+}
+
+// gcpID returns the identifier of the certificate uploaded for serial.
+func (m gcpExportResourceModel) gcpID(client *gcp.Client, serial string) string {
+	project, location, name := m.Project.ValueString(), m.Location.ValueString(), m.gcpName(serial)
+	if m.Service.ValueString() != gcpCompute {
+		return gcp.ManagedCertificateName(project, location, name)
+	}
+	if location == "global" {
+		return fmt.Sprintf("%s/projects/%s/global/sslCertificates/%s", client.ComputeURL, project, name)
+	}
+	return fmt.Sprintf("%s/projects/%s/regions/%s/sslCertificates/%s", client.ComputeURL, project, location, name)
+}
+
+// Read removes the upload from state when the certificate was deleted in
+// Google Cloud, and records the serial of the certificate found there.
+func (r *gcpExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state gcpExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	client, err := state.gcpClient(r.client.ExternalClient())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Google Cloud Configuration", err.Error())
+		return
+	}
+
+	project, location := state.Project.ValueString(), state.Location.ValueString()
+	name := state.gcpName(state.Serial.ValueString())
+	var uploaded string
+	if state.Service.ValueString() == gcpCompute {
+		var certificate *gcp.SSLCertificate
+		if certificate, err = client.GetSSLCertificate(ctx, project, location, name); err == nil {
+			uploaded = certificate.CertificatePEM
+		}
+	} else {
+		var certificate *gcp.ManagedCertificate
+		if certificate, err = client.GetManagedCertificate(ctx, project, location, name); err == nil {
+			uploaded = certificate.CertificatePEM
+		}
+	}
+	if err != nil {
+		if errors.Is(err, gcp.ErrNotFound) {
+			resp.Diagnostics.AddWarning(
+				"Google Cloud Certificate Not Found",
+				fmt.Sprintf("The certificate %s no longer exists in project %s; removing resource from state.", name, project),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Google Cloud Certificate",
+			fmt.Sprintf("Could not read certificate %s in project %s: %s", name, project, err),
+		)
+		return
+	}
+
+	// Compute Engine SSL certificates are looked up by the serial in their
+	// name, so only Certificate Manager ones can drift.
+	if state.Service.ValueString() != gcpCompute {
+		state.Serial = types.StringValue(certificateSerial(uploaded))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *gcpExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config gcpExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	// Compute Engine SSL certificates are replaced rather than updated, so
+	// only the attributes kept in state change for them.
+	if plan.Service.ValueString() == gcpCompute {
+		diags := resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *gcpExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state gcpExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	client, err := state.gcpClient(r.client.ExternalClient())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Google Cloud Configuration", err.Error())
+		return
+	}
+
+	project, location := state.Project.ValueString(), state.Location.ValueString()
+	name := state.gcpName(state.Serial.ValueString())
+	if state.Service.ValueString() == gcpCompute {
+		err = client.DeleteSSLCertificate(ctx, project, location, name)
+	} else {
+		err = client.DeleteManagedCertificate(ctx, project, location, name)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Google Cloud Certificate",
+			fmt.Sprintf("Could not delete certificate %s in project %s: %s", name, project, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *gcpExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ datasource.DataSource              = &hostDataSource{}
+	_ datasource.DataSourceWithConfigure = &hostDataSource{}
+)
+
+func NewHostDataSource() datasource.DataSource {
+	return &hostDataSource{}
+}
+
+type hostDataSourceModel struct {
+	Hostname         hostnameValue `tfsdk:"hostname"`
+	ID               types.Int64   `tfsdk:"id"`
+	Owner            types.String  `tfsdk:"owner"`
+	ResponsibleGroup types.String  `tfsdk:"responsible_egroup"`
+	CertificateIDs   types.List    `tfsdk:"certificate_ids"`
+}
+
+type hostDataSource struct {
+	client certMgr.ClientAPI
+}
+
+func (d *hostDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host"
+}
+
+func (d *hostDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up the registration of a host, for example to check ownership before issuing certificates.",
+		Attributes: map[string]schema.Attribute{
+			"hostname": schema.StringAttribute{
+				Description: "Hostname to look up.",
+				Required:    true,
+				CustomType:  hostnameType{},
+			},
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the host registration.",
+				Computed:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "Account owning the host.",
+				Computed:    true,
+			},
+			"responsible_egroup": schema.StringAttribute{
+				Description: "E-group responsible for the host.",
+				Computed:    true,
+			},
+			"certificate_ids": schema.ListAttribute{
+				Description: "Identifiers of the staged certificate entries of the host, oldest first.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *hostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var config hostDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostname := config.Hostname.ValueString()
+	host, err := d.client.GetHost(ctx, hostname)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Host",
+			fmt.Sprintf("Could not read host %s: %s", hostname, err),
+		)
+		return
+	}
+
+	certs, err := d.client.ListStaged(ctx, hostname)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Host",
+			fmt.Sprintf("Could not list certificates of host %s: %s", hostname, err),
+		)
+		return
+	}
+
+	ids := make([]int, 0, len(certs))
+	for _, cert := range certs {
+		ids = append(ids, cert.ID)
+	}
+
+	config.ID = types.Int64Value(int64(host.ID))
+	config.Owner = types.StringValue(host.Owner)
+	config.ResponsibleGroup = types.StringValue(host.ResponsibleGroup)
+	config.CertificateIDs, diags = types.ListValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *hostDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
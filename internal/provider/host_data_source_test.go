@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostDataSourceCertificateIDs(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		staged []certMgr.Certificate
+		want   []int64
+	}{
+		{name: "staged", staged: []certMgr.Certificate{{ID: 4}, {ID: 7}}, want: []int64{4, 7}},
+		{name: "none", want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &hostDataSource{client: &clientmock.Client{
+				GetHostFunc: func(_ context.Context, hostname string) (*certMgr.Host, error) {
+					require.Equal(t, "tf-test.cern.ch", hostname)
+					return &certMgr.Host{ID: 23, Hostname: hostname, Owner: "jdoe"}, nil
+				},
+				ListStagedFunc: func(_ context.Context, hostname string) ([]certMgr.Certificate, error) {
+					require.Equal(t, "tf-test.cern.ch", hostname)
+					return tt.staged, nil
+				},
+			}}
+			var schemaResp datasource.SchemaResponse
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, hostDataSourceModel{
+				Hostname:         newHostnameValue("tf-test.cern.ch"),
+				ID:               types.Int64Null(),
+				Owner:            types.StringNull(),
+				ResponsibleGroup: types.StringNull(),
+				CertificateIDs:   types.ListNull(types.Int64Type),
+			}).HasError())
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var state hostDataSourceModel
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+			require.Equal(t, int64(23), state.ID.ValueInt64())
+			require.Equal(t, "jdoe", state.Owner.ValueString())
+			require.Equal(t, "", state.ResponsibleGroup.ValueString())
+			require.False(t, state.CertificateIDs.IsNull())
+
+			var ids []int64
+			require.False(t, state.CertificateIDs.ElementsAs(ctx, &ids, false).HasError())
+			require.Equal(t, tt.want, ids)
+		})
+	}
+}
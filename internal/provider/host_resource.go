@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                = &hostResource{}
+	_ resource.ResourceWithConfigure   = &hostResource{}
+	_ resource.ResourceWithImportState = &hostResource{}
+)
+
+func NewHostResource() resource.Resource {
+	return &hostResource{}
+}
+
+type hostResourceModel struct {
+	ID               types.Int64   `tfsdk:"id"`
+	Hostname         hostnameValue `tfsdk:"hostname"`
+	Owner            types.String  `tfsdk:"owner"`
+	ResponsibleGroup types.String  `tfsdk:"responsible_egroup"`
+	Organization     types.String  `tfsdk:"organization"`
+}
+
+type hostResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *hostResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host"
+}
+
+func (r *hostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Registers a host with certMgr so that certificates can be issued for it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the host registration.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname to register. Changing this forces a new registration.",
+				Required:    true,
+				CustomType:  hostnameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						hostnameChanged,
+						"Changing the hostname forces a new registration.",
+						"Changing the hostname forces a new registration.",
+					),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "Account owning the host.",
+				Required:    true,
+			},
+			"responsible_egroup": schema.StringAttribute{
+				Description: "E-group responsible for the host.",
+				Optional:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the host is managed in, overriding the provider's organization. " +
+					"Changing this forces a new host.",
+			),
+		},
+	}
+}
+
+func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan hostResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	host, err := r.client.CreateHost(ctx, plan.toHost())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error registering host",
+			"Could not register host: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(host.ID))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state hostResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	hostname := state.Hostname.ValueString()
+	host, err := r.client.GetHost(ctx, hostname)
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoHost) {
+			resp.Diagnostics.AddWarning(
+				"Host Not Found",
+				fmt.Sprintf("Host %s is not registered; removing resource from state.", hostname),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Host",
+			fmt.Sprintf("Could not read host %s: %s", hostname, err),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(int64(host.ID))
+	state.Hostname = newHostnameValue(host.Hostname)
+	state.Owner = types.StringValue(host.Owner)
+	state.ResponsibleGroup = optionalString(state.ResponsibleGroup, host.ResponsibleGroup)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state hostResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	plan.ID = state.ID
+	if err := r.client.UpdateHost(ctx, plan.toHost()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating host",
+			"Could not update host: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state hostResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	if err := r.client.DeleteHost(ctx, int(state.ID.ValueInt64())); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deregistering host",
+			fmt.Sprintf("Could not deregister host %s: %s", state.Hostname.ValueString(), err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *hostResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *hostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("hostname"), req, resp)
+}
+
+func (m hostResourceModel) toHost() certMgr.Host {
+	return certMgr.Host{
+		ID:               int(m.ID.ValueInt64()),
+		Hostname:         m.Hostname.ValueString(),
+		Owner:            m.Owner.ValueString(),
+		ResponsibleGroup: m.ResponsibleGroup.ValueString(),
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHostImport checks that a host imported by hostname is filled in from
+// certMgr, and that later updates address it by the imported ID.
+func TestHostImport(t *testing.T) {
+	ctx := context.Background()
+	host := certMgr.Host{ID: 23, Hostname: "tf-test.cern.ch", Owner: "jdoe"}
+	var updated certMgr.Host
+	r := &hostResource{client: &clientmock.Client{
+		GetHostFunc: func(_ context.Context, hostname string) (*certMgr.Host, error) {
+			require.Equal(t, "TF-Test.cern.ch", hostname)
+			return &host, nil
+		},
+		UpdateHostFunc: func(_ context.Context, host certMgr.Host) error {
+			updated = host
+			return nil
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	importResp := resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "TF-Test.cern.ch"}, &importResp)
+	require.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	// A responsible e-group certMgr does not report stays unset.
+	var state hostResourceModel
+	require.False(t, readResp.State.Get(ctx, &state).HasError())
+	require.Equal(t, hostResourceModel{
+		ID:               types.Int64Value(23),
+		Hostname:         newHostnameValue("tf-test.cern.ch"),
+		Owner:            types.StringValue("jdoe"),
+		ResponsibleGroup: types.StringNull(),
+		Organization:     types.StringNull(),
+	}, state)
+
+	planned := state
+	planned.ID = types.Int64Unknown()
+	planned.ResponsibleGroup = types.StringValue("it-dep")
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, planned).HasError())
+
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, certMgr.Host{ID: 23, Hostname: "tf-test.cern.ch", Owner: "jdoe", ResponsibleGroup: "it-dep"}, updated)
+}
+
+func TestHostDeregistered(t *testing.T) {
+	ctx := context.Background()
+	r := &hostResource{client: &clientmock.Client{
+		GetHostFunc: func(context.Context, string) (*certMgr.Host, error) {
+			return nil, certMgr.ErrNoHost
+		},
+		DeleteHostFunc: func(context.Context, int) error {
+			return certMgr.ErrNotFound
+		},
+	}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, hostResourceModel{
+		ID:               types.Int64Value(23),
+		Hostname:         newHostnameValue("tf-test.cern.ch"),
+		Owner:            types.StringValue("jdoe"),
+		ResponsibleGroup: types.StringNull(),
+		Organization:     types.StringNull(),
+	}).HasError())
+
+	readResp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.Equal(t, "Host Not Found", readResp.Diagnostics.Warnings()[0].Summary())
+	require.True(t, readResp.State.Raw.IsNull())
+
+	deleteResp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.True(t, deleteResp.State.Raw.IsNull())
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ basetypes.StringTypable                    = hostnameType{}
+	_ basetypes.StringValuableWithSemanticEquals = hostnameValue{}
+	_ xattr.ValidateableAttribute                = hostnameValue{}
+)
+
+// hostnameType is a string type whose values compare equal when they
+// normalize to the same punycode hostname.
+type hostnameType struct {
+	basetypes.StringType
+}
+
+func (t hostnameType) Equal(o attr.Type) bool {
+	other, ok := o.(hostnameType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t hostnameType) String() string {
+	return "hostnameType"
+}
+
+func (t hostnameType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return hostnameValue{StringValue: in}, nil
+}
+
+func (t hostnameType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t hostnameType) ValueType(_ context.Context) attr.Value {
+	return hostnameValue{}
+}
+
+type hostnameValue struct {
+	basetypes.StringValue
+}
+
+func newHostnameValue(hostname string) hostnameValue {
+	return hostnameValue{StringValue: basetypes.NewStringValue(hostname)}
+}
+
+func (v hostnameValue) Equal(o attr.Value) bool {
+	other, ok := o.(hostnameValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v hostnameValue) Type(_ context.Context) attr.Type {
+	return hostnameType{}
+}
+
+func (v hostnameValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(hostnameValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T", v, newValuable),
+		)
+		return false, diags
+	}
+
+	prior, err := certMgr.NormalizeHostname(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	next, err := certMgr.NormalizeHostname(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	return prior == next, diags
+}
+
+func (v hostnameValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := certMgr.NormalizeHostname(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Hostname",
+			err.Error(),
+		)
+	}
+}
+
+// hostnameChanged requires replacement only when the normalized hostname
+// differs, so switching between the Unicode and punycode spelling of the
+// same host is an in-place no-op. Hostnames unknown until apply may change,
+// so they require replacement.
+func hostnameChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+	prior, err := certMgr.NormalizeHostname(req.StateValue.ValueString())
+	if err != nil {
+		resp.RequiresReplace = true
+		return
+	}
+	next, err := certMgr.NormalizeHostname(req.PlanValue.ValueString())
+	if err != nil {
+		resp.RequiresReplace = true
+		return
+	}
+	resp.RequiresReplace = prior != next
+}
+
+// hostnameSetValue builds a set of hostname values, for attributes declared
+// with hostnameType elements.
+func hostnameSetValue(_ context.Context, hostnames []string) (types.Set, diag.Diagnostics) {
+	elements := make([]attr.Value, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		elements = append(elements, newHostnameValue(hostname))
+	}
+	return types.SetValue(hostnameType{}, elements)
+}
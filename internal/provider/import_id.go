@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	certMgr "certMgr/internal/client"
+)
+
+// parseImportID parses the numeric ID of a certMgr object given to terraform
+// import. kind names the object in the error, which shows the accepted
+// format.
+func parseImportID(raw, kind string) (int64, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return 0, fmt.Errorf("expected the numeric %s ID, for example 42, got an empty import ID", kind)
+	}
+
+	id, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("expected the numeric %s ID, for example 42, got: %q", kind, raw)
+	}
+	return id, nil
+}
+
+// parseIDOrHostname parses an import ID that is either the numeric ID of a
+// staged entry or a hostname, whose latest staged entry is adopted. Exactly
+// one of the results is set.
+func parseIDOrHostname(raw, kind string) (int64, string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return 0, "", fmt.Errorf("expected the numeric %s ID, for example 42, or a hostname, for example host.cern.ch, got an empty import ID", kind)
+	}
+
+	if id, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+		if id <= 0 {
+			return 0, "", fmt.Errorf("expected a positive %s ID, got: %q", kind, raw)
+		}
+		return id, "", nil
+	}
+
+	hostname, err := certMgr.NormalizeHostname(trimmed)
+	if err != nil || strings.ContainsAny(trimmed, "/ ") {
+		return 0, "", fmt.Errorf("expected the numeric %s ID, for example 42, or a hostname, for example host.cern.ch, got: %q", kind, raw)
+	}
+	return 0, hostname, nil
+}
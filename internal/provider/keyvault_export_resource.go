@@ -0,0 +1,425 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/keyvault"
+	"certMgr/internal/pki"
+)
+
+var (
+	_ resource.Resource               = &keyVaultExportResource{}
+	_ resource.ResourceWithConfigure  = &keyVaultExportResource{}
+	_ resource.ResourceWithModifyPlan = &keyVaultExportResource{}
+)
+
+func NewKeyVaultExportResource() resource.Resource {
+	return &keyVaultExportResource{}
+}
+
+// Tags the export sets on the Key Vault certificate for traceability.
+const (
+	keyVaultSerialTag        = "certmgr_serial"
+	keyVaultCertificateIDTag = "certmgr_certificate_id"
+	keyVaultHostnameTag      = "certmgr_hostname"
+)
+
+type keyVaultExportResourceModel struct {
+	CertificateID   types.Int64  `tfsdk:"certificate_id"`
+	VaultURL        types.String `tfsdk:"vault_url"`
+	Name            types.String `tfsdk:"name"`
+	Tags            types.Map    `tfsdk:"tags"`
+	TenantID        types.String `tfsdk:"tenant_id"`
+	ClientID        types.String `tfsdk:"client_id"`
+	ClientSecret    types.String `tfsdk:"client_secret"`
+	AccessToken     types.String `tfsdk:"access_token"`
+	PurgeOnDestroy  types.Bool   `tfsdk:"purge_on_destroy"`
+	PrivateKeyPEMWO types.String `tfsdk:"private_key_pem_wo"`
+	Serial          types.String `tfsdk:"serial"`
+	Version         types.String `tfsdk:"version"`
+	KeyVaultID      types.String `tfsdk:"keyvault_id"`
+	Organization    types.String `tfsdk:"organization"`
+}
+
+type keyVaultExportResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *keyVaultExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keyvault_export"
+}
+
+func (r *keyVaultExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Imports an issued certificate, its chain and private key as a PKCS#12 archive into an Azure Key Vault certificate. " +
+			"The certificate is tagged with `" + keyVaultSerialTag + "`, `" + keyVaultCertificateIDTag + "` and `" + keyVaultHostnameTag +
+			"` for traceability. A new version is imported when certMgr reissues the certificate, and the certificate is deleted " +
+			"when the resource is destroyed. Authenticates with `access_token`, or with the client credentials of a service principal.",
+		Attributes: map[string]schema.Attribute{
+			"certificate_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate to export.",
+				Required:    true,
+			},
+			"vault_url": schema.StringAttribute{
+				Description: "URL of the key vault, for example `https://example.vault.azure.net`. Changing this forces a new export.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the certificate in the key vault. Changing this forces a new export.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "Additional tags of the certificate in the key vault.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "Microsoft Entra ID tenant of the service principal. May also be provided via ARM_TENANT_ID environment variable.",
+				Optional:    true,
+			},
+			"client_id": schema.StringAttribute{
+				Description: "Client ID of the service principal. May also be provided via ARM_CLIENT_ID environment variable.",
+				Optional:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "Client secret of the service principal. May also be provided via ARM_CLIENT_SECRET environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"access_token": schema.StringAttribute{
+				Description: "Access token for Key Vault, used instead of the client credentials, for example from " +
+					"`az account get-access-token --resource https://vault.azure.net`.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"purge_on_destroy": schema.BoolAttribute{
+				Description: "Purge the certificate after deleting it from a key vault with soft-delete enabled, so that its name " +
+					"can be reused right away. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"private_key_pem_wo": schema.StringAttribute{
+				Description: "PEM encoded private key to export, for certificates requested with a CSR whose key certMgr does not hold. " +
+					"Never stored in state or plan.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the exported certificate.",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the key vault certificate imported by the last export.",
+				Computed:    true,
+			},
+			"keyvault_id": schema.StringAttribute{
+				Description: "Versionless identifier of the key vault certificate, which always refers to its latest version.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is read from, overriding the provider's organization. " +
+					"Changing this forces a new export.",
+			),
+		},
+	}
+}
+
+// ModifyPlan plans a new export when certMgr reissued the certificate since
+// the last one.
+func (r *keyVaultExportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state keyVaultExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.CertificateID.IsUnknown() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	certificate, err := r.client.GetCertificateByID(ctx, int(plan.CertificateID.ValueInt64()))
+	if err != nil {
+		// Read reports the certificate as gone; the plan proceeds on state.
+		return
+	}
+	if certificateSerial(certificate.CertificatePEM) != state.Serial.ValueString() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("serial"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), types.StringUnknown())...)
+	}
+}
+
+func (r *keyVaultExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config keyVaultExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// export imports the certificate into the key vault and records its serial
+// and the imported version in model.
+func (r *keyVaultExportResource) export(ctx context.Context, model *keyVaultExportResourceModel, config keyVaultExportResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client, err := model.keyVaultClient(ctx, r.client.ExternalClient())
+	if err != nil {
+		diags.AddError("Unable to Authenticate to Azure Key Vault", err.Error())
+		return diags
+	}
+
+	id := int(model.CertificateID.ValueInt64())
+	certificate, err := r.client.GetCertificateByID(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not read certificate %d: %s", id, err),
+		)
+		return diags
+	}
+	if certificate.CertificatePEM == "" {
+		diags.AddAttributeError(
+			path.Root("certificate_id"),
+			"Certificate Not Issued",
+			fmt.Sprintf("Certificate %d for %s has not been issued yet, so there is nothing to export.", id, certificate.Hostname),
+		)
+		return diags
+	}
+
+	leaf, chain, err := leafAndChain(certificate.CertificatePEM)
+	if err != nil {
+		diags.AddError("Invalid Certificate", fmt.Sprintf("Could not split the chain of certificate %d: %s", id, err))
+		return diags
+	}
+
+	privateKey := certificate.PrivateKeyPEM
+	if !config.PrivateKeyPEMWO.IsNull() {
+		privateKey = config.PrivateKeyPEMWO.ValueString()
+	}
+	if privateKey == "" {
+		diags.AddAttributeError(
+			path.Root("private_key_pem_wo"),
+			"Private Key Unavailable",
+			fmt.Sprintf("certMgr does not hold the private key of certificate %d; set private_key_pem_wo to export it.", id),
+		)
+		return diags
+	}
+
+	// The archive never leaves the provider other than to the key vault, so
+	// it is not protected by a password of its own.
+	archive, err := pki.EncodePKCS12(leaf, chain, privateKey, "")
+	if err != nil {
+		diags.AddError("Error Encoding PKCS#12 Archive", fmt.Sprintf("Could not encode certificate %d: %s", id, err))
+		return diags
+	}
+
+	tags := map[string]string{}
+	if !model.Tags.IsNull() {
+		diags.Append(model.Tags.ElementsAs(ctx, &tags, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	serial := certificateSerial(leaf)
+	maps.Copy(tags, map[string]string{
+		keyVaultSerialTag:        serial,
+		keyVaultCertificateIDTag: strconv.Itoa(id),
+		keyVaultHostnameTag:      certificate.Hostname,
+	})
+
+	name := model.Name.ValueString()
+	imported, err := client.ImportCertificate(ctx, name, archive, "", tags)
+	if err != nil {
+		diags.AddError(
+			"Error Importing Key Vault Certificate",
+			fmt.Sprintf("Could not import certificate %d as %s into %s: %s", id, name, model.VaultURL.ValueString(), err),
+		)
+		return diags
+	}
+
+	model.Serial = types.StringValue(serial)
+	model.Version = types.StringValue(imported.Version())
+	model.KeyVaultID = types.StringValue(imported.VersionlessID())
+	return diags
+}
+
+func (m keyVaultExportResourceModel) keyVaultClient(ctx context.Context, httpClient *http.Client) (*keyvault.Client, error) {
+	token := m.AccessToken.ValueString()
+	if token == "" {
+		tenantID := stringOrEnv(m.TenantID, "ARM_TENANT_ID")
+		clientID := stringOrEnv(m.ClientID, "ARM_CLIENT_ID")
+		clientSecret := stringOrEnv(m.ClientSecret, "ARM_CLIENT_SECRET")
+		if tenantID == "" || clientID == "" || clientSecret == "" {
+			return nil, errors.New("either access_token or tenant_id, client_id and client_secret are required")
+		}
+
+		var err error
+		token, err = keyvault.ClientCredentialsToken(ctx, httpClient, keyvault.AuthorityURL, tenantID, clientID, clientSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	client, err := keyvault.NewClient(m.VaultURL.ValueString(), token)
+	if err != nil {
+		return nil, err
+	}
+	client.HTTPClient = httpClient
+	return client, nil
+}
+
+// Read removes the export from state when the certificate was deleted from
+// the key vault, and records the serial of its latest version.
+func (r *keyVaultExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state keyVaultExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	client, err := state.keyVaultClient(ctx, r.client.ExternalClient())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Authenticate to Azure Key Vault", err.Error())
+		return
+	}
+
+	name := state.Name.ValueString()
+	certificate, err := client.GetCertificate(ctx, name)
+	if err != nil {
+		if errors.Is(err, keyvault.ErrNotFound) {
+			resp.Diagnostics.AddWarning(
+				"Key Vault Certificate Not Found",
+				fmt.Sprintf("The certificate %s no longer exists in %s; removing resource from state.", name, state.VaultURL.ValueString()),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Key Vault Certificate",
+			fmt.Sprintf("Could not read certificate %s from %s: %s", name, state.VaultURL.ValueString(), err),
+		)
+		return
+	}
+
+	// A version imported outside of Terraform is replaced on the next apply.
+	serial := ""
+	if cert, err := x509.ParseCertificate(certificate.CER); err == nil {
+		serial = cert.SerialNumber.Text(16)
+	}
+	state.Serial = types.StringValue(serial)
+	state.Version = types.StringValue(certificate.Version())
+	state.KeyVaultID = types.StringValue(certificate.VersionlessID())
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *keyVaultExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config keyVaultExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *keyVaultExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state keyVaultExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	client, err := state.keyVaultClient(ctx, r.client.ExternalClient())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Authenticate to Azure Key Vault", err.Error())
+		return
+	}
+
+	name := state.Name.ValueString()
+	if err := client.DeleteCertificate(ctx, name, state.PurgeOnDestroy.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Key Vault Certificate",
+			fmt.Sprintf("Could not delete certificate %s from %s: %s", name, state.VaultURL.ValueString(), err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *keyVaultExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &kubernetesTLSSecretFunction{}
+
+func NewKubernetesTLSSecretFunction() function.Function {
+	return &kubernetesTLSSecretFunction{}
+}
+
+type kubernetesTLSSecretFunction struct{}
+
+func (f *kubernetesTLSSecretFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "kubernetes_tls_secret"
+}
+
+func (f *kubernetesTLSSecretFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the data of a kubernetes.io/tls secret",
+		Description: "Returns the `data` map of a secret of type `kubernetes.io/tls`: `tls.crt` holds the certificate " +
+			"followed by its chain, `tls.key` the private key and, when the bundle contains a chain, `ca.crt` its " +
+			"certificates. The values are not base64-encoded, as expected by the `data` of `kubernetes_secret`. " +
+			"Fails when the private key does not belong to the certificate.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "certificate_pem",
+				Description: "PEM-encoded certificate, optionally followed by its chain.",
+			},
+			function.StringParameter{
+				Name:        "private_key_pem",
+				Description: "PEM-encoded private key of the certificate.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *kubernetesTLSSecretFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certificatePEM, privateKeyPEM string
+	resp.Error = req.Arguments.Get(ctx, &certificatePEM, &privateKeyPEM)
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := kubernetesTLSSecretData(certificatePEM, privateKeyPEM)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, data)
+}
+
+// kubernetesTLSSecretData returns the data of a kubernetes.io/tls secret for
+// a certificate bundle and the private key of its leaf.
+func kubernetesTLSSecretData(certificatePEM, privateKeyPEM string) (map[string]string, error) {
+	blocks, err := pki.SplitChain(certificatePEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, errors.New("certificate_pem contains no certificate")
+	}
+
+	leaf, err := pki.ParseCertificatePEM(blocks[0])
+	if err != nil {
+		return nil, err
+	}
+	key, err := pki.ParsePrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if public, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !public.Equal(leaf.PublicKey) {
+		return nil, errors.New("private_key_pem does not match the certificate")
+	}
+
+	data := map[string]string{
+		"tls.crt": strings.Join(blocks, ""),
+		"tls.key": privateKeyPEM,
+	}
+	if len(blocks) > 1 {
+		data["ca.crt"] = strings.Join(blocks[1:], "")
+	}
+	return data, nil
+}
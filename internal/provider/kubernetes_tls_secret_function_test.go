@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"certMgr/internal/pki"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChain returns a leaf certificate for tf-test.cern.ch, the root CA
+// that signed it and the private key of the leaf, all PEM encoded.
+func newTestChain(t *testing.T) (string, string, string) {
+	t.Helper()
+
+	rootKey, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	root := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, root, root, rootKey.Public(), rootKey)
+	require.NoError(t, err)
+	root, err = x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	leafKey, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "tf-test.cern.ch"},
+		DNSNames:     []string{"tf-test.cern.ch"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leaf, root, leafKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	keyPEM, err := pki.EncodePrivateKeyPEM(leafKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER})),
+		keyPEM
+}
+
+func TestKubernetesTLSSecretData(t *testing.T) {
+	leafPEM, rootPEM, keyPEM := newTestChain(t)
+
+	// The chain is reordered leaf first.
+	data, err := kubernetesTLSSecretData(rootPEM+leafPEM, keyPEM)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"tls.crt": leafPEM + rootPEM,
+		"tls.key": keyPEM,
+		"ca.crt":  rootPEM,
+	}, data)
+
+	data, err = kubernetesTLSSecretData(leafPEM, keyPEM)
+	require.NoError(t, err)
+	require.NotContains(t, data, "ca.crt")
+
+	otherKey, err := pki.GenerateKey("ECDSA", 0, "P256")
+	require.NoError(t, err)
+	otherKeyPEM, err := pki.EncodePrivateKeyPEM(otherKey)
+	require.NoError(t, err)
+	_, err = kubernetesTLSSecretData(leafPEM, otherKeyPEM)
+	require.ErrorContains(t, err, "does not match")
+}
@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                   = &notificationResource{}
+	_ resource.ResourceWithConfigure      = &notificationResource{}
+	_ resource.ResourceWithImportState    = &notificationResource{}
+	_ resource.ResourceWithValidateConfig = &notificationResource{}
+)
+
+func NewNotificationResource() resource.Resource {
+	return &notificationResource{}
+}
+
+type notificationResourceModel struct {
+	ID            types.Int64  `tfsdk:"id"`
+	CertificateID types.Int64  `tfsdk:"certificate_id"`
+	Hostgroup     types.String `tfsdk:"hostgroup"`
+	Email         types.String `tfsdk:"email"`
+	WebhookURL    types.String `tfsdk:"webhook_url"`
+	LeadDays      types.List   `tfsdk:"lead_days"`
+	Organization  types.String `tfsdk:"organization"`
+}
+
+type notificationResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *notificationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification"
+}
+
+func (r *notificationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an expiry notification subscription for a certificate or hostgroup.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the subscription.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_id": schema.Int64Attribute{
+				Description: "Certificate to watch. Exactly one of `certificate_id` or `hostgroup` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"hostgroup": schema.StringAttribute{
+				Description: "Hostgroup whose certificates are watched. Exactly one of `certificate_id` or `hostgroup` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description: "E-mail address to notify. At least one of `email` or `webhook_url` must be set.",
+				Optional:    true,
+			},
+			"webhook_url": schema.StringAttribute{
+				Description: "Webhook URL to notify. At least one of `email` or `webhook_url` must be set.",
+				Optional:    true,
+			},
+			"lead_days": schema.ListAttribute{
+				Description: "Days before expiry at which notifications are sent. Defaults to `[30, 7, 1]`.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				Default: listdefault.StaticValue(types.ListValueMust(types.Int64Type, []attr.Value{
+					types.Int64Value(30),
+					types.Int64Value(7),
+					types.Int64Value(1),
+				})),
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the notification is managed in, overriding the provider's organization. " +
+					"Changing this forces a new notification.",
+			),
+		},
+	}
+}
+
+func (r *notificationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config notificationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.CertificateID.IsUnknown() && !config.Hostgroup.IsUnknown() &&
+		config.CertificateID.IsNull() == config.Hostgroup.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate_id"),
+			"Invalid Notification Target",
+			"Exactly one of certificate_id or hostgroup must be set.",
+		)
+	}
+
+	if config.Email.IsNull() && config.WebhookURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("email"),
+			"Missing Notification Channel",
+			"At least one of email or webhook_url must be set.",
+		)
+	}
+}
+
+func (r *notificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan notificationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	notification, diags := plan.toNotification(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateNotification(ctx, notification)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating notification",
+			"Could not create notification subscription: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(created.ID))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *notificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state notificationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	notification, err := r.client.GetNotification(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoNotification) {
+			resp.Diagnostics.AddWarning(
+				"Notification Not Found",
+				fmt.Sprintf("No notification subscription found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Notification",
+			fmt.Sprintf("Could not read notification subscription %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fromNotification(ctx, notification)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *notificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state notificationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	plan.ID = state.ID
+	notification, diags := plan.toNotification(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateNotification(ctx, notification); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating notification",
+			"Could not update notification subscription: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *notificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state notificationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	if err := r.client.DeleteNotification(ctx, int(id)); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting notification",
+			fmt.Sprintf("Could not delete notification subscription %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *notificationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *notificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportID(req.ID, "notification subscription")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Could not import %s: %s.", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (m notificationResourceModel) toNotification(ctx context.Context) (certMgr.Notification, diag.Diagnostics) {
+	notification := certMgr.Notification{
+		ID:            int(m.ID.ValueInt64()),
+		CertificateID: int(m.CertificateID.ValueInt64()),
+		Hostgroup:     m.Hostgroup.ValueString(),
+		Email:         m.Email.ValueString(),
+		WebhookURL:    m.WebhookURL.ValueString(),
+	}
+	diags := m.LeadDays.ElementsAs(ctx, &notification.LeadDays, false)
+	return notification, diags
+}
+
+func (m *notificationResourceModel) fromNotification(ctx context.Context, notification *certMgr.Notification) diag.Diagnostics {
+	m.CertificateID = optionalInt64(m.CertificateID, notification.CertificateID)
+	m.Hostgroup = optionalString(m.Hostgroup, notification.Hostgroup)
+	m.Email = optionalString(m.Email, notification.Email)
+	m.WebhookURL = optionalString(m.WebhookURL, notification.WebhookURL)
+
+	leadDays, diags := types.ListValueFrom(ctx, types.Int64Type, notification.LeadDays)
+	m.LeadDays = leadDays
+	return diags
+}
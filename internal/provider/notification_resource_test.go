@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&notificationResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	valid := notificationResourceModel{
+		CertificateID: types.Int64Value(42),
+		Hostgroup:     types.StringNull(),
+		Email:         types.StringValue("team@cern.ch"),
+		WebhookURL:    types.StringNull(),
+		LeadDays:      types.ListNull(types.Int64Type),
+	}
+
+	tests := []struct {
+		name      string
+		config    func(notificationResourceModel) notificationResourceModel
+		summaries []string
+	}{
+		{
+			name:   "certificate by e-mail",
+			config: func(m notificationResourceModel) notificationResourceModel { return m },
+		},
+		{
+			name: "hostgroup by webhook",
+			config: func(m notificationResourceModel) notificationResourceModel {
+				m.CertificateID = types.Int64Null()
+				m.Hostgroup = types.StringValue("cms/web")
+				m.Email = types.StringNull()
+				m.WebhookURL = types.StringValue("https://hooks.cern.ch/certs")
+				return m
+			},
+		},
+		{
+			name: "unknown certificate",
+			config: func(m notificationResourceModel) notificationResourceModel {
+				m.CertificateID = types.Int64Unknown()
+				return m
+			},
+		},
+		{
+			name: "certificate and hostgroup",
+			config: func(m notificationResourceModel) notificationResourceModel {
+				m.Hostgroup = types.StringValue("cms/web")
+				return m
+			},
+			summaries: []string{"Invalid Notification Target"},
+		},
+		{
+			name: "no channel or target",
+			config: func(m notificationResourceModel) notificationResourceModel {
+				m.CertificateID = types.Int64Null()
+				m.Email = types.StringNull()
+				return m
+			},
+			summaries: []string{"Invalid Notification Target", "Missing Notification Channel"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, tt.config(valid)).HasError())
+
+			var resp resource.ValidateConfigResponse
+			(&notificationResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, &resp)
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			require.Equal(t, tt.summaries, summaries)
+		})
+	}
+}
+
+// TestNotificationFromNotification checks that a hostgroup subscription keeps
+// certificate_id null and that lead days keep the order certMgr returns.
+func TestNotificationFromNotification(t *testing.T) {
+	ctx := context.Background()
+	model := notificationResourceModel{
+		ID:            types.Int64Value(11),
+		CertificateID: types.Int64Null(),
+		Hostgroup:     types.StringValue("cms/web"),
+		Email:         types.StringNull(),
+		WebhookURL:    types.StringValue("https://hooks.cern.ch/certs"),
+	}
+
+	require.False(t, model.fromNotification(ctx, &certMgr.Notification{
+		ID:         11,
+		Hostgroup:  "cms/web",
+		WebhookURL: "https://hooks.cern.ch/certs",
+		LeadDays:   []int{14, 3},
+	}).HasError())
+	require.True(t, model.CertificateID.IsNull())
+	require.True(t, model.Email.IsNull())
+	require.Equal(t, types.ListValueMust(types.Int64Type, []attr.Value{
+		types.Int64Value(14), types.Int64Value(3),
+	}), model.LeadDays)
+
+	notification, diags := model.toNotification(ctx)
+	require.False(t, diags.HasError())
+	require.Equal(t, certMgr.Notification{
+		ID:         11,
+		Hostgroup:  "cms/web",
+		WebhookURL: "https://hooks.cern.ch/certs",
+		LeadDays:   []int{14, 3},
+	}, notification)
+}
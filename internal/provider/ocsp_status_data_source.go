@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+)
+
+var (
+	_ datasource.DataSource                   = &ocspStatusDataSource{}
+	_ datasource.DataSourceWithConfigure      = &ocspStatusDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &ocspStatusDataSource{}
+)
+
+// ocspTimeout bounds the requests to OCSP responders and issuer URLs.
+const ocspTimeout = 30 * time.Second
+
+func NewOCSPStatusDataSource() datasource.DataSource {
+	return &ocspStatusDataSource{}
+}
+
+type ocspStatusDataSourceModel struct {
+	Serial         types.String `tfsdk:"serial"`
+	CertificatePEM types.String `tfsdk:"certificate_pem"`
+	IssuerPEM      types.String `tfsdk:"issuer_pem"`
+	Status         types.String `tfsdk:"status"`
+	ThisUpdate     types.String `tfsdk:"this_update"`
+	NextUpdate     types.String `tfsdk:"next_update"`
+	RevokedAt      types.String `tfsdk:"revoked_at"`
+}
+
+type ocspStatusDataSource struct {
+	client certMgr.ClientAPI
+}
+
+func (d *ocspStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ocsp_status"
+}
+
+func (d *ocspStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks the revocation status of a certificate with the OCSP responder named in the certificate. " +
+			"Suited for `check` blocks that continuously validate deployed certificates.",
+		Attributes: map[string]schema.Attribute{
+			"serial": schema.StringAttribute{
+				Description: "Serial number of a certificate known to certMgr. Exactly one of `serial` or `certificate_pem` must be set.",
+				Optional:    true,
+			},
+			"certificate_pem": schema.StringAttribute{
+				Description: "PEM-encoded certificate to check. Exactly one of `serial` or `certificate_pem` must be set.",
+				Optional:    true,
+			},
+			"issuer_pem": schema.StringAttribute{
+				Description: "PEM-encoded issuer of the certificate. Defaults to the issuer downloaded from the certificate's " +
+					"Authority Information Access URL.",
+				Optional: true,
+			},
+			"status": schema.StringAttribute{
+				Description: "OCSP status: `good`, `revoked` or `unknown`.",
+				Computed:    true,
+			},
+			"this_update": schema.StringAttribute{
+				Description: "Time at which the status was known to be correct, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"next_update": schema.StringAttribute{
+				Description: "Time by which newer status information will be available, in RFC 3339 format. Empty if the responder does not say.",
+				Computed:    true,
+			},
+			"revoked_at": schema.StringAttribute{
+				Description: "Time of revocation in RFC 3339 format. Empty unless `status` is `revoked`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ocspStatusDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config ocspStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Serial.IsUnknown() && !config.CertificatePEM.IsUnknown() &&
+		config.Serial.IsNull() == config.CertificatePEM.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("serial"),
+			"Invalid Certificate Selection",
+			"Exactly one of serial or certificate_pem must be set.",
+		)
+	}
+}
+
+func (d *ocspStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var config ocspStatusDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certificatePEM := config.CertificatePEM.ValueString()
+	if !config.Serial.IsNull() {
+		serial := config.Serial.ValueString()
+		cert, err := d.client.GetCertificateBySerial(ctx, serial)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Certificate",
+				fmt.Sprintf("Could not read certificate with serial %s: %s", serial, err),
+			)
+			return
+		}
+		certificatePEM = cert.CertificatePEM
+	}
+
+	cert, err := pki.ParseCertificatePEM(certificatePEM)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Certificate", err.Error())
+		return
+	}
+
+	httpClient := *d.client.ExternalClient()
+	httpClient.Timeout = ocspTimeout
+	var issuer *x509.Certificate
+	if !config.IssuerPEM.IsNull() {
+		issuer, err = pki.ParseCertificatePEM(config.IssuerPEM.ValueString())
+	} else {
+		issuer, err = pki.FetchIssuer(ctx, &httpClient, cert)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("issuer_pem"), "Invalid Issuer", err.Error())
+		return
+	}
+
+	status, err := pki.CheckOCSP(ctx, &httpClient, cert, issuer)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Checking OCSP Status",
+			fmt.Sprintf("Could not check OCSP status of certificate %s: %s", cert.SerialNumber.Text(16), err),
+		)
+		return
+	}
+
+	config.Status = types.StringValue(status.Status)
+	config.ThisUpdate = types.StringValue(formatTime(status.ThisUpdate))
+	config.NextUpdate = types.StringValue(formatTime(status.NextUpdate))
+	config.RevokedAt = types.StringValue(formatTime(status.RevokedAt))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *ocspStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// formatTime renders t in RFC 3339, or as an empty string for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
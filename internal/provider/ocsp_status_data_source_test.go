@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+// TestOCSPStatusBySerial checks that a certificate selected by serial is read
+// from certMgr and its issuer fetched from the Authority Information Access
+// URL before asking the responder.
+func TestOCSPStatusBySerial(t *testing.T) {
+	ctx := context.Background()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tf-test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	thisUpdate := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ca.der", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(caDER)
+	})
+	mux.HandleFunc("POST /ocsp", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		request, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+		response, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: request.SerialNumber,
+			ThisUpdate:   thisUpdate,
+			NextUpdate:   thisUpdate.Add(24 * time.Hour),
+		}, caKey)
+		require.NoError(t, err)
+		_, _ = w.Write(response)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:          big.NewInt(0x2a),
+		Subject:               pkix.Name{CommonName: "tf-test.cern.ch"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		OCSPServer:            []string{server.URL + "/ocsp"},
+		IssuingCertificateURL: []string{server.URL + "/ca.der"},
+	}, ca, leafKey.Public(), caKey)
+	require.NoError(t, err)
+
+	d := &ocspStatusDataSource{client: &clientmock.Client{
+		GetCertificateBySerialFunc: func(_ context.Context, serial string) (*certMgr.Certificate, error) {
+			require.Equal(t, "2A", serial)
+			return &certMgr.Certificate{
+				Serial:         serial,
+				CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})),
+			}, nil
+		},
+	}}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, config.Set(ctx, ocspStatusDataSourceModel{
+		Serial:         types.StringValue("2A"),
+		CertificatePEM: types.StringNull(),
+		IssuerPEM:      types.StringNull(),
+		Status:         types.StringNull(),
+		ThisUpdate:     types.StringNull(),
+		NextUpdate:     types.StringNull(),
+		RevokedAt:      types.StringNull(),
+	}).HasError())
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state ocspStatusDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Equal(t, "good", state.Status.ValueString())
+	require.Equal(t, thisUpdate.Format(time.RFC3339), state.ThisUpdate.ValueString())
+	require.Equal(t, thisUpdate.Add(24*time.Hour).Format(time.RFC3339), state.NextUpdate.ValueString())
+	require.Equal(t, "", state.RevokedAt.ValueString())
+	require.True(t, state.CertificatePEM.IsNull())
+}
+
+func TestOCSPStatusValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	(&ocspStatusDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name           string
+		serial         types.String
+		certificatePEM types.String
+		summaries      []string
+	}{
+		{name: "serial", serial: types.StringValue("2A"), certificatePEM: types.StringNull()},
+		{name: "certificate", serial: types.StringNull(), certificatePEM: types.StringValue("pem")},
+		{name: "unknown certificate", serial: types.StringNull(), certificatePEM: types.StringUnknown()},
+		{name: "neither", serial: types.StringNull(), certificatePEM: types.StringNull(), summaries: []string{"Invalid Certificate Selection"}},
+		{name: "both", serial: types.StringValue("2A"), certificatePEM: types.StringValue("pem"), summaries: []string{"Invalid Certificate Selection"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, ocspStatusDataSourceModel{
+				Serial:         tt.serial,
+				CertificatePEM: tt.certificatePEM,
+				IssuerPEM:      types.StringNull(),
+				Status:         types.StringNull(),
+				ThisUpdate:     types.StringNull(),
+				NextUpdate:     types.StringNull(),
+				RevokedAt:      types.StringNull(),
+			}).HasError())
+
+			var resp datasource.ValidateConfigResponse
+			(&ocspStatusDataSource{}).ValidateConfig(ctx, datasource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, &resp)
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			require.Equal(t, tt.summaries, summaries)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+// withOrganization returns ctx scoped to the organization of a resource,
+// overriding the provider's, when one is set.
+func withOrganization(ctx context.Context, organization types.String) context.Context {
+	if organization.ValueString() == "" {
+		return ctx
+	}
+	return certMgr.WithOrganization(ctx, organization.ValueString())
+}
+
+// organizationAttribute returns the organization attribute resources use to
+// override the provider's organization. The objects of one organization are
+// not visible from another, so changing it forces a new resource.
+func organizationAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description,
+		Optional:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
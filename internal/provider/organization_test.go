@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStagedRequestOrganization(t *testing.T) {
+	ctx := context.Background()
+	cli := fakecertmgr.NewServer(t).NewClient()
+	cli.Organization = "atlas"
+	var mu sync.Mutex
+	var organizations []string
+	cli.OnRequest(func(req *http.Request) {
+		mu.Lock()
+		organizations = append(organizations, req.Header.Get("X-CertMgr-Organization"))
+		mu.Unlock()
+	})
+
+	r := &stagedRequestResource{client: cli}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := stagedRequestResourceModel{
+		ID:           types.Int64Unknown(),
+		Hostname:     newHostnameValue("tf-test.cern.ch"),
+		Requestor:    types.StringUnknown(),
+		Start:        types.StringUnknown(),
+		End:          types.StringUnknown(),
+		Organization: types.StringValue("cms"),
+	}
+	createReq := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema}}
+	require.False(t, createReq.Plan.Set(ctx, plan).HasError())
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, createReq, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, organizations)
+	for _, organization := range organizations {
+		require.Equal(t, "cms", organization)
+	}
+}
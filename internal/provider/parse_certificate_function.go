@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &parseCertificateFunction{}
+
+func NewParseCertificateFunction() function.Function {
+	return &parseCertificateFunction{}
+}
+
+type parseCertificateFunction struct{}
+
+// parsedCertificate is the object returned by parse_certificate.
+type parsedCertificate struct {
+	Subject        string   `tfsdk:"subject"`
+	CommonName     string   `tfsdk:"common_name"`
+	Issuer         string   `tfsdk:"issuer"`
+	Serial         string   `tfsdk:"serial"`
+	DNSNames       []string `tfsdk:"dns_names"`
+	IPAddresses    []string `tfsdk:"ip_addresses"`
+	EmailAddresses []string `tfsdk:"email_addresses"`
+	NotBefore      string   `tfsdk:"not_before"`
+	NotAfter       string   `tfsdk:"not_after"`
+	IsCA           bool     `tfsdk:"is_ca"`
+}
+
+var parsedCertificateAttrTypes = map[string]attr.Type{
+	"subject":         types.StringType,
+	"common_name":     types.StringType,
+	"issuer":          types.StringType,
+	"serial":          types.StringType,
+	"dns_names":       types.ListType{ElemType: types.StringType},
+	"ip_addresses":    types.ListType{ElemType: types.StringType},
+	"email_addresses": types.ListType{ElemType: types.StringType},
+	"not_before":      types.StringType,
+	"not_after":       types.StringType,
+	"is_ca":           types.BoolType,
+}
+
+func (f *parseCertificateFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_certificate"
+}
+
+func (f *parseCertificateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a PEM-encoded certificate",
+		Description: "Returns the subject, issuer, subject alternative names, serial number and validity of the first " +
+			"certificate in a PEM string. Serial numbers are lower-case hex and times are RFC 3339.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "PEM-encoded certificate.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedCertificateAttrTypes,
+		},
+	}
+}
+
+func (f *parseCertificateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	resp.Error = req.Arguments.Get(ctx, &data)
+	if resp.Error != nil {
+		return
+	}
+
+	cert, err := pki.ParseCertificatePEM(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := parsedCertificate{
+		Subject:        cert.Subject.String(),
+		CommonName:     cert.Subject.CommonName,
+		Issuer:         cert.Issuer.String(),
+		Serial:         cert.SerialNumber.Text(16),
+		DNSNames:       append([]string{}, cert.DNSNames...),
+		IPAddresses:    []string{},
+		EmailAddresses: append([]string{}, cert.EmailAddresses...),
+		NotBefore:      cert.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:       cert.NotAfter.UTC().Format(time.RFC3339),
+		IsCA:           cert.IsCA,
+	}
+	for _, ip := range cert.IPAddresses {
+		result.IPAddresses = append(result.IPAddresses, ip.String())
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
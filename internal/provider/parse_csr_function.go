@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &parseCSRFunction{}
+
+func NewParseCSRFunction() function.Function {
+	return &parseCSRFunction{}
+}
+
+type parseCSRFunction struct{}
+
+// parsedCSR is the object returned by parse_csr.
+type parsedCSR struct {
+	Subject              string   `tfsdk:"subject"`
+	CommonName           string   `tfsdk:"common_name"`
+	DNSNames             []string `tfsdk:"dns_names"`
+	IPAddresses          []string `tfsdk:"ip_addresses"`
+	EmailAddresses       []string `tfsdk:"email_addresses"`
+	KeyAlgorithm         string   `tfsdk:"key_algorithm"`
+	KeySize              int64    `tfsdk:"key_size"`
+	PublicKeyFingerprint string   `tfsdk:"public_key_fingerprint_sha256"`
+	SignatureValid       bool     `tfsdk:"signature_valid"`
+}
+
+var parsedCSRAttrTypes = map[string]attr.Type{
+	"subject":                       types.StringType,
+	"common_name":                   types.StringType,
+	"dns_names":                     types.ListType{ElemType: types.StringType},
+	"ip_addresses":                  types.ListType{ElemType: types.StringType},
+	"email_addresses":               types.ListType{ElemType: types.StringType},
+	"key_algorithm":                 types.StringType,
+	"key_size":                      types.Int64Type,
+	"public_key_fingerprint_sha256": types.StringType,
+	"signature_valid":               types.BoolType,
+}
+
+func (f *parseCSRFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_csr"
+}
+
+func (f *parseCSRFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a PEM-encoded certificate signing request",
+		Description: "Returns the subject, subject alternative names and public key details of a certificate signing " +
+			"request, so user-supplied requests can be validated before they are passed to `certmgr_certificate`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "PEM-encoded certificate signing request.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedCSRAttrTypes,
+		},
+	}
+}
+
+func (f *parseCSRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	resp.Error = req.Arguments.Get(ctx, &data)
+	if resp.Error != nil {
+		return
+	}
+
+	csr, err := pki.ParseCSRPEM(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	algorithm, size, err := pki.DescribePublicKey(csr.PublicKey)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	fingerprint, err := pki.PublicKeyFingerprintSHA256(csr.PublicKey)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := parsedCSR{
+		Subject:              csr.Subject.String(),
+		CommonName:           csr.Subject.CommonName,
+		DNSNames:             append([]string{}, csr.DNSNames...),
+		IPAddresses:          []string{},
+		EmailAddresses:       append([]string{}, csr.EmailAddresses...),
+		KeyAlgorithm:         algorithm,
+		KeySize:              int64(size),
+		PublicKeyFingerprint: fingerprint,
+		SignatureValid:       csr.CheckSignature() == nil,
+	}
+	for _, ip := range csr.IPAddresses {
+		result.IPAddresses = append(result.IPAddresses, ip.String())
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &pkcs12EncodeFunction{}
+
+func NewPKCS12EncodeFunction() function.Function {
+	return &pkcs12EncodeFunction{}
+}
+
+type pkcs12EncodeFunction struct{}
+
+func (f *pkcs12EncodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pkcs12_encode"
+}
+
+func (f *pkcs12EncodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Bundle PEM material into a PKCS#12 archive",
+		Description: "Returns a base64-encoded, password protected PKCS#12 archive holding a certificate, its chain and " +
+			"private key, for consumers such as Windows or Java that do not read PEM.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "certificate_pem",
+				Description: "PEM-encoded certificate.",
+			},
+			function.StringParameter{
+				Name:        "chain_pem",
+				Description: "PEM-encoded intermediate certificates to include. May be empty.",
+			},
+			function.StringParameter{
+				Name:        "private_key_pem",
+				Description: "PEM-encoded private key of the certificate.",
+			},
+			function.StringParameter{
+				Name:        "password",
+				Description: "Password protecting the archive.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *pkcs12EncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certificatePEM, chainPEM, privateKeyPEM, password string
+	resp.Error = req.Arguments.Get(ctx, &certificatePEM, &chainPEM, &privateKeyPEM, &password)
+	if resp.Error != nil {
+		return
+	}
+
+	archive, err := pki.EncodePKCS12(certificatePEM, chainPEM, privateKeyPEM, password)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, base64.StdEncoding.EncodeToString(archive))
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+)
+
+var (
+	_ resource.Resource                   = &privateKeyResource{}
+	_ resource.ResourceWithValidateConfig = &privateKeyResource{}
+	_ resource.ResourceWithConfigure      = &privateKeyResource{}
+)
+
+func NewPrivateKeyResource() resource.Resource {
+	return &privateKeyResource{}
+}
+
+type privateKeyResourceModel struct {
+	Algorithm       types.String `tfsdk:"algorithm"`
+	RSABits         types.Int64  `tfsdk:"rsa_bits"`
+	ECDSACurve      types.String `tfsdk:"ecdsa_curve"`
+	PrivateKeyPEM   types.String `tfsdk:"private_key_pem"`
+	PublicKeyPEM    types.String `tfsdk:"public_key_pem"`
+	PublicKeySHA256 types.String `tfsdk:"public_key_fingerprint_sha256"`
+}
+
+// privateKeyResource generates keys locally; it never talks to certMgr. The
+// provider is only consulted for FIPS mode.
+type privateKeyResource struct {
+	fips bool
+}
+
+func (r *privateKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_private_key"
+}
+
+func (r *privateKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a private key locally, independently of any certificate. " +
+			"The key is stored unencrypted in Terraform state; protect the state accordingly.",
+		Attributes: map[string]schema.Attribute{
+			"algorithm": schema.StringAttribute{
+				Description: "Key algorithm, one of: " + strings.Join(pki.KeyAlgorithms, ", ") + ". Changing this forces a new key.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rsa_bits": schema.Int64Attribute{
+				Description: "Size of RSA keys in bits. Defaults to `2048`. Changing this forces a new key.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(2048),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"ecdsa_curve": schema.StringAttribute{
+				Description: "Curve of ECDSA keys, one of: " + strings.Join(pki.ECDSACurves, ", ") + ". Defaults to `P256`. Changing this forces a new key.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("P256"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				Description: "Private key in PKCS#8 PEM format.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_pem": schema.StringAttribute{
+				Description: "Public key in PKIX PEM format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_fingerprint_sha256": schema.StringAttribute{
+				Description: "Hex SHA-256 fingerprint of the DER encoded public key.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *privateKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config privateKeyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Algorithm.IsNull() && !config.Algorithm.IsUnknown() &&
+		!slices.Contains(pki.KeyAlgorithms, config.Algorithm.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("algorithm"),
+			"Invalid Key Algorithm",
+			fmt.Sprintf("algorithm must be one of: %s. Got: %q", strings.Join(pki.KeyAlgorithms, ", "), config.Algorithm.ValueString()),
+		)
+	}
+
+	if !config.ECDSACurve.IsNull() && !config.ECDSACurve.IsUnknown() &&
+		!slices.Contains(pki.ECDSACurves, config.ECDSACurve.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ecdsa_curve"),
+			"Invalid ECDSA Curve",
+			fmt.Sprintf("ecdsa_curve must be one of: %s. Got: %q", strings.Join(pki.ECDSACurves, ", "), config.ECDSACurve.ValueString()),
+		)
+	}
+
+	if !config.RSABits.IsNull() && !config.RSABits.IsUnknown() && config.RSABits.ValueInt64() < 2048 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rsa_bits"),
+			"Invalid RSA Key Size",
+			fmt.Sprintf("rsa_bits must be at least 2048, got: %d", config.RSABits.ValueInt64()),
+		)
+	}
+}
+
+func (r *privateKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.fips = client.FIPSMode()
+}
+
+func (r *privateKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan privateKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.fips && !slices.Contains(pki.FIPSKeyAlgorithms, plan.Algorithm.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("algorithm"),
+			"Key Algorithm Not Allowed in FIPS Mode",
+			fmt.Sprintf("The provider is configured with fips_mode, which only allows: %s. Got: %q",
+				strings.Join(pki.FIPSKeyAlgorithms, ", "), plan.Algorithm.ValueString()),
+		)
+		return
+	}
+
+	key, err := pki.GenerateKey(plan.Algorithm.ValueString(), int(plan.RSABits.ValueInt64()), plan.ECDSACurve.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error generating private key",
+			"Could not generate private key: "+err.Error(),
+		)
+		return
+	}
+
+	privateKeyPEM, err := pki.EncodePrivateKeyPEM(key)
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding private key", err.Error())
+		return
+	}
+	publicKeyPEM, err := pki.EncodePublicKeyPEM(key.Public())
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding public key", err.Error())
+		return
+	}
+	fingerprint, err := pki.PublicKeyFingerprintSHA256(key.Public())
+	if err != nil {
+		resp.Diagnostics.AddError("Error fingerprinting public key", err.Error())
+		return
+	}
+
+	plan.PrivateKeyPEM = types.StringValue(privateKeyPEM)
+	plan.PublicKeyPEM = types.StringValue(publicKeyPEM)
+	plan.PublicKeySHA256 = types.StringValue(fingerprint)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state as is; the key only exists in state.
+func (r *privateKeyResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+func (r *privateKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan privateKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *privateKeyResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"certMgr/internal/pki"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// createPrivateKey runs Create of r for a key of algorithm, with rsa_bits and
+// ecdsa_curve at their defaults unless given.
+func createPrivateKey(t *testing.T, r *privateKeyResource, algorithm string, rsaBits int64, curve string) (privateKeyResourceModel, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	if rsaBits == 0 {
+		rsaBits = 2048
+	}
+	if curve == "" {
+		curve = "P256"
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, privateKeyResourceModel{
+		Algorithm:       types.StringValue(algorithm),
+		RSABits:         types.Int64Value(rsaBits),
+		ECDSACurve:      types.StringValue(curve),
+		PrivateKeyPEM:   types.StringUnknown(),
+		PublicKeyPEM:    types.StringUnknown(),
+		PublicKeySHA256: types.StringUnknown(),
+	}).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	var state privateKeyResourceModel
+	if !resp.Diagnostics.HasError() {
+		require.False(t, resp.State.Get(ctx, &state).HasError())
+	}
+	return state, resp.Diagnostics
+}
+
+func TestPrivateKeyCreate(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		rsaBits   int64
+		curve     string
+		wantBits  int
+	}{
+		{algorithm: "RSA", rsaBits: 3072, wantBits: 3072},
+		{algorithm: "ECDSA", curve: "P384", wantBits: 384},
+		{algorithm: "ED25519", wantBits: 256},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			state, diags := createPrivateKey(t, &privateKeyResource{}, tt.algorithm, tt.rsaBits, tt.curve)
+			require.False(t, diags.HasError(), diags)
+
+			key, err := pki.ParsePrivateKeyPEM(state.PrivateKeyPEM.ValueString())
+			require.NoError(t, err)
+			algorithm, bits, err := pki.DescribePublicKey(key.Public())
+			require.NoError(t, err)
+			require.Equal(t, tt.algorithm, algorithm)
+			require.Equal(t, tt.wantBits, bits)
+
+			publicKeyPEM, err := pki.EncodePublicKeyPEM(key.Public())
+			require.NoError(t, err)
+			require.Equal(t, publicKeyPEM, state.PublicKeyPEM.ValueString())
+			fingerprint, err := pki.PublicKeyFingerprintSHA256(key.Public())
+			require.NoError(t, err)
+			require.Equal(t, fingerprint, state.PublicKeySHA256.ValueString())
+		})
+	}
+}
+
+func TestPrivateKeyFIPSMode(t *testing.T) {
+	r := &privateKeyResource{fips: true}
+
+	_, diags := createPrivateKey(t, r, "ED25519", 0, "")
+	require.True(t, diags.HasError())
+	require.Equal(t, "Key Algorithm Not Allowed in FIPS Mode", diags.Errors()[0].Summary())
+
+	_, diags = createPrivateKey(t, r, "ECDSA", 0, "")
+	require.False(t, diags.HasError(), diags)
+}
+
+func TestPrivateKeyValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&privateKeyResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name      string
+		algorithm string
+		rsaBits   types.Int64
+		curve     types.String
+		summaries []string
+	}{
+		{name: "defaults", algorithm: "RSA", rsaBits: types.Int64Null(), curve: types.StringNull()},
+		{name: "algorithm", algorithm: "DSA", rsaBits: types.Int64Null(), curve: types.StringNull(), summaries: []string{"Invalid Key Algorithm"}},
+		{name: "curve", algorithm: "ECDSA", rsaBits: types.Int64Null(), curve: types.StringValue("P224"), summaries: []string{"Invalid ECDSA Curve"}},
+		{name: "rsa bits", algorithm: "RSA", rsaBits: types.Int64Value(1024), curve: types.StringNull(), summaries: []string{"Invalid RSA Key Size"}},
+		{name: "unknown rsa bits", algorithm: "RSA", rsaBits: types.Int64Unknown(), curve: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, privateKeyResourceModel{
+				Algorithm:  types.StringValue(tt.algorithm),
+				RSABits:    tt.rsaBits,
+				ECDSACurve: tt.curve,
+			}).HasError())
+
+			var resp resource.ValidateConfigResponse
+			(&privateKeyResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, &resp)
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			require.Equal(t, tt.summaries, summaries)
+		})
+	}
+}
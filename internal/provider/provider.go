@@ -7,10 +7,17 @@ package provider
 import (
 	certMgr "certMgr/internal/client"
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -20,7 +27,10 @@ import (
 )
 
 var (
-	_ provider.Provider = &certMgrProvider{}
+	_ provider.Provider                       = &certMgrProvider{}
+	_ provider.ProviderWithFunctions          = &certMgrProvider{}
+	_ provider.ProviderWithEphemeralResources = &certMgrProvider{}
+	_ provider.ProviderWithActions            = &certMgrProvider{}
 )
 
 func New(version string) func() provider.Provider {
@@ -32,8 +42,27 @@ func New(version string) func() provider.Provider {
 }
 
 type certMgrProviderModel struct {
-	Host types.String `tfsdk:"host"`
-	Port types.Number `tfsdk:"port"`
+	Host     types.String `tfsdk:"host"`
+	Port     types.Number `tfsdk:"port"`
+	Endpoint types.String `tfsdk:"endpoint"`
+
+	Organization types.String `tfsdk:"organization"`
+
+	CreateTimeoutSeconds    types.Int64  `tfsdk:"create_timeout_seconds"`
+	CreateBatchWindowMS     types.Int64  `tfsdk:"create_batch_window_ms"`
+	RetryBudgetSeconds      types.Int64  `tfsdk:"retry_budget_seconds"`
+	WriteRetryBudgetSeconds types.Int64  `tfsdk:"write_retry_budget_seconds"`
+	IdempotencyKeys         types.Bool   `tfsdk:"idempotency_keys"`
+	MaxConcurrentRequests   types.Int64  `tfsdk:"max_concurrent_requests"`
+	StrictDecoding          types.Bool   `tfsdk:"strict_decoding"`
+	OnReadError             types.String `tfsdk:"on_read_error"`
+	FIPSMode                types.Bool   `tfsdk:"fips_mode"`
+
+	AuditLogPath types.String `tfsdk:"audit_log_path"`
+
+	OIDCTokenURL     types.String `tfsdk:"oidc_token_url"`
+	OIDCClientID     types.String `tfsdk:"oidc_client_id"`
+	OIDCClientSecret types.String `tfsdk:"oidc_client_secret"`
 }
 
 type certMgrProvider struct {
@@ -49,14 +78,89 @@ func (p *certMgrProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Description: "Interact with certMgr.",
 		Attributes: map[string]schema.Attribute{
+			"fips_mode": schema.BoolAttribute{
+				Description: "Restrict TLS to FIPS approved versions, cipher suites and curves, and refuse to generate or use " +
+					"private keys of algorithms that are not FIPS approved (ED25519, RSA below 2048 bits). Defaults to false.",
+				Optional: true,
+			},
 			"host": schema.StringAttribute{
 				Description: "URI for certMgr API. May also be provided via CERTMGR_HOST environment variable.",
 				Optional:    true,
 			},
+			"oidc_client_id": schema.StringAttribute{
+				Description: "Client ID used with oidc_token_url. May also be provided via CERTMGR_OIDC_CLIENT_ID environment variable.",
+				Optional:    true,
+			},
+			"oidc_client_secret": schema.StringAttribute{
+				Description: "Client secret used with oidc_token_url. May also be provided via CERTMGR_OIDC_CLIENT_SECRET environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"oidc_token_url": schema.StringAttribute{
+				Description: "OpenID Connect token endpoint. When set, the provider authenticates with bearer tokens obtained with the " +
+					"client credentials grant instead of Kerberos, refreshing them before they expire. " +
+					"May also be provided via CERTMGR_OIDC_TOKEN_URL environment variable.",
+				Optional: true,
+			},
+			"organization": schema.StringAttribute{
+				Description: "Organization (VO) of a multi-tenant certMgr instance that requests are scoped to. Resources may override " +
+					"it with their own organization attribute. May also be provided via CERTMGR_ORGANIZATION environment variable.",
+				Optional: true,
+			},
 			"port": schema.NumberAttribute{
 				Description: "Port for certMgr API. May also be provided via CERTMGR_PORT environment variable.",
 				Optional:    true,
 			},
+			"audit_log_path": schema.StringAttribute{
+				Description: "Path of a JSON lines file to which every request modifying certMgr is appended, " +
+					"with its time, principal, organization, URL and outcome. Disabled by default.",
+				Optional: true,
+			},
+			"endpoint": schema.StringAttribute{
+				Description: "Unix domain socket of a local certMgr agent, as unix:///path/to/socket, used instead of host and port. " +
+					"May also be provided via CERTMGR_ENDPOINT environment variable.",
+				Optional: true,
+			},
+			"on_read_error": schema.StringAttribute{
+				Description: "What happens when refreshing a resource fails with a transient error, such as a timeout, " +
+					"rate limiting or a server error: \"fail\" fails the plan, \"warn\" keeps the prior state of the resource " +
+					"and reports a warning. Defaults to \"fail\".",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of requests sent to certMgr at the same time, regardless of Terraform's parallelism. " +
+					"Useful for smaller certMgr deployments. Defaults to unlimited.",
+				Optional: true,
+			},
+			"idempotency_keys": schema.BoolAttribute{
+				Description: "Send an idempotency key with every POST so that certMgr can recognize retried requests. " +
+					"Without idempotency keys, POSTs are never retried. Defaults to true.",
+				Optional: true,
+			},
+			"create_timeout_seconds": schema.Int64Attribute{
+				Description: "Maximum time in seconds to wait for a certificate request that certMgr accepted asynchronously " +
+					"to show up as a staged entry. Defaults to 300.",
+				Optional: true,
+			},
+			"create_batch_window_ms": schema.Int64Attribute{
+				Description: "Time in milliseconds certmgr_certificate creations wait for others of the same apply, which are then " +
+					"sent to certMgr in a single bulk request, up to 100 at a time. Speeds up creating many certificates at once. " +
+					"Defaults to 0, which sends a request per certificate.",
+				Optional: true,
+			},
+			"retry_budget_seconds": schema.Int64Attribute{
+				Description: "Maximum time in seconds a read waits in total on rate limited (429) or unavailable (503) " +
+					"responses, as announced by Retry-After, and on timeouts before failing. Defaults to 60; 0 disables retries.",
+				Optional: true,
+			},
+			"strict_decoding": schema.BoolAttribute{
+				Description: "Fail on certMgr responses carrying fields unknown to the provider, to detect API changes early. Defaults to false.",
+				Optional:    true,
+			},
+			"write_retry_budget_seconds": schema.Int64Attribute{
+				Description: "Like retry_budget_seconds, for requests that modify certMgr. Defaults to retry_budget_seconds.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -89,10 +193,60 @@ func (p *certMgrProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.Endpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Unknown certMgr Endpoint",
+			"The provider cannot create the certMgr API client as there is an unknown configuration value for the certMgr endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the CERTMGR_ENDPOINT environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	endpoint := os.Getenv("CERTMGR_ENDPOINT")
+	if !config.Endpoint.IsNull() {
+		endpoint = config.Endpoint.ValueString()
+	}
+
+	var socketPath string
+	if endpoint != "" {
+		var err error
+		socketPath, err = unixSocketPath(endpoint)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "Invalid certMgr Endpoint", err.Error())
+		}
+		if !config.Host.IsNull() || !config.Port.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Conflicting certMgr Endpoint",
+				"endpoint cannot be combined with host or port.",
+			)
+		}
+	}
+
+	oidcTokenURL := stringOrEnv(config.OIDCTokenURL, "CERTMGR_OIDC_TOKEN_URL")
+	oidcClientID := stringOrEnv(config.OIDCClientID, "CERTMGR_OIDC_CLIENT_ID")
+	oidcClientSecret := stringOrEnv(config.OIDCClientSecret, "CERTMGR_OIDC_CLIENT_SECRET")
+	if oidcTokenURL != "" {
+		if oidcClientID == "" || oidcClientSecret == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("oidc_token_url"),
+				"Incomplete OIDC Configuration",
+				"oidc_client_id and oidc_client_secret are required with oidc_token_url.",
+			)
+		}
+		if socketPath != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("oidc_token_url"),
+				"Conflicting certMgr Authentication",
+				"oidc_token_url cannot be combined with endpoint, as the local agent authenticates on its own.",
+			)
+		}
+	}
+
 	host := os.Getenv("CERTMGR_HOST")
 	if host == "" {
 		host = "hector.cern.ch"
@@ -115,7 +269,7 @@ func (p *certMgrProvider) Configure(ctx context.Context, req provider.ConfigureR
 		port = int(portInt64)
 	}
 
-	if host == "" {
+	if host == "" && socketPath == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing certMgr Host",
@@ -123,7 +277,56 @@ func (p *certMgrProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if port == 0 {
+	if config.CreateTimeoutSeconds.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("create_timeout_seconds"),
+			"Invalid Create Timeout",
+			"create_timeout_seconds must not be negative.",
+		)
+	}
+
+	if config.CreateBatchWindowMS.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("create_batch_window_ms"),
+			"Invalid Create Batch Window",
+			"create_batch_window_ms must not be negative.",
+		)
+	}
+
+	if config.RetryBudgetSeconds.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_budget_seconds"),
+			"Invalid Retry Budget",
+			"retry_budget_seconds must not be negative.",
+		)
+	}
+
+	if config.WriteRetryBudgetSeconds.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("write_retry_budget_seconds"),
+			"Invalid Retry Budget",
+			"write_retry_budget_seconds must not be negative.",
+		)
+	}
+
+	if onReadError := config.OnReadError.ValueString(); onReadError != "" &&
+		onReadError != onReadErrorFail && onReadError != onReadErrorWarn {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("on_read_error"),
+			"Invalid Read Error Behavior",
+			fmt.Sprintf("on_read_error must be %q or %q. Got: %q", onReadErrorFail, onReadErrorWarn, onReadError),
+		)
+	}
+
+	if config.MaxConcurrentRequests.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Concurrency Limit",
+			"max_concurrent_requests must not be negative.",
+		)
+	}
+
+	if port == 0 && socketPath == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("port"),
 			"Missing certMgr Port",
@@ -135,12 +338,32 @@ func (p *certMgrProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "certMgr_host", host)
-	ctx = tflog.SetField(ctx, "certMgr_port", port)
-
-	tflog.Debug(ctx, "Creating certMgr client")
+	// Every connection the provider makes outside of certMgr goes through
+	// externalClient, so that FIPS mode restricts them all alike.
+	externalClient := http.DefaultClient
+	if config.FIPSMode.ValueBool() {
+		externalClient = certMgr.NewFIPSHTTPClient()
+	}
 
-	client, err := certMgr.NewClient(host, port)
+	var client *certMgr.Client
+	var err error
+	if socketPath != "" {
+		ctx = tflog.SetField(ctx, "certMgr_socket", socketPath)
+		tflog.Debug(ctx, "Creating certMgr client")
+		client, err = certMgr.NewUnixSocketClient(socketPath)
+	} else {
+		ctx = tflog.SetField(ctx, "certMgr_host", host)
+		ctx = tflog.SetField(ctx, "certMgr_port", port)
+		tflog.Debug(ctx, "Creating certMgr client")
+		if oidcTokenURL != "" {
+			client, err = certMgr.NewTokenClient(host, port, certMgr.OIDCClientCredentials(externalClient, oidcTokenURL, oidcClientID, oidcClientSecret))
+			if err == nil {
+				client.Principal = oidcClientID
+			}
+		} else {
+			client, err = certMgr.NewClient(host, port)
+		}
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create certMgr API Client",
@@ -151,18 +374,148 @@ func (p *certMgrProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	if !config.RetryBudgetSeconds.IsNull() && !config.RetryBudgetSeconds.IsUnknown() {
+		client.RetryBudget = time.Duration(config.RetryBudgetSeconds.ValueInt64()) * time.Second
+	}
+	client.WriteRetryBudget = client.RetryBudget
+	if !config.WriteRetryBudgetSeconds.IsNull() && !config.WriteRetryBudgetSeconds.IsUnknown() {
+		client.WriteRetryBudget = time.Duration(config.WriteRetryBudgetSeconds.ValueInt64()) * time.Second
+	}
+
+	if !config.CreateTimeoutSeconds.IsNull() && !config.CreateTimeoutSeconds.IsUnknown() {
+		client.CreateTimeout = time.Duration(config.CreateTimeoutSeconds.ValueInt64()) * time.Second
+	}
+
+	if !config.CreateBatchWindowMS.IsNull() && !config.CreateBatchWindowMS.IsUnknown() {
+		client.CreateBatchWindow = time.Duration(config.CreateBatchWindowMS.ValueInt64()) * time.Millisecond
+	}
+
+	if !config.IdempotencyKeys.IsNull() && !config.IdempotencyKeys.IsUnknown() {
+		client.DisableIdempotencyKeys = !config.IdempotencyKeys.ValueBool()
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		client.SetMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64()))
+	}
+
+	client.Organization = stringOrEnv(config.Organization, "CERTMGR_ORGANIZATION")
+	client.Run = runMetadataFromEnv()
+	client.StrictDecoding = config.StrictDecoding.ValueBool()
+	client.WarnOnReadErrors = config.OnReadError.ValueString() == onReadErrorWarn
+
+	client.ExternalHTTPClient = externalClient
+	if config.FIPSMode.ValueBool() {
+		client.SetFIPSMode()
+	}
+
+	if auditLogPath := config.AuditLogPath.ValueString(); auditLogPath != "" {
+		if err := client.SetAuditLog(auditLogPath); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("audit_log_path"),
+				"Unable to Open Audit Log",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	client.OnRequest(func(req *http.Request) {
+		tflog.Debug(req.Context(), "certMgr request", map[string]any{"method": req.Method, "url": req.URL.String()})
+	})
+	client.OnResponse(func(resp *http.Response) {
+		tflog.Debug(resp.Request.Context(), "certMgr response", map[string]any{"url": resp.Request.URL.String(), "status": resp.StatusCode})
+	})
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ActionData = client
 
 	tflog.Info(ctx, "Configured certMgr client", map[string]any{"success": true})
 }
 
+// stringOrEnv returns the configured value, falling back to the environment
+// variable env when it is not set.
+func stringOrEnv(value types.String, env string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// unixSocketPath returns the socket path of a unix:// endpoint.
+func unixSocketPath(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("endpoint %q is not a valid URL: %w", endpoint, err)
+	}
+	if u.Scheme != "unix" || u.Path == "" {
+		return "", fmt.Errorf("endpoint must have the form unix:///path/to/socket, got %q", endpoint)
+	}
+	return u.Path, nil
+}
+
 func (p *certMgrProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewCertificateResource,
+		NewStagedRequestResource,
+		NewRevocationResource,
+		NewRenewalResource,
+		NewHostResource,
+		NewACLResource,
+		NewTemplateResource,
+		NewAutoRenewalPolicyResource,
+		NewNotificationResource,
+		NewTrustBundleResource,
+		NewPrivateKeyResource,
+		NewCSRResource,
+		NewCertificateSetResource,
+		NewCleanupPolicyResource,
+		NewCertificateBindingResource,
+		NewDNSAliasResource,
+		NewServiceIdentityResource,
+		NewVaultExportResource,
+		NewTeigiExportResource,
+		NewGCPExportResource,
+		NewKeyVaultExportResource,
 	}
 }
 
 func (p *certMgrProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewCertificateDataSource,
+		NewHostDataSource,
+		NewCertificateBySerialDataSource,
+		NewStatisticsDataSource,
+		NewCertificatesByTagDataSource,
+		NewOCSPStatusDataSource,
+		NewCertificatesDataSource,
+	}
+}
+
+func (p *certMgrProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAPITokenEphemeralResource,
+	}
+}
+
+func (p *certMgrProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewRenewAction,
+	}
+}
+
+func (p *certMgrProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseCertificateFunction,
+		NewFingerprintFunction,
+		NewDaysUntilExpiryFunction,
+		NewParseCSRFunction,
+		NewPKCS12EncodeFunction,
+		NewValidatePEMFunction,
+		NewTLSARecordFunction,
+		NewSPKIPinFunction,
+		NewSplitChainFunction,
+		NewKubernetesTLSSecretFunction,
+	}
 }
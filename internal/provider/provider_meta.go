@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var _ provider.ProviderWithMetaSchema = &certMgrProvider{}
+
+// certMgrProviderMetaModel is the provider_meta "certmgr" block that modules
+// set to identify themselves to certMgr.
+type certMgrProviderMetaModel struct {
+	ModuleName    types.String `tfsdk:"module_name"`
+	ModuleVersion types.String `tfsdk:"module_version"`
+}
+
+func (p *certMgrProvider) MetaSchema(_ context.Context, _ provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	resp.Schema = metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"module_name": metaschema.StringAttribute{
+				Description: "Name of the module managing the certificates, sent to certMgr with every request of its resources.",
+				Optional:    true,
+			},
+			"module_version": metaschema.StringAttribute{
+				Description: "Version of the module managing the certificates.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// withProviderMeta returns ctx carrying the provider_meta block of the module
+// a resource is declared in, so that the requests made with it are attributed
+// to that module.
+func withProviderMeta(ctx context.Context, meta tfsdk.Config, diags *diag.Diagnostics) context.Context {
+	if meta.Raw.IsNull() {
+		return ctx
+	}
+
+	var model certMgrProviderMetaModel
+	diags.Append(meta.Get(ctx, &model)...)
+	if diags.HasError() || model.ModuleName.ValueString() == "" {
+		return ctx
+	}
+
+	return certMgr.WithModuleMetadata(ctx, certMgr.ModuleMetadata{
+		Name:    model.ModuleName.ValueString(),
+		Version: model.ModuleVersion.ValueString(),
+	})
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+// testAccProtoV6ProviderFactories serves the provider in-process to the
+// Terraform CLI run by acceptance tests.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"certmgr": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccServer starts a fake certMgr server for an acceptance test and
+// returns it with the provider block pointing at it.
+func testAccServer(t *testing.T) (*fakecertmgr.Server, string) {
+	t.Helper()
+
+	server := fakecertmgr.NewServer(t)
+	return server, fmt.Sprintf(`
+provider "certmgr" {
+  endpoint = "unix://%s"
+}
+`, testAccSocket(t, server))
+}
+
+// testAccSocket serves server on a unix domain socket and returns its path.
+func testAccSocket(t *testing.T, server *fakecertmgr.Server) string {
+	t.Helper()
+
+	// Socket paths are limited to about 100 bytes, more than t.TempDir
+	// leaves on some systems.
+	dir, err := os.MkdirTemp("", "certmgr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "certmgr.sock")
+	server.ServeUnix(t, socket)
+	return socket
+}
+
+func TestAccProviderInvalidEndpoint(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "certmgr" {
+  endpoint = "tcp://localhost:8443"
+}
+
+resource "certmgr_certificate" "test" {
+  hostname = "tf-test.cern.ch"
+}
+`,
+				ExpectError: regexp.MustCompile(`endpoint must have the form unix:///path/to/socket`),
+			},
+		},
+	})
+}
+
+func TestRunMetadataFromEnv(t *testing.T) {
+	for _, env := range []string{"TFC_RUN_ID", "TFC_WORKSPACE_NAME", "PULL_NUM", "WORKSPACE", "BASE_REPO_OWNER", "BASE_REPO_NAME"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("TF_WORKSPACE", "staging")
+	require.Equal(t, certMgr.RunMetadata{Workspace: "staging"}, runMetadataFromEnv())
+
+	t.Setenv("PULL_NUM", "42")
+	t.Setenv("WORKSPACE", "default")
+	t.Setenv("BASE_REPO_OWNER", "it-db")
+	t.Setenv("BASE_REPO_NAME", "infra")
+	require.Equal(t, certMgr.RunMetadata{Workspace: "default", RunID: "it-db/infra#42"}, runMetadataFromEnv())
+
+	t.Setenv("TFC_RUN_ID", "run-CZcmD7eagjhyX0vN")
+	t.Setenv("TFC_WORKSPACE_NAME", "production")
+	require.Equal(t, certMgr.RunMetadata{Workspace: "production", RunID: "run-CZcmD7eagjhyX0vN"}, runMetadataFromEnv())
+}
+
+func TestProviderSchemaActions(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["certmgr"]()
+	require.NoError(t, err)
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+	require.Contains(t, resp.ActionSchemas, "certmgr_renew")
+}
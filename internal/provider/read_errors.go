@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	certMgr "certMgr/internal/client"
+)
+
+// Values of the on_read_error provider attribute.
+const (
+	onReadErrorFail = "fail"
+	onReadErrorWarn = "warn"
+)
+
+// addReadError reports err, which refreshing a resource from certMgr failed
+// with. With on_read_error = "warn", transient errors are reported as
+// warnings instead, and the resource keeps its prior state as long as the
+// caller returns without setting it.
+func addReadError(diags *diag.Diagnostics, client certMgr.ClientAPI, err error, summary, detail string) {
+	if client.ReadErrorsAsWarnings() && certMgr.IsTransient(err) {
+		diags.AddWarning(summary, detail+"\n\nKeeping the prior state, as on_read_error is set to \"warn\".")
+		return
+	}
+	diags.AddError(summary, detail)
+}
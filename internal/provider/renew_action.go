@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ action.Action              = &renewAction{}
+	_ action.ActionWithConfigure = &renewAction{}
+)
+
+func NewRenewAction() action.Action {
+	return &renewAction{}
+}
+
+type renewActionModel struct {
+	CertificateID types.Int64   `tfsdk:"certificate_id"`
+	Hostname      hostnameValue `tfsdk:"hostname"`
+}
+
+type renewAction struct {
+	client certMgr.ClientAPI
+}
+
+func (a *renewAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_renew"
+}
+
+func (a *renewAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renews a certificate on demand, for example with `terraform apply -invoke` or from an " +
+			"`action_trigger`, without changing the configuration of the certificate. Unlike `certmgr_renewal`, " +
+			"nothing is kept in state: the renewed certificate is staged as a new entry that `certmgr_certificate` " +
+			"does not track, as the resource keeps reading the entry it created. The new entry must be cleaned up " +
+			"separately, for example with `certmgr_cleanup_policy`; mind that such a policy also prunes the entry " +
+			"read by `certmgr_certificate` once it is superseded and old enough, and the certificate is then staged again.",
+		Attributes: map[string]schema.Attribute{
+			"certificate_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate to renew.",
+				Required:    true,
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname of the certificate to renew.",
+				Required:    true,
+				CustomType:  hostnameType{},
+			},
+		},
+	}
+}
+
+func (a *renewAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config renewActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := int(config.CertificateID.ValueInt64())
+	hostname := config.Hostname.ValueString()
+	if _, err := a.client.GetStaged(ctx, hostname, id); err != nil {
+		resp.Diagnostics.AddError(
+			"Error renewing certificate",
+			fmt.Sprintf("Could not find certificate %d for hostname %s: %s", id, hostname, err),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Renewing certificate %d for hostname %s", id, hostname),
+	})
+	renewed, err := a.client.RenewCertificate(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error renewing certificate",
+			fmt.Sprintf("Could not renew certificate for hostname %s: %s", hostname, err),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Renewed certificate for hostname %s as %d, serial %s, valid until %s", hostname, renewed.ID, renewed.Serial, renewed.End),
+	})
+}
+
+func (a *renewAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource              = &renewalResource{}
+	_ resource.ResourceWithConfigure = &renewalResource{}
+)
+
+func NewRenewalResource() resource.Resource {
+	return &renewalResource{}
+}
+
+type renewalResourceModel struct {
+	ID            types.Int64   `tfsdk:"id"`
+	CertificateID types.Int64   `tfsdk:"certificate_id"`
+	Hostname      hostnameValue `tfsdk:"hostname"`
+	Triggers      types.Map     `tfsdk:"triggers"`
+	Serial        types.String  `tfsdk:"serial"`
+	Start         types.String  `tfsdk:"start"`
+	End           types.String  `tfsdk:"end"`
+	Organization  types.String  `tfsdk:"organization"`
+}
+
+type renewalResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *renewalResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_renewal"
+}
+
+func (r *renewalResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renews an existing certificate. The renewal runs once on create and again whenever `triggers` change; " +
+			"destroying the resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the staged entry created by the renewal.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate to renew. Changing this forces a new renewal.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname of the certificate to renew. Changing this forces a new renewal.",
+				Required:    true,
+				CustomType:  hostnameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						hostnameChanged,
+						"Changing the hostname forces a new renewal.",
+						"Changing the hostname forces a new renewal.",
+					),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that, when changed, renew the certificate again.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the renewed certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start": schema.StringAttribute{
+				Description: "Start of the validity of the renewed certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"end": schema.StringAttribute{
+				Description: "End of the validity of the renewed certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is renewed in, overriding the provider's organization. " +
+					"Changing this forces a new renewal.",
+			),
+		},
+	}
+}
+
+func (r *renewalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan renewalResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	hostname := plan.Hostname.ValueString()
+	if _, err := r.client.GetStaged(ctx, hostname, int(plan.CertificateID.ValueInt64())); err != nil {
+		resp.Diagnostics.AddError(
+			"Error renewing certificate",
+			fmt.Sprintf("Could not find certificate %d for hostname %s: %s", plan.CertificateID.ValueInt64(), hostname, err),
+		)
+		return
+	}
+
+	renewed, err := r.client.RenewCertificate(ctx, int(plan.CertificateID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error renewing certificate",
+			fmt.Sprintf("Could not renew certificate for hostname %s: %s", hostname, err),
+		)
+		return
+	}
+
+	plan.fromCertificate(renewed)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *renewalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state renewalResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	hostname := state.Hostname.ValueString()
+	renewed, err := r.client.GetStaged(ctx, hostname, int(state.ID.ValueInt64()))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoCertificates) {
+			resp.Diagnostics.AddWarning(
+				"Renewed Certificate Not Found",
+				fmt.Sprintf("No renewed certificate found for hostname %s; removing resource from state.", hostname),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Renewal",
+			fmt.Sprintf("Could not read renewed certificate for hostname %s: %s", hostname, err),
+		)
+		return
+	}
+
+	state.fromCertificate(renewed)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *renewalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan renewalResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete leaves the renewed certificate in place; the renewal itself cannot
+// be undone.
+func (r *renewalResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *renewalResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (m *renewalResourceModel) fromCertificate(cert *certMgr.Certificate) {
+	m.ID = types.Int64Value(int64(cert.ID))
+	m.Serial = types.StringValue(cert.Serial)
+	m.Start = types.StringValue(cert.Start)
+	m.End = types.StringValue(cert.End)
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRenewal checks that a renewal tracks the staged entry it created rather
+// than the renewed certificate, and that destroying it keeps both.
+func TestRenewal(t *testing.T) {
+	ctx := context.Background()
+	server := fakecertmgr.NewServer(t)
+	client := server.NewClient()
+	r := &renewalResource{client: client}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cert, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	other, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test2.cern.ch"})
+	require.NoError(t, err)
+
+	renew := func(hostname string, certificateID int) (tfsdk.State, diag.Diagnostics) {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(ctx, renewalResourceModel{
+			ID:            types.Int64Unknown(),
+			CertificateID: types.Int64Value(int64(certificateID)),
+			Hostname:      newHostnameValue(hostname),
+			Triggers:      types.MapNull(types.StringType),
+			Serial:        types.StringUnknown(),
+			Start:         types.StringUnknown(),
+			End:           types.StringUnknown(),
+			Organization:  types.StringNull(),
+		}).HasError())
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+		return resp.State, resp.Diagnostics
+	}
+
+	// The certificate must belong to the hostname.
+	_, diags := renew("tf-test.cern.ch", other.ID)
+	require.True(t, diags.HasError())
+	require.Equal(t, "Error renewing certificate", diags.Errors()[0].Summary())
+	require.Len(t, server.Certificates(), 2)
+
+	created, diags := renew("tf-test.cern.ch", cert.ID)
+	require.False(t, diags.HasError(), diags)
+	var state renewalResourceModel
+	require.False(t, created.Get(ctx, &state).HasError())
+	require.NotEqual(t, int64(cert.ID), state.ID.ValueInt64())
+	require.NotEqual(t, cert.Serial, state.Serial.ValueString())
+	require.Len(t, server.Certificates(), 3)
+
+	readResp := resource.ReadResponse{State: created}
+	r.Read(ctx, resource.ReadRequest{State: created}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var refreshed renewalResourceModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	require.Equal(t, state, refreshed)
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Len(t, server.Certificates(), 3)
+
+	server.Remove(int(state.ID.ValueInt64()))
+	readResp = resource.ReadResponse{State: created}
+	r.Read(ctx, resource.ReadRequest{State: created}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.Equal(t, "Renewed Certificate Not Found", readResp.Diagnostics.Warnings()[0].Summary())
+	require.True(t, readResp.State.Raw.IsNull())
+}
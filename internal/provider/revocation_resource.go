@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                   = &revocationResource{}
+	_ resource.ResourceWithConfigure      = &revocationResource{}
+	_ resource.ResourceWithValidateConfig = &revocationResource{}
+)
+
+func NewRevocationResource() resource.Resource {
+	return &revocationResource{}
+}
+
+type revocationResourceModel struct {
+	ID            types.Int64  `tfsdk:"id"`
+	Serial        types.String `tfsdk:"serial"`
+	CertificateID types.Int64  `tfsdk:"certificate_id"`
+	Reason        types.String `tfsdk:"reason"`
+	RevokedAt     types.String `tfsdk:"revoked_at"`
+	Organization  types.String `tfsdk:"organization"`
+}
+
+type revocationResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *revocationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_revocation"
+}
+
+func (r *revocationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Revokes a certificate. Revocation cannot be undone: destroying this resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the revocation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the certificate to revoke. Exactly one of `serial` or `certificate_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate_id": schema.Int64Attribute{
+				Description: "certMgr identifier of the certificate to revoke. Exactly one of `serial` or `certificate_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"reason": schema.StringAttribute{
+				Description: "RFC 5280 reason code for the revocation, one of: " + strings.Join(certMgr.RevocationReasons, ", ") + ". " +
+					"A certificate that is already revoked keeps its original reason.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revoked_at": schema.StringAttribute{
+				Description: "Timestamp at which certMgr revoked the certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is revoked in, overriding the provider's organization. " +
+					"Changing this forces a new revocation.",
+			),
+		},
+	}
+}
+
+func (r *revocationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config revocationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Serial.IsUnknown() || config.CertificateID.IsUnknown() {
+		return
+	}
+	if config.Serial.IsNull() == config.CertificateID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("serial"),
+			"Invalid Certificate Selection",
+			"Exactly one of serial or certificate_id must be set.",
+		)
+	}
+
+	if !config.Reason.IsNull() && !config.Reason.IsUnknown() &&
+		!slices.Contains(certMgr.RevocationReasons, config.Reason.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("reason"),
+			"Invalid Revocation Reason",
+			fmt.Sprintf("Reason must be one of: %s. Got: %q", strings.Join(certMgr.RevocationReasons, ", "), config.Reason.ValueString()),
+		)
+	}
+}
+
+func (r *revocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan revocationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	serial := plan.Serial.ValueString()
+	if plan.Serial.IsNull() {
+		id := plan.CertificateID.ValueInt64()
+		cert, err := r.client.GetCertificateByID(ctx, int(id))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error revoking certificate",
+				fmt.Sprintf("Could not read certificate %d: %s", id, err),
+			)
+			return
+		}
+		if cert.Serial == "" {
+			resp.Diagnostics.AddError(
+				"Error revoking certificate",
+				fmt.Sprintf("Certificate %d has not been issued yet.", id),
+			)
+			return
+		}
+		serial = cert.Serial
+	}
+
+	revocation, err := r.client.RevokeCertificate(ctx, serial, plan.Reason.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error revoking certificate",
+			"Could not revoke certificate: "+err.Error(),
+		)
+		return
+	}
+	if revocation.Reason != plan.Reason.ValueString() {
+		resp.Diagnostics.AddWarning(
+			"Certificate Already Revoked",
+			fmt.Sprintf("Certificate %s was already revoked with reason %q, which certMgr keeps.", serial, revocation.Reason),
+		)
+	}
+
+	plan.ID = types.Int64Value(int64(revocation.ID))
+	plan.RevokedAt = types.StringValue(revocation.RevokedAt)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *revocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state revocationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	revocation, err := r.client.GetRevocation(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoRevocation) {
+			resp.Diagnostics.AddWarning(
+				"Revocation Not Found",
+				fmt.Sprintf("No revocation found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Revocation",
+			fmt.Sprintf("Could not read revocation %d: %s", id, err),
+		)
+		return
+	}
+
+	state.RevokedAt = types.StringValue(revocation.RevokedAt)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *revocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan revocationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *revocationResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"Revocation Not Undone",
+		"Revocations are permanent in certMgr; the revocation has only been removed from Terraform state.",
+	)
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *revocationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRevocationAlreadyRevoked checks that a certificate revoked by ID can be
+// revoked again by serial, adopting the first revocation with a warning, and
+// that destroying a revocation leaves it in certMgr.
+func TestRevocationAlreadyRevoked(t *testing.T) {
+	ctx := context.Background()
+	server := fakecertmgr.NewServer(t)
+	client := server.NewClient()
+	r := &revocationResource{client: client}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cert, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+
+	revoke := func(model revocationResourceModel) (revocationResourceModel, tfsdk.State, diag.Diagnostics) {
+		model.ID = types.Int64Unknown()
+		model.RevokedAt = types.StringUnknown()
+		model.Organization = types.StringNull()
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(ctx, model).HasError())
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		var state revocationResourceModel
+		require.False(t, resp.State.Get(ctx, &state).HasError())
+		return state, resp.State, resp.Diagnostics
+	}
+
+	byID, _, diags := revoke(revocationResourceModel{
+		Serial:        types.StringNull(),
+		CertificateID: types.Int64Value(int64(cert.ID)),
+		Reason:        types.StringValue("keyCompromise"),
+	})
+	require.Empty(t, diags.Warnings())
+	require.NotEmpty(t, byID.RevokedAt.ValueString())
+
+	bySerial, state, diags := revoke(revocationResourceModel{
+		Serial:        types.StringValue(strings.ToLower(cert.Serial)),
+		CertificateID: types.Int64Null(),
+		Reason:        types.StringValue("superseded"),
+	})
+	require.Len(t, diags.Warnings(), 1)
+	require.Equal(t, "Certificate Already Revoked", diags.Warnings()[0].Summary())
+	require.Contains(t, diags.Warnings()[0].Detail(), `"keyCompromise"`)
+	require.Equal(t, byID.ID, bySerial.ID)
+	require.Equal(t, byID.RevokedAt, bySerial.RevokedAt)
+
+	deleteResp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Equal(t, "Revocation Not Undone", deleteResp.Diagnostics.Warnings()[0].Summary())
+	require.True(t, deleteResp.State.Raw.IsNull())
+
+	revoked, err := client.CertificateRevoked(ctx, cert.Serial)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestRevocationValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&revocationResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name          string
+		serial        types.String
+		certificateID types.Int64
+		reason        string
+		summaries     []string
+	}{
+		{name: "serial", serial: types.StringValue("0A1B"), certificateID: types.Int64Null(), reason: "superseded"},
+		{name: "certificate id", serial: types.StringNull(), certificateID: types.Int64Value(42), reason: "keyCompromise"},
+		{name: "unknown certificate id", serial: types.StringNull(), certificateID: types.Int64Unknown(), reason: "superseded"},
+		{
+			name: "neither", serial: types.StringNull(), certificateID: types.Int64Null(), reason: "superseded",
+			summaries: []string{"Invalid Certificate Selection"},
+		},
+		{
+			name: "both", serial: types.StringValue("0A1B"), certificateID: types.Int64Value(42), reason: "superseded",
+			summaries: []string{"Invalid Certificate Selection"},
+		},
+		{
+			name: "reason", serial: types.StringValue("0A1B"), certificateID: types.Int64Null(), reason: "expired",
+			summaries: []string{"Invalid Revocation Reason"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, config.Set(ctx, revocationResourceModel{
+				ID:            types.Int64Null(),
+				Serial:        tt.serial,
+				CertificateID: tt.certificateID,
+				Reason:        types.StringValue(tt.reason),
+				RevokedAt:     types.StringNull(),
+				Organization:  types.StringNull(),
+			}).HasError())
+
+			var resp resource.ValidateConfigResponse
+			(&revocationResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, &resp)
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			require.Equal(t, tt.summaries, summaries)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"fmt"
+	"os"
+
+	certMgr "certMgr/internal/client"
+)
+
+// runMetadataFromEnv identifies the Terraform run the provider serves from the
+// environment of HCP Terraform or Atlantis, falling back to the workspace
+// selected with TF_WORKSPACE.
+func runMetadataFromEnv() certMgr.RunMetadata {
+	if runID := os.Getenv("TFC_RUN_ID"); runID != "" {
+		return certMgr.RunMetadata{
+			Workspace: os.Getenv("TFC_WORKSPACE_NAME"),
+			RunID:     runID,
+		}
+	}
+
+	// Atlantis has no run IDs; runs are identified by their pull request.
+	if pull := os.Getenv("PULL_NUM"); pull != "" {
+		return certMgr.RunMetadata{
+			Workspace: os.Getenv("WORKSPACE"),
+			RunID:     fmt.Sprintf("%s/%s#%s", os.Getenv("BASE_REPO_OWNER"), os.Getenv("BASE_REPO_NAME"), pull),
+		}
+	}
+
+	return certMgr.RunMetadata{Workspace: os.Getenv("TF_WORKSPACE")}
+}
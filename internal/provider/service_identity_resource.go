@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource               = &serviceIdentityResource{}
+	_ resource.ResourceWithConfigure  = &serviceIdentityResource{}
+	_ resource.ResourceWithModifyPlan = &serviceIdentityResource{}
+)
+
+func NewServiceIdentityResource() resource.Resource {
+	return &serviceIdentityResource{}
+}
+
+type serviceIdentityResourceModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	OwnerGroup       types.String `tfsdk:"owner_egroup"`
+	CSRPEM           types.String `tfsdk:"csr_pem"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	CertificateID    types.Int64  `tfsdk:"certificate_id"`
+	Serial           types.String `tfsdk:"serial"`
+	End              types.String `tfsdk:"end"`
+	CertificatePEM   types.String `tfsdk:"certificate_pem"`
+	PrivateKeyPEM    types.String `tfsdk:"private_key_pem"`
+	Organization     types.String `tfsdk:"organization"`
+}
+
+type serviceIdentityResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *serviceIdentityResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_identity"
+}
+
+func (r *serviceIdentityResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a robot or service account identity together with its client certificate. " +
+			"The certificate is reissued whenever `csr_pem` or `rotation_triggers` change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the service identity.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the service account. Changing this forces a new identity.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner_egroup": schema.StringAttribute{
+				Description: "E-group owning the service account.",
+				Required:    true,
+			},
+			"csr_pem": schema.StringAttribute{
+				Description: "PEM-encoded certificate signing request for the client certificate. When omitted, certMgr " +
+					"generates the key pair and `private_key_pem` is populated.",
+				Optional: true,
+			},
+			"rotation_triggers": schema.MapAttribute{
+				Description: "Arbitrary values that, when changed, reissue the client certificate.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"certificate_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the current client certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the current client certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"end": schema.StringAttribute{
+				Description: "End of the validity of the current client certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_pem": schema.StringAttribute{
+				Description: "PEM-encoded current client certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				Description: "PEM-encoded private key generated by certMgr. Empty when `csr_pem` is set.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is issued in, overriding the provider's organization. " +
+					"Changing this forces a new service identity.",
+			),
+		},
+	}
+}
+
+// ModifyPlan marks the certificate attributes unknown when the planned change
+// rotates the client certificate.
+func (r *serviceIdentityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state serviceIdentityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.rotates(state) {
+		return
+	}
+
+	plan.CertificateID = types.Int64Unknown()
+	plan.Serial = types.StringUnknown()
+	plan.End = types.StringUnknown()
+	plan.CertificatePEM = types.StringUnknown()
+	plan.PrivateKeyPEM = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *serviceIdentityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan serviceIdentityResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	identity, err := r.client.CreateServiceIdentity(ctx, plan.toServiceIdentity())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating service identity",
+			"Could not create service identity: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(identity.ID))
+
+	resp.Diagnostics.Append(r.issue(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		// Keep the identity in state so it is not orphaned; the next apply
+		// retries issuing the certificate.
+		plan.CertificateID = types.Int64Null()
+		plan.Serial = types.StringNull()
+		plan.End = types.StringNull()
+		plan.CertificatePEM = types.StringNull()
+		plan.PrivateKeyPEM = types.StringNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *serviceIdentityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state serviceIdentityResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	identity, err := r.client.GetServiceIdentity(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoServiceIdentity) {
+			resp.Diagnostics.AddWarning(
+				"Service Identity Not Found",
+				fmt.Sprintf("No service identity found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Service Identity",
+			fmt.Sprintf("Could not read service identity %d: %s", id, err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(identity.Name)
+	state.OwnerGroup = types.StringValue(identity.OwnerGroup)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *serviceIdentityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state serviceIdentityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	plan.ID = state.ID
+	if !plan.OwnerGroup.Equal(state.OwnerGroup) {
+		if err := r.client.UpdateServiceIdentity(ctx, plan.toServiceIdentity()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating service identity",
+				"Could not update service identity: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if plan.rotates(state) {
+		resp.Diagnostics.Append(r.issue(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *serviceIdentityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state serviceIdentityResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	if err := r.client.DeleteServiceIdentity(ctx, int(state.ID.ValueInt64())); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting service identity",
+			fmt.Sprintf("Could not delete service identity %s: %s", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *serviceIdentityResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// issue requests a fresh client certificate for the identity in m and
+// records it.
+func (r *serviceIdentityResource) issue(ctx context.Context, m *serviceIdentityResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cert, err := r.client.IssueIdentityCertificate(ctx, int(m.ID.ValueInt64()), m.CSRPEM.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error issuing client certificate",
+			fmt.Sprintf("Could not issue client certificate for service identity %s: %s", m.Name.ValueString(), err),
+		)
+		return diags
+	}
+
+	m.CertificateID = types.Int64Value(int64(cert.ID))
+	m.Serial = types.StringValue(cert.Serial)
+	m.End = types.StringValue(cert.End)
+	m.CertificatePEM = types.StringValue(cert.CertificatePEM)
+	m.PrivateKeyPEM = types.StringValue(cert.PrivateKeyPEM)
+	return diags
+}
+
+// rotates reports whether moving from state to m reissues the client
+// certificate. An identity whose first certificate failed to issue is
+// retried.
+func (m serviceIdentityResourceModel) rotates(state serviceIdentityResourceModel) bool {
+	return state.CertificateID.IsNull() ||
+		!m.CSRPEM.Equal(state.CSRPEM) ||
+		!m.RotationTriggers.Equal(state.RotationTriggers)
+}
+
+func (m serviceIdentityResourceModel) toServiceIdentity() certMgr.ServiceIdentity {
+	return certMgr.ServiceIdentity{
+		ID:         int(m.ID.ValueInt64()),
+		Name:       m.Name.ValueString(),
+		OwnerGroup: m.OwnerGroup.ValueString(),
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// issuedServiceIdentity is the state of service identity 19 holding client
+// certificate 101.
+func issuedServiceIdentity() serviceIdentityResourceModel {
+	return serviceIdentityResourceModel{
+		ID:               types.Int64Value(19),
+		Name:             types.StringValue("ci-runner"),
+		OwnerGroup:       types.StringValue("it-dep"),
+		CSRPEM:           types.StringNull(),
+		RotationTriggers: types.MapValueMust(types.StringType, map[string]attr.Value{"epoch": types.StringValue("1")}),
+		CertificateID:    types.Int64Value(101),
+		Serial:           types.StringValue("65"),
+		End:              types.StringValue("2027-10-16T00:00:00Z"),
+		CertificatePEM:   types.StringValue("certificate"),
+		PrivateKeyPEM:    types.StringValue("key"),
+		Organization:     types.StringNull(),
+	}
+}
+
+func TestServiceIdentityModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := &serviceIdentityResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name    string
+		state   func(*serviceIdentityResourceModel)
+		plan    func(*serviceIdentityResourceModel)
+		rotates bool
+	}{
+		{name: "owner", plan: func(m *serviceIdentityResourceModel) { m.OwnerGroup = types.StringValue("it-ops") }},
+		{name: "csr", plan: func(m *serviceIdentityResourceModel) { m.CSRPEM = types.StringValue("csr") }, rotates: true},
+		{
+			name: "rotation trigger",
+			plan: func(m *serviceIdentityResourceModel) {
+				m.RotationTriggers = types.MapValueMust(types.StringType, map[string]attr.Value{"epoch": types.StringValue("2")})
+			},
+			rotates: true,
+		},
+		{
+			name:    "failed issue",
+			state:   func(m *serviceIdentityResourceModel) { m.CertificateID = types.Int64Null() },
+			rotates: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := issuedServiceIdentity()
+			if tt.state != nil {
+				tt.state(&prior)
+			}
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, prior).HasError())
+
+			planned := issuedServiceIdentity()
+			if tt.plan != nil {
+				tt.plan(&planned)
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, planned).HasError())
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var got serviceIdentityResourceModel
+			require.False(t, resp.Plan.Get(ctx, &got).HasError())
+			require.Equal(t, tt.rotates, got.CertificateID.IsUnknown())
+			require.Equal(t, tt.rotates, got.CertificatePEM.IsUnknown())
+			require.Equal(t, tt.rotates, got.PrivateKeyPEM.IsUnknown())
+		})
+	}
+}
+
+// TestServiceIdentityIssueRetry checks that an identity whose certificate
+// failed to issue is kept in state, and that the next apply issues it
+// without updating the identity.
+func TestServiceIdentityIssueRetry(t *testing.T) {
+	ctx := context.Background()
+	issueErr := errors.New("certMgr CA unavailable")
+	client := &clientmock.Client{
+		CreateServiceIdentityFunc: func(_ context.Context, identity certMgr.ServiceIdentity) (*certMgr.ServiceIdentity, error) {
+			require.Equal(t, certMgr.ServiceIdentity{Name: "ci-runner", OwnerGroup: "it-dep"}, identity)
+			identity.ID = 19
+			return &identity, nil
+		},
+		IssueIdentityCertificateFunc: func(_ context.Context, id int, csr string) (*certMgr.Certificate, error) {
+			require.Equal(t, 19, id)
+			require.Empty(t, csr)
+			if issueErr != nil {
+				return nil, issueErr
+			}
+			return &certMgr.Certificate{
+				ID:             101,
+				Serial:         "65",
+				End:            "2027-10-16T00:00:00Z",
+				CertificatePEM: "certificate",
+				PrivateKeyPEM:  "key",
+			}, nil
+		},
+	}
+	r := &serviceIdentityResource{client: client}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	planned := issuedServiceIdentity()
+	planned.ID = types.Int64Unknown()
+	planned.CertificateID = types.Int64Unknown()
+	planned.Serial = types.StringUnknown()
+	planned.End = types.StringUnknown()
+	planned.CertificatePEM = types.StringUnknown()
+	planned.PrivateKeyPEM = types.StringUnknown()
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, planned).HasError())
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	require.Equal(t, "Error issuing client certificate", createResp.Diagnostics.Errors()[0].Summary())
+
+	var state serviceIdentityResourceModel
+	require.False(t, createResp.State.Get(ctx, &state).HasError())
+	require.Equal(t, int64(19), state.ID.ValueInt64())
+	require.True(t, state.CertificateID.IsNull())
+	require.True(t, state.PrivateKeyPEM.IsNull())
+
+	// UpdateServiceIdentityFunc is not mocked: the owner is unchanged, so
+	// only the certificate is issued.
+	issueErr = nil
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.False(t, updateResp.State.Get(ctx, &state).HasError())
+	require.Equal(t, issuedServiceIdentity(), state)
+}
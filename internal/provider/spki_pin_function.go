@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &spkiPinFunction{}
+
+func NewSPKIPinFunction() function.Function {
+	return &spkiPinFunction{}
+}
+
+type spkiPinFunction struct{}
+
+func (f *spkiPinFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "spki_pin"
+}
+
+func (f *spkiPinFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute the SPKI pin of a certificate or public key",
+		Description: "Returns the base64 SHA-256 digest of the SubjectPublicKeyInfo of a certificate or public key, " +
+			"as used in HPKP-style `pin-sha256` pinning configuration.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "PEM-encoded certificate or public key.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *spkiPinFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	resp.Error = req.Arguments.Get(ctx, &data)
+	if resp.Error != nil {
+		return
+	}
+
+	pin, err := pki.SPKIPin(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, pin)
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &splitChainFunction{}
+
+func NewSplitChainFunction() function.Function {
+	return &splitChainFunction{}
+}
+
+type splitChainFunction struct{}
+
+func (f *splitChainFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_chain"
+}
+
+func (f *splitChainFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a PEM bundle into individual certificates",
+		Description: "Returns the certificates of a concatenated PEM bundle as a list of PEM strings. When the " +
+			"certificates form a single chain they are ordered leaf first, whatever their order in the bundle; " +
+			"otherwise the bundle order is kept. Blocks other than certificates are ignored.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "Concatenated PEM-encoded certificates.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *splitChainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	resp.Error = req.Arguments.Get(ctx, &data)
+	if resp.Error != nil {
+		return
+	}
+
+	blocks, err := pki.SplitChain(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if blocks == nil {
+		blocks = []string{}
+	}
+
+	resp.Error = resp.Result.Set(ctx, blocks)
+}
@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                = &stagedRequestResource{}
+	_ resource.ResourceWithConfigure   = &stagedRequestResource{}
+	_ resource.ResourceWithImportState = &stagedRequestResource{}
+)
+
+func NewStagedRequestResource() resource.Resource {
+	return &stagedRequestResource{}
+}
+
+type stagedRequestResourceModel struct {
+	ID           types.Int64   `tfsdk:"id"`
+	Hostname     hostnameValue `tfsdk:"hostname"`
+	Requestor    types.String  `tfsdk:"requestor"`
+	Start        types.String  `tfsdk:"start"`
+	End          types.String  `tfsdk:"end"`
+	Organization types.String  `tfsdk:"organization"`
+}
+
+type stagedRequestResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *stagedRequestResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_staged_request"
+}
+
+func (r *stagedRequestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a raw staged certificate request, without the issuance handling of certmgr_certificate.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the staged request.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname the request is staged for. Changing this forces a new request.",
+				Required:    true,
+				CustomType:  hostnameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						hostnameChanged,
+						"Changing the hostname forces a new request.",
+						"Changing the hostname forces a new request.",
+					),
+				},
+			},
+			"requestor": schema.StringAttribute{
+				Description: "Requestor recorded by certMgr for the staged request.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start": schema.StringAttribute{
+				Description: "Start timestamp of the staged request as reported by certMgr.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"end": schema.StringAttribute{
+				Description: "End timestamp of the staged request as reported by certMgr.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the request is staged in, overriding the provider's organization. " +
+					"Changing this forces a new staged request.",
+			),
+		},
+	}
+}
+
+func (r *stagedRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan stagedRequestResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	staged, err := r.client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: plan.Hostname.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating staged request",
+			"Could not stage certificate request: "+err.Error(),
+		)
+		return
+	}
+
+	plan.fromStaged(staged)
+	plan.Hostname = newHostnameValue(staged.Hostname)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *stagedRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state stagedRequestResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	hostname := state.Hostname.ValueString()
+
+	var staged *certMgr.Certificate
+	var err error
+	if state.ID.IsNull() {
+		staged, err = r.client.GetCertificate(ctx, hostname)
+	} else {
+		staged, err = r.client.GetStaged(ctx, hostname, int(state.ID.ValueInt64()))
+	}
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoCertificates) {
+			resp.Diagnostics.AddWarning(
+				"Staged Request Not Found",
+				fmt.Sprintf("No staged request found for hostname %s; removing resource from state.", hostname),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Staged Request",
+			fmt.Sprintf("Could not read staged request for hostname %s: %s", hostname, err),
+		)
+		return
+	}
+
+	state.fromStaged(staged)
+	state.Hostname = newHostnameValue(staged.Hostname)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *stagedRequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan stagedRequestResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *stagedRequestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state stagedRequestResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	if err := r.client.DeleteStaged(ctx, int(id)); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting staged request",
+			fmt.Sprintf("Could not delete staged request %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *stagedRequestResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState accepts either `<hostname>` to adopt the latest staged request
+// or `<hostname>/<id>` to adopt a specific one.
+func (r *stagedRequestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	hostname, idStr, hasID := strings.Cut(strings.TrimSpace(req.ID), "/")
+	hostname, err := certMgr.NormalizeHostname(hostname)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Could not import %s: expected <hostname> or <hostname>/<id>, for example host.cern.ch/42: %s.", req.ID, err),
+		)
+		return
+	}
+
+	var id int64
+	if hasID {
+		id, err = parseImportID(idStr, "staged request")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Could not import %s: %s after the hostname.", req.ID, err),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hostname"), hostname)...)
+	if hasID {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	}
+}
+
+func (m *stagedRequestResourceModel) fromStaged(staged *certMgr.Certificate) {
+	m.ID = types.Int64Value(int64(staged.ID))
+	m.Requestor = types.StringValue(staged.Requestor)
+	m.Start = types.StringValue(staged.Start)
+	m.End = types.StringValue(staged.End)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStagedRequestImport checks that importing a hostname adopts its latest
+// staged request and importing <hostname>/<id> the given one, even when a
+// newer request is staged for the host.
+func TestStagedRequestImport(t *testing.T) {
+	ctx := context.Background()
+	server := fakecertmgr.NewServer(t)
+	client := server.NewClient()
+	r := &stagedRequestResource{client: client}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	older, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+	latest, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: "tf-test.cern.ch"})
+	require.NoError(t, err)
+
+	importAndRead := func(id string) (stagedRequestResourceModel, tfsdk.State) {
+		importResp := resource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+		require.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+		readResp := resource.ReadResponse{State: importResp.State}
+		r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+		require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+		var state stagedRequestResourceModel
+		require.False(t, readResp.State.Get(ctx, &state).HasError())
+		return state, readResp.State
+	}
+
+	state, _ := importAndRead("TF-Test.cern.ch")
+	require.Equal(t, int64(latest.ID), state.ID.ValueInt64())
+	require.Equal(t, "tf-test.cern.ch", state.Hostname.ValueString())
+
+	state, imported := importAndRead("tf-test.cern.ch/" + strconv.Itoa(older.ID))
+	require.Equal(t, int64(older.ID), state.ID.ValueInt64())
+	require.Equal(t, older.Start, state.Start.ValueString())
+
+	deleteResp := resource.DeleteResponse{State: imported}
+	r.Delete(ctx, resource.DeleteRequest{State: imported}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Len(t, server.Certificates(), 1)
+
+	readResp := resource.ReadResponse{State: imported}
+	r.Read(ctx, resource.ReadRequest{State: imported}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.Equal(t, "Staged Request Not Found", readResp.Diagnostics.Warnings()[0].Summary())
+	require.True(t, readResp.State.Raw.IsNull())
+}
+
+func TestStagedRequestImportInvalid(t *testing.T) {
+	ctx := context.Background()
+	r := &stagedRequestResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for _, id := range []string{"", "/42", "tf-test.cern.ch/", "tf-test.cern.ch/latest"} {
+		resp := resource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, &resp)
+		require.True(t, resp.Diagnostics.HasError(), "import ID %q", id)
+		require.Equal(t, "Invalid Import ID", resp.Diagnostics.Errors()[0].Summary())
+	}
+}
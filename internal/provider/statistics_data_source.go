@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ datasource.DataSource                   = &statisticsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &statisticsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &statisticsDataSource{}
+)
+
+func NewStatisticsDataSource() datasource.DataSource {
+	return &statisticsDataSource{}
+}
+
+type statisticsDataSourceModel struct {
+	Requestor       types.String `tfsdk:"requestor"`
+	Hostgroup       types.String `tfsdk:"hostgroup"`
+	Total           types.Int64  `tfsdk:"total"`
+	IssuedThisMonth types.Int64  `tfsdk:"issued_this_month"`
+	ExpiringSoon    types.Int64  `tfsdk:"expiring_in_30_days"`
+}
+
+type statisticsDataSource struct {
+	client certMgr.ClientAPI
+}
+
+func (d *statisticsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_statistics"
+}
+
+func (d *statisticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports aggregate certificate counts for a requestor, a hostgroup or, when neither is set, the whole service.",
+		Attributes: map[string]schema.Attribute{
+			"requestor": schema.StringAttribute{
+				Description: "Only count certificates requested by this account. Conflicts with `hostgroup`.",
+				Optional:    true,
+			},
+			"hostgroup": schema.StringAttribute{
+				Description: "Only count certificates of hosts in this hostgroup. Conflicts with `requestor`.",
+				Optional:    true,
+			},
+			"total": schema.Int64Attribute{
+				Description: "Number of certificates.",
+				Computed:    true,
+			},
+			"issued_this_month": schema.Int64Attribute{
+				Description: "Number of certificates issued since the start of the current month.",
+				Computed:    true,
+			},
+			"expiring_in_30_days": schema.Int64Attribute{
+				Description: "Number of certificates expiring within the next 30 days.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *statisticsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config statisticsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Requestor.IsNull() && !config.Hostgroup.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostgroup"),
+			"Conflicting Statistics Scope",
+			"At most one of requestor or hostgroup may be set.",
+		)
+	}
+}
+
+func (d *statisticsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var config statisticsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stats, err := d.client.GetStatistics(ctx, certMgr.StatisticsFilter{
+		Requestor: config.Requestor.ValueString(),
+		Hostgroup: config.Hostgroup.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Statistics",
+			"Could not read certificate statistics: "+err.Error(),
+		)
+		return
+	}
+
+	config.Total = types.Int64Value(int64(stats.Total))
+	config.IssuedThisMonth = types.Int64Value(int64(stats.IssuedThisMonth))
+	config.ExpiringSoon = types.Int64Value(int64(stats.ExpiringSoon))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *statisticsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatisticsDataSourceScope(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		requestor types.String
+		hostgroup types.String
+		filter    certMgr.StatisticsFilter
+		summaries []string
+	}{
+		{name: "all", requestor: types.StringNull(), hostgroup: types.StringNull()},
+		{
+			name: "requestor", requestor: types.StringValue("jdoe"), hostgroup: types.StringNull(),
+			filter: certMgr.StatisticsFilter{Requestor: "jdoe"},
+		},
+		{
+			name: "hostgroup", requestor: types.StringNull(), hostgroup: types.StringValue("cms/web"),
+			filter: certMgr.StatisticsFilter{Hostgroup: "cms/web"},
+		},
+		{
+			name: "both", requestor: types.StringValue("jdoe"), hostgroup: types.StringValue("cms/web"),
+			summaries: []string{"Conflicting Statistics Scope"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &statisticsDataSource{client: &clientmock.Client{
+				GetStatisticsFunc: func(_ context.Context, filter certMgr.StatisticsFilter) (*certMgr.Statistics, error) {
+					require.Equal(t, tt.filter, filter)
+					return &certMgr.Statistics{Total: 12, IssuedThisMonth: 3, ExpiringSoon: 1}, nil
+				},
+			}}
+			var schemaResp datasource.SchemaResponse
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, statisticsDataSourceModel{
+				Requestor:       tt.requestor,
+				Hostgroup:       tt.hostgroup,
+				Total:           types.Int64Null(),
+				IssuedThisMonth: types.Int64Null(),
+				ExpiringSoon:    types.Int64Null(),
+			}).HasError())
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			var validateResp datasource.ValidateConfigResponse
+			d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: config}, &validateResp)
+			var summaries []string
+			for _, e := range validateResp.Diagnostics.Errors() {
+				summaries = append(summaries, e.Summary())
+			}
+			require.Equal(t, tt.summaries, summaries)
+			if summaries != nil {
+				return
+			}
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			var got statisticsDataSourceModel
+			require.False(t, resp.State.Get(ctx, &got).HasError())
+			require.Equal(t, statisticsDataSourceModel{
+				Requestor:       tt.requestor,
+				Hostgroup:       tt.hostgroup,
+				Total:           types.Int64Value(12),
+				IssuedThisMonth: types.Int64Value(3),
+				ExpiringSoon:    types.Int64Value(1),
+			}, got)
+		})
+	}
+}
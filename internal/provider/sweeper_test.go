@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/fakecertmgr"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+// testAccHostnamePrefix starts the hostnames of certificates requested by
+// acceptance tests, for the sweeper to recognize those left behind.
+const testAccHostnamePrefix = "tf-test-cert-"
+
+// TestMain runs the sweepers when go test is given -sweep, and the tests
+// otherwise.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("certmgr_certificate", &resource.Sweeper{
+		Name: "certmgr_certificate",
+		F:    sweepCertificates,
+	})
+}
+
+// sweepCertificates deletes the staged entries of tf-test-cert-* hostnames
+// left behind by failed acceptance test runs against the certMgr instance
+// the provider environment variables point at.
+func sweepCertificates(_ string) error {
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	staged, err := client.ListCertificates(ctx, certMgr.CertificateFilter{HostnamePrefix: testAccHostnamePrefix})
+	if err != nil {
+		return fmt.Errorf("listing test certificates: %w", err)
+	}
+
+	var ids []int
+	for _, cert := range staged {
+		// Only delete what is unmistakably a test certificate, whatever the
+		// server-side filter matched.
+		if strings.HasPrefix(strings.ToLower(cert.Hostname), testAccHostnamePrefix) {
+			ids = append(ids, cert.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := client.DeleteStagedEntries(ctx, ids); err != nil {
+		return fmt.Errorf("deleting %d test certificates: %w", len(ids), err)
+	}
+	return nil
+}
+
+// sweeperClient returns a client configured from the CERTMGR_ENDPOINT, or
+// CERTMGR_HOST and CERTMGR_PORT, environment variables, like the provider.
+func sweeperClient() (*certMgr.Client, error) {
+	if endpoint := os.Getenv("CERTMGR_ENDPOINT"); endpoint != "" {
+		socketPath, err := unixSocketPath(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return certMgr.NewUnixSocketClient(socketPath)
+	}
+
+	host := os.Getenv("CERTMGR_HOST")
+	port, err := strconv.Atoi(os.Getenv("CERTMGR_PORT"))
+	if host == "" || err != nil {
+		return nil, fmt.Errorf("set CERTMGR_ENDPOINT, or CERTMGR_HOST and CERTMGR_PORT, to sweep")
+	}
+	return certMgr.NewClient(host, port)
+}
+
+func TestSweepCertificates(t *testing.T) {
+	server := fakecertmgr.NewServer(t)
+	socket := testAccSocket(t, server)
+	client := server.NewClient()
+	ctx := context.Background()
+	for _, hostname := range []string{testAccHostnamePrefix + "a.cern.ch", testAccHostnamePrefix + "b.cern.ch", "tf-test.cern.ch"} {
+		_, err := client.CreateCertificate(ctx, certMgr.CertificateRequest{Hostname: hostname})
+		require.NoError(t, err)
+	}
+
+	t.Setenv("CERTMGR_ENDPOINT", "unix://"+socket)
+	require.NoError(t, sweepCertificates(""))
+
+	remaining := server.Certificates()
+	require.Len(t, remaining, 1)
+	require.Equal(t, "tf-test.cern.ch", remaining[0].Hostname)
+}
@@ -0,0 +1,356 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+	"certMgr/internal/teigi"
+)
+
+var (
+	_ resource.Resource               = &teigiExportResource{}
+	_ resource.ResourceWithConfigure  = &teigiExportResource{}
+	_ resource.ResourceWithModifyPlan = &teigiExportResource{}
+)
+
+func NewTeigiExportResource() resource.Resource {
+	return &teigiExportResource{}
+}
+
+type teigiExportResourceModel struct {
+	CertificateID   types.Int64  `tfsdk:"certificate_id"`
+	URL             types.String `tfsdk:"url"`
+	Hostgroup       types.String `tfsdk:"hostgroup"`
+	CertificateKey  types.String `tfsdk:"certificate_secret"`
+	ChainKey        types.String `tfsdk:"chain_secret"`
+	PrivateKeyKey   types.String `tfsdk:"private_key_secret"`
+	PrivateKeyPEMWO types.String `tfsdk:"private_key_pem_wo"`
+	Serial          types.String `tfsdk:"serial"`
+	Organization    types.String `tfsdk:"organization"`
+}
+
+type teigiExportResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *teigiExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_teigi_export"
+}
+
+func (r *teigiExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Pushes an issued certificate, its chain and private key into Teigi as secrets of a hostgroup, " +
+			"from where Puppet managed nodes read them. Teigi is accessed with the Kerberos credentials of the provider. " +
+			"The secrets are written again when certMgr reissues the certificate and deleted when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"certificate_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate to export.",
+				Required:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the Teigi service. Defaults to `" + teigi.DefaultURL + "`.",
+				Optional:    true,
+			},
+			"hostgroup": schema.StringAttribute{
+				Description: "Hostgroup owning the secrets, for example `it/web/frontend`. Changing this forces a new export.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate_secret": schema.StringAttribute{
+				Description: "Key of the secret holding the certificate. Changing this forces a new export.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"chain_secret": schema.StringAttribute{
+				Description: "Key of the secret holding the chain. The chain is not exported when unset. Changing this forces a new export.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_secret": schema.StringAttribute{
+				Description: "Key of the secret holding the private key. The key is not exported when unset. Changing this forces a new export.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_pem_wo": schema.StringAttribute{
+				Description: "PEM encoded private key to export, for certificates requested with a CSR whose key certMgr does not hold. " +
+					"Never stored in state or plan.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the exported certificate.",
+				Computed:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is read from, overriding the provider's organization. " +
+					"Changing this forces a new export.",
+			),
+		},
+	}
+}
+
+// ModifyPlan plans a new export when certMgr reissued the certificate since
+// the last one.
+func (r *teigiExportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state teigiExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.CertificateID.IsUnknown() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	certificate, err := r.client.GetCertificateByID(ctx, int(plan.CertificateID.ValueInt64()))
+	if err != nil {
+		return
+	}
+	if certificateSerial(certificate.CertificatePEM) != state.Serial.ValueString() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("serial"), types.StringUnknown())...)
+	}
+}
+
+func (m teigiExportResourceModel) teigiClient() (*teigi.Client, error) {
+	httpClient, err := certMgr.NewKerberosHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return teigi.NewClient(m.URL.ValueString(), httpClient), nil
+}
+
+func (r *teigiExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config teigiExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// export writes the certificate material to Teigi and records its serial in
+// model.
+func (r *teigiExportResource) export(ctx context.Context, model *teigiExportResourceModel, config teigiExportResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client, err := model.teigiClient()
+	if err != nil {
+		diags.AddError("Unable to Authenticate to Teigi", err.Error())
+		return diags
+	}
+
+	id := int(model.CertificateID.ValueInt64())
+	certificate, err := r.client.GetCertificateByID(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not read certificate %d: %s", id, err),
+		)
+		return diags
+	}
+	if certificate.CertificatePEM == "" {
+		diags.AddAttributeError(
+			path.Root("certificate_id"),
+			"Certificate Not Issued",
+			fmt.Sprintf("Certificate %d for %s has not been issued yet, so there is nothing to export.", id, certificate.Hostname),
+		)
+		return diags
+	}
+
+	leaf, chain, err := leafAndChain(certificate.CertificatePEM)
+	if err != nil {
+		diags.AddError("Invalid Certificate", fmt.Sprintf("Could not split the chain of certificate %d: %s", id, err))
+		return diags
+	}
+
+	privateKey := certificate.PrivateKeyPEM
+	if !config.PrivateKeyPEMWO.IsNull() {
+		privateKey = config.PrivateKeyPEMWO.ValueString()
+	}
+	if !model.PrivateKeyKey.IsNull() && privateKey == "" {
+		diags.AddAttributeError(
+			path.Root("private_key_pem_wo"),
+			"Private Key Unavailable",
+			fmt.Sprintf("certMgr does not hold the private key of certificate %d; set private_key_pem_wo to export it.", id),
+		)
+		return diags
+	}
+
+	secrets := map[string]string{model.CertificateKey.ValueString(): leaf}
+	if !model.ChainKey.IsNull() {
+		secrets[model.ChainKey.ValueString()] = chain
+	}
+	if !model.PrivateKeyKey.IsNull() {
+		secrets[model.PrivateKeyKey.ValueString()] = privateKey
+	}
+
+	hostgroup := model.Hostgroup.ValueString()
+	for key, value := range secrets {
+		if err := client.SetSecret(ctx, hostgroup, key, []byte(value)); err != nil {
+			diags.AddError(
+				"Error Writing Teigi Secret",
+				fmt.Sprintf("Could not write secret %s of hostgroup %s: %s", key, hostgroup, err),
+			)
+			return diags
+		}
+	}
+
+	model.Serial = types.StringValue(certificateSerial(leaf))
+	return diags
+}
+
+// certificateSerial returns the hex serial number of the first certificate in
+// a PEM bundle, or an empty string when there is none. Serials are compared
+// in this form, as certMgr and Teigi may format them differently.
+func certificateSerial(bundle string) string {
+	cert, err := pki.ParseCertificatePEM(bundle)
+	if err != nil {
+		return ""
+	}
+	return cert.SerialNumber.Text(16)
+}
+
+// Read removes the export from state when the certificate secret was deleted
+// from Teigi, and records the serial of the certificate found there.
+func (r *teigiExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state teigiExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := state.teigiClient()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Authenticate to Teigi", err.Error())
+		return
+	}
+
+	hostgroup, key := state.Hostgroup.ValueString(), state.CertificateKey.ValueString()
+	value, err := client.GetSecret(ctx, hostgroup, key)
+	if err != nil {
+		if errors.Is(err, teigi.ErrNotFound) {
+			resp.Diagnostics.AddWarning(
+				"Teigi Secret Not Found",
+				fmt.Sprintf("The secret %s of hostgroup %s no longer exists; removing resource from state.", key, hostgroup),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Teigi Secret",
+			fmt.Sprintf("Could not read secret %s of hostgroup %s: %s", key, hostgroup, err),
+		)
+		return
+	}
+
+	// A secret that no longer parses is replaced on the next apply.
+	state.Serial = types.StringValue(certificateSerial(string(value)))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *teigiExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config teigiExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *teigiExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state teigiExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := state.teigiClient()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Authenticate to Teigi", err.Error())
+		return
+	}
+
+	hostgroup := state.Hostgroup.ValueString()
+	for _, key := range []types.String{state.CertificateKey, state.ChainKey, state.PrivateKeyKey} {
+		if key.IsNull() {
+			continue
+		}
+		if err := client.DeleteSecret(ctx, hostgroup, key.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Teigi Secret",
+				fmt.Sprintf("Could not delete secret %s of hostgroup %s: %s", key.ValueString(), hostgroup, err),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *teigiExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource                = &templateResource{}
+	_ resource.ResourceWithConfigure   = &templateResource{}
+	_ resource.ResourceWithImportState = &templateResource{}
+)
+
+func NewTemplateResource() resource.Resource {
+	return &templateResource{}
+}
+
+type templateResourceModel struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	KeyAlgorithm       types.String `tfsdk:"key_algorithm"`
+	MinKeyBits         types.Int64  `tfsdk:"min_key_bits"`
+	MaxValidityDays    types.Int64  `tfsdk:"max_validity_days"`
+	AllowedSANPatterns types.List   `tfsdk:"allowed_san_patterns"`
+	Organization       types.String `tfsdk:"organization"`
+}
+
+type templateResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *templateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template"
+}
+
+func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a certMgr issuance template. Requires certMgr administrator privileges.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the template.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Unique name of the template.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Human readable description of the template.",
+				Optional:    true,
+			},
+			"key_algorithm": schema.StringAttribute{
+				Description: "Key algorithm certificates issued with the template must use, such as `RSA` or `ECDSA`.",
+				Optional:    true,
+			},
+			"min_key_bits": schema.Int64Attribute{
+				Description: "Minimum key size in bits.",
+				Optional:    true,
+			},
+			"max_validity_days": schema.Int64Attribute{
+				Description: "Maximum validity of issued certificates in days.",
+				Optional:    true,
+			},
+			"allowed_san_patterns": schema.ListAttribute{
+				Description: "Glob patterns subject alternative names must match, such as `*.cern.ch`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the template is managed in, overriding the provider's organization. " +
+					"Changing this forces a new template.",
+			),
+		},
+	}
+}
+
+func (r *templateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan templateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	template, diags := plan.toTemplate(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateTemplate(ctx, template)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating template",
+			"Could not create template: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(created.ID))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *templateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state templateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	template, err := r.client.GetTemplate(ctx, int(id))
+	if err != nil {
+		if errors.Is(err, certMgr.ErrNoTemplate) {
+			resp.Diagnostics.AddWarning(
+				"Template Not Found",
+				fmt.Sprintf("No template found with ID %d; removing resource from state.", id),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addReadError(
+			&resp.Diagnostics, r.client, err,
+			"Error Reading Template",
+			fmt.Sprintf("Could not read template %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fromTemplate(ctx, template)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *templateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, state templateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	plan.ID = state.ID
+	template, diags := plan.toTemplate(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateTemplate(ctx, template); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating template",
+			"Could not update template: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *templateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state templateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	id := state.ID.ValueInt64()
+	if err := r.client.DeleteTemplate(ctx, int(id)); err != nil && !errors.Is(err, certMgr.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting template",
+			fmt.Sprintf("Could not delete template %d: %s", id, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *templateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *templateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportID(req.ID, "template")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Could not import %s: %s.", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (m templateResourceModel) toTemplate(ctx context.Context) (certMgr.Template, diag.Diagnostics) {
+	template := certMgr.Template{
+		ID:              int(m.ID.ValueInt64()),
+		Name:            m.Name.ValueString(),
+		Description:     m.Description.ValueString(),
+		KeyAlgorithm:    m.KeyAlgorithm.ValueString(),
+		MinKeyBits:      int(m.MinKeyBits.ValueInt64()),
+		MaxValidityDays: int(m.MaxValidityDays.ValueInt64()),
+	}
+
+	var diags diag.Diagnostics
+	if !m.AllowedSANPatterns.IsNull() {
+		diags = m.AllowedSANPatterns.ElementsAs(ctx, &template.AllowedSANPatterns, false)
+	}
+	return template, diags
+}
+
+func (m *templateResourceModel) fromTemplate(ctx context.Context, template *certMgr.Template) diag.Diagnostics {
+	m.Name = types.StringValue(template.Name)
+	m.Description = optionalString(m.Description, template.Description)
+	m.KeyAlgorithm = optionalString(m.KeyAlgorithm, template.KeyAlgorithm)
+	m.MinKeyBits = optionalInt64(m.MinKeyBits, template.MinKeyBits)
+	m.MaxValidityDays = optionalInt64(m.MaxValidityDays, template.MaxValidityDays)
+
+	if len(template.AllowedSANPatterns) == 0 && m.AllowedSANPatterns.IsNull() {
+		return nil
+	}
+	patterns, diags := types.ListValueFrom(ctx, types.StringType, template.AllowedSANPatterns)
+	m.AllowedSANPatterns = patterns
+	return diags
+}
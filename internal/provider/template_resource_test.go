@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTemplateFromTemplate checks that optional settings left unset stay null
+// while settings changed in certMgr show up as drift.
+func TestTemplateFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	unset := templateResourceModel{
+		ID:                 types.Int64Value(5),
+		Name:               types.StringValue("web"),
+		Description:        types.StringNull(),
+		KeyAlgorithm:       types.StringNull(),
+		MinKeyBits:         types.Int64Null(),
+		MaxValidityDays:    types.Int64Null(),
+		AllowedSANPatterns: types.ListNull(types.StringType),
+	}
+	patterns := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("*.cern.ch")})
+
+	tests := []struct {
+		name     string
+		prior    func(templateResourceModel) templateResourceModel
+		template certMgr.Template
+		want     func(templateResourceModel) templateResourceModel
+	}{
+		{
+			name:     "unset",
+			prior:    func(m templateResourceModel) templateResourceModel { return m },
+			template: certMgr.Template{ID: 5, Name: "web"},
+			want:     func(m templateResourceModel) templateResourceModel { return m },
+		},
+		{
+			name:  "set in certMgr",
+			prior: func(m templateResourceModel) templateResourceModel { return m },
+			template: certMgr.Template{
+				ID: 5, Name: "web", KeyAlgorithm: "ECDSA", MinKeyBits: 256, MaxValidityDays: 90,
+				AllowedSANPatterns: []string{"*.cern.ch"},
+			},
+			want: func(m templateResourceModel) templateResourceModel {
+				m.KeyAlgorithm = types.StringValue("ECDSA")
+				m.MinKeyBits = types.Int64Value(256)
+				m.MaxValidityDays = types.Int64Value(90)
+				m.AllowedSANPatterns = patterns
+				return m
+			},
+		},
+		{
+			name: "cleared in certMgr",
+			prior: func(m templateResourceModel) templateResourceModel {
+				m.Description = types.StringValue("Web servers")
+				m.MinKeyBits = types.Int64Value(2048)
+				m.AllowedSANPatterns = patterns
+				return m
+			},
+			template: certMgr.Template{ID: 5, Name: "web"},
+			want: func(m templateResourceModel) templateResourceModel {
+				m.Description = types.StringValue("")
+				m.MinKeyBits = types.Int64Value(0)
+				m.AllowedSANPatterns = types.ListNull(types.StringType)
+				return m
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := tt.prior(unset)
+			require.False(t, model.fromTemplate(ctx, &tt.template).HasError())
+			require.Equal(t, tt.want(unset), model)
+		})
+	}
+}
+
+func TestTemplateToTemplate(t *testing.T) {
+	ctx := context.Background()
+	model := templateResourceModel{
+		ID:                 types.Int64Value(5),
+		Name:               types.StringValue("web"),
+		Description:        types.StringNull(),
+		KeyAlgorithm:       types.StringValue("RSA"),
+		MinKeyBits:         types.Int64Value(2048),
+		MaxValidityDays:    types.Int64Null(),
+		AllowedSANPatterns: types.ListNull(types.StringType),
+	}
+
+	template, diags := model.toTemplate(ctx)
+	require.False(t, diags.HasError())
+	require.Equal(t, certMgr.Template{ID: 5, Name: "web", KeyAlgorithm: "RSA", MinKeyBits: 2048}, template)
+
+	model.AllowedSANPatterns = types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("*.cern.ch"), types.StringValue("*.web.cern.ch"),
+	})
+	template, diags = model.toTemplate(ctx)
+	require.False(t, diags.HasError())
+	require.Equal(t, []string{"*.cern.ch", "*.web.cern.ch"}, template.AllowedSANPatterns)
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &tlsaRecordFunction{}
+
+func NewTLSARecordFunction() function.Function {
+	return &tlsaRecordFunction{}
+}
+
+type tlsaRecordFunction struct{}
+
+func (f *tlsaRecordFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tlsa_record"
+}
+
+func (f *tlsaRecordFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute a DANE TLSA record for a certificate",
+		Description: "Returns the value of a DANE TLSA record (RFC 6698) for the first certificate in a PEM string, " +
+			"for example `3 1 1 <sha256 of the public key>`, ready to be passed to a DNS provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "PEM-encoded certificate.",
+			},
+			function.Int64Parameter{
+				Name:        "usage",
+				Description: "Certificate usage: `0` (PKIX-TA), `1` (PKIX-EE), `2` (DANE-TA) or `3` (DANE-EE).",
+			},
+			function.Int64Parameter{
+				Name:        "selector",
+				Description: "Selector: `0` for the full certificate or `1` for its SubjectPublicKeyInfo.",
+			},
+			function.Int64Parameter{
+				Name:        "matching_type",
+				Description: "Matching type: `0` for the raw data, `1` for SHA-256 or `2` for SHA-512.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *tlsaRecordFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	var usage, selector, matchingType int64
+	resp.Error = req.Arguments.Get(ctx, &data, &usage, &selector, &matchingType)
+	if resp.Error != nil {
+		return
+	}
+
+	cert, err := pki.ParseCertificatePEM(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	record, err := pki.TLSARecord(cert, int(usage), int(selector), int(matchingType))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, record)
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+)
+
+var (
+	_ resource.Resource              = &trustBundleResource{}
+	_ resource.ResourceWithConfigure = &trustBundleResource{}
+)
+
+func NewTrustBundleResource() resource.Resource {
+	return &trustBundleResource{}
+}
+
+type trustBundleResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	Authorities  types.List   `tfsdk:"authorities"`
+	PEM          types.String `tfsdk:"pem"`
+	Organization types.String `tfsdk:"organization"`
+}
+
+type trustBundleResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *trustBundleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trust_bundle"
+}
+
+func (r *trustBundleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assembles a named trust bundle from root and intermediate CAs known to certMgr. " +
+			"The bundle is refreshed on every read, so CA rotations show up as a diff of `pem`.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the bundle. Changing this forces a new bundle.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"authorities": schema.ListAttribute{
+				Description: "Names of the certMgr CAs to include, in bundle order.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"pem": schema.StringAttribute{
+				Description: "Concatenated PEM encoded certificates of the selected CAs.",
+				Computed:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate authorities are read from, overriding the provider's organization. " +
+					"Changing this forces a new bundle.",
+			),
+		},
+	}
+}
+
+func (r *trustBundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan trustBundleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.assemble(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *trustBundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state trustBundleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	resp.Diagnostics.Append(r.assemble(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *trustBundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan trustBundleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.assemble(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only forgets the bundle; it exists solely in Terraform state.
+func (r *trustBundleResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *trustBundleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// assemble fetches the configured CAs and stores their concatenated PEM.
+func (r *trustBundleResource) assemble(ctx context.Context, m *trustBundleResourceModel) diag.Diagnostics {
+	var names []string
+	diags := m.Authorities.ElementsAs(ctx, &names, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	var bundle strings.Builder
+	for _, name := range names {
+		authority, err := r.client.GetCertificateAuthority(ctx, name)
+		if err != nil {
+			diags.AddError(
+				"Error Assembling Trust Bundle",
+				fmt.Sprintf("Could not fetch certificate authority %s for bundle %s: %s", name, m.Name.ValueString(), err),
+			)
+			return diags
+		}
+		bundle.WriteString(strings.TrimSpace(authority.PEM))
+		bundle.WriteString("\n")
+	}
+
+	m.PEM = types.StringValue(bundle.String())
+	return diags
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustBundleAssemble(t *testing.T) {
+	ctx := context.Background()
+	r := &trustBundleResource{client: &clientmock.Client{
+		GetCertificateAuthorityFunc: func(_ context.Context, name string) (*certMgr.CertificateAuthority, error) {
+			pem, ok := map[string]string{
+				"root":         "root-pem\n\n",
+				"intermediate": "  intermediate-pem",
+			}[name]
+			if !ok {
+				return nil, certMgr.ErrNotFound
+			}
+			return &certMgr.CertificateAuthority{Name: name, PEM: pem}, nil
+		},
+	}}
+
+	tests := []struct {
+		name        string
+		authorities []string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "configured order",
+			authorities: []string{"intermediate", "root"},
+			want:        "intermediate-pem\nroot-pem\n",
+		},
+		{
+			name:        "reversed",
+			authorities: []string{"root", "intermediate"},
+			want:        "root-pem\nintermediate-pem\n",
+		},
+		{
+			name:        "empty",
+			authorities: []string{},
+			want:        "",
+		},
+		{
+			name:        "unknown authority",
+			authorities: []string{"root", "missing"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := make([]attr.Value, len(tt.authorities))
+			for i, name := range tt.authorities {
+				names[i] = types.StringValue(name)
+			}
+			model := trustBundleResourceModel{
+				Name:        types.StringValue("grid"),
+				Authorities: types.ListValueMust(types.StringType, names),
+				PEM:         types.StringUnknown(),
+			}
+
+			diags := r.assemble(ctx, &model)
+			if tt.wantErr {
+				require.True(t, diags.HasError())
+				require.Contains(t, diags.Errors()[0].Detail(), "missing")
+				require.True(t, model.PEM.IsUnknown())
+				return
+			}
+			require.False(t, diags.HasError(), diags)
+			require.Equal(t, tt.want, model.PEM.ValueString())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"certMgr/internal/pki"
+)
+
+var _ function.Function = &validatePEMFunction{}
+
+func NewValidatePEMFunction() function.Function {
+	return &validatePEMFunction{}
+}
+
+type validatePEMFunction struct{}
+
+func (f *validatePEMFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_pem"
+}
+
+func (f *validatePEMFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check whether a string is well-formed PEM of a given type",
+		Description: "Returns `true` when the string consists only of PEM blocks of the given type that decode " +
+			"successfully, and `false` otherwise. Intended for `validation` blocks of user-supplied input.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "String to check.",
+			},
+			function.StringParameter{
+				Name:        "type",
+				Description: "Expected PEM type: one of `" + strings.Join(pki.PEMTypes, "`, `") + "`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *validatePEMFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data, kind string
+	resp.Error = req.Arguments.Get(ctx, &data, &kind)
+	if resp.Error != nil {
+		return
+	}
+
+	if !slices.Contains(pki.PEMTypes, kind) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unsupported PEM type %q", kind))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, pki.ValidatePEM(data, kind) == nil)
+}
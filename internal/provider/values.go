@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// optionalString maps an API value onto an optional attribute, keeping the
+// attribute null when it was never configured and the API has no value.
+func optionalString(prior types.String, value string) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// optionalInt64 is the Int64 counterpart of optionalString.
+func optionalInt64(prior types.Int64, value int) types.Int64 {
+	if value == 0 && prior.IsNull() {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(value))
+}
@@ -0,0 +1,387 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/pki"
+	"certMgr/internal/vault"
+)
+
+var (
+	_ resource.Resource                   = &vaultExportResource{}
+	_ resource.ResourceWithConfigure      = &vaultExportResource{}
+	_ resource.ResourceWithValidateConfig = &vaultExportResource{}
+	_ resource.ResourceWithModifyPlan     = &vaultExportResource{}
+)
+
+func NewVaultExportResource() resource.Resource {
+	return &vaultExportResource{}
+}
+
+type vaultExportResourceModel struct {
+	CertificateID   types.Int64  `tfsdk:"certificate_id"`
+	Address         types.String `tfsdk:"address"`
+	Namespace       types.String `tfsdk:"namespace"`
+	Mount           types.String `tfsdk:"mount"`
+	Path            types.String `tfsdk:"path"`
+	KVVersion       types.Int64  `tfsdk:"kv_version"`
+	PrivateKeyPEMWO types.String `tfsdk:"private_key_pem_wo"`
+	Serial          types.String `tfsdk:"serial"`
+	SecretVersion   types.Int64  `tfsdk:"secret_version"`
+	Organization    types.String `tfsdk:"organization"`
+}
+
+type vaultExportResource struct {
+	client certMgr.ClientAPI
+}
+
+func (r *vaultExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault_export"
+}
+
+func (r *vaultExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Writes an issued certificate, its chain and private key into a HashiCorp Vault KV secret, " +
+			"with the keys `certificate`, `ca_chain`, `private_key`, `serial` and `hostname`. " +
+			"The secret is written again when certMgr reissues the certificate and deleted when the resource is destroyed. " +
+			"The Vault token is read from the VAULT_TOKEN environment variable, so that it is never stored in state.",
+		Attributes: map[string]schema.Attribute{
+			"certificate_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the certificate to export.",
+				Required:    true,
+			},
+			"address": schema.StringAttribute{
+				Description: "Address of the Vault server. May also be provided via VAULT_ADDR environment variable.",
+				Optional:    true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: "Vault Enterprise namespace. May also be provided via VAULT_NAMESPACE environment variable.",
+				Optional:    true,
+			},
+			"mount": schema.StringAttribute{
+				Description: "Mount path of the KV secrets engine, for example `secret`. Changing this forces a new export.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of the secret within the mount. Changing this forces a new export.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"kv_version": schema.Int64Attribute{
+				Description: "Version of the KV secrets engine, `1` or `2`. Defaults to `2`. Changing this forces a new export.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(2),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_pem_wo": schema.StringAttribute{
+				Description: "PEM encoded private key to export, for certificates requested with a CSR whose key certMgr does not hold. " +
+					"Never stored in state or plan.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"serial": schema.StringAttribute{
+				Description: "Serial number of the exported certificate.",
+				Computed:    true,
+			},
+			"secret_version": schema.Int64Attribute{
+				Description: "Version of the KV version 2 secret written by the last export.",
+				Computed:    true,
+			},
+			"organization": organizationAttribute(
+				"Organization (VO) of the certMgr instance the certificate is read from, overriding the provider's organization. " +
+					"Changing this forces a new export.",
+			),
+		},
+	}
+}
+
+func (r *vaultExportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config vaultExportResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.KVVersion.IsNull() && !config.KVVersion.IsUnknown() &&
+		config.KVVersion.ValueInt64() != 1 && config.KVVersion.ValueInt64() != 2 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kv_version"),
+			"Invalid KV Version",
+			fmt.Sprintf("kv_version must be 1 or 2, got: %d", config.KVVersion.ValueInt64()),
+		)
+	}
+}
+
+// ModifyPlan plans a new export when certMgr reissued the certificate since
+// the last one.
+func (r *vaultExportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state vaultExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.CertificateID.IsUnknown() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	certificate, err := r.client.GetCertificateByID(ctx, int(plan.CertificateID.ValueInt64()))
+	if err != nil {
+		// Read reports the certificate as gone; the plan proceeds on state.
+		return
+	}
+	if issuedSerial(certificate) != state.Serial.ValueString() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("serial"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_version"), types.Int64Unknown())...)
+	}
+}
+
+func (r *vaultExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config vaultExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// export writes the certificate to Vault and records its serial and the
+// secret version in model.
+func (r *vaultExportResource) export(ctx context.Context, model *vaultExportResourceModel, config vaultExportResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client, err := model.vaultClient(r.client.ExternalClient())
+	if err != nil {
+		diags.AddError("Invalid Vault Configuration", err.Error())
+		return diags
+	}
+
+	id := int(model.CertificateID.ValueInt64())
+	certificate, err := r.client.GetCertificateByID(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Certificate",
+			fmt.Sprintf("Could not read certificate %d: %s", id, err),
+		)
+		return diags
+	}
+	if certificate.CertificatePEM == "" {
+		diags.AddAttributeError(
+			path.Root("certificate_id"),
+			"Certificate Not Issued",
+			fmt.Sprintf("Certificate %d for %s has not been issued yet, so there is nothing to export.", id, certificate.Hostname),
+		)
+		return diags
+	}
+
+	leaf, chain, err := leafAndChain(certificate.CertificatePEM)
+	if err != nil {
+		diags.AddError("Invalid Certificate", fmt.Sprintf("Could not split the chain of certificate %d: %s", id, err))
+		return diags
+	}
+
+	data := map[string]string{
+		"certificate": leaf,
+		"ca_chain":    chain,
+		"private_key": certificate.PrivateKeyPEM,
+		"serial":      issuedSerial(certificate),
+		"hostname":    certificate.Hostname,
+	}
+	if !config.PrivateKeyPEMWO.IsNull() {
+		data["private_key"] = config.PrivateKeyPEMWO.ValueString()
+	}
+
+	version, err := client.WriteKV(ctx, model.Mount.ValueString(), model.Path.ValueString(), int(model.KVVersion.ValueInt64()), data)
+	if err != nil {
+		diags.AddError(
+			"Error Writing Vault Secret",
+			fmt.Sprintf("Could not write certificate %d to %s/%s: %s", id, model.Mount.ValueString(), model.Path.ValueString(), err),
+		)
+		return diags
+	}
+
+	model.Serial = types.StringValue(data["serial"])
+	model.SecretVersion = types.Int64Value(int64(version))
+	return diags
+}
+
+func (m vaultExportResourceModel) vaultClient(httpClient *http.Client) (*vault.Client, error) {
+	client, err := vault.NewClient(
+		stringOrEnv(m.Address, "VAULT_ADDR"),
+		os.Getenv("VAULT_TOKEN"),
+		stringOrEnv(m.Namespace, "VAULT_NAMESPACE"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	client.HTTPClient = httpClient
+	return client, nil
+}
+
+// issuedSerial returns the serial certMgr reports for certificate, or the one
+// of its PEM when certMgr leaves it out.
+func issuedSerial(certificate *certMgr.Certificate) string {
+	if certificate.Serial != "" {
+		return certificate.Serial
+	}
+	return certificateSerial(certificate.CertificatePEM)
+}
+
+// leafAndChain splits a PEM bundle into its leaf certificate and the
+// concatenation of the remaining certificates, leaf first.
+func leafAndChain(bundle string) (string, string, error) {
+	blocks, err := pki.SplitChain(bundle)
+	if err != nil {
+		return "", "", err
+	}
+	if len(blocks) == 0 {
+		return "", "", errors.New("no certificates found")
+	}
+	return blocks[0], strings.Join(blocks[1:], ""), nil
+}
+
+// Read removes the export from state when the secret was deleted in Vault,
+// so that the next apply writes it again.
+func (r *vaultExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state vaultExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	client, err := state.vaultClient(r.client.ExternalClient())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Vault Configuration", err.Error())
+		return
+	}
+
+	data, err := client.ReadKV(ctx, state.Mount.ValueString(), state.Path.ValueString(), int(state.KVVersion.ValueInt64()))
+	if err != nil {
+		if errors.Is(err, vault.ErrNotFound) {
+			resp.Diagnostics.AddWarning(
+				"Vault Secret Not Found",
+				fmt.Sprintf("The secret %s/%s no longer exists; removing resource from state.", state.Mount.ValueString(), state.Path.ValueString()),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Vault Secret",
+			fmt.Sprintf("Could not read %s/%s: %s", state.Mount.ValueString(), state.Path.ValueString(), err),
+		)
+		return
+	}
+
+	state.Serial = types.StringValue(data["serial"])
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vaultExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var plan, config vaultExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, plan.Organization)
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vaultExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withProviderMeta(ctx, req.ProviderMeta, &resp.Diagnostics)
+	var state vaultExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withOrganization(ctx, state.Organization)
+
+	client, err := state.vaultClient(r.client.ExternalClient())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Vault Configuration", err.Error())
+		return
+	}
+
+	if err := client.DeleteKV(ctx, state.Mount.ValueString(), state.Path.ValueString(), int(state.KVVersion.ValueInt64())); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Vault Secret",
+			fmt.Sprintf("Could not delete %s/%s: %s", state.Mount.ValueString(), state.Path.ValueString(), err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *vaultExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(certMgr.ClientAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected client type",
+			fmt.Sprintf("Expected certMgr.ClientAPI, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
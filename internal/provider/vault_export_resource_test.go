@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	certMgr "certMgr/internal/client"
+	"certMgr/internal/clientmock"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultExportSerialFromPEM(t *testing.T) {
+	ctx := context.Background()
+	leafPEM, rootPEM, _ := newTestChain(t)
+
+	var written map[string]string
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "root", r.Header.Get("X-Vault-Token"))
+		require.Equal(t, "/v1/secret/data/tls/tf-test", r.URL.Path)
+		var payload struct {
+			Data map[string]string `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		written = payload.Data
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]int{"version": 1}})
+	}))
+	t.Cleanup(vault.Close)
+	t.Setenv("VAULT_TOKEN", "root")
+
+	// certMgr leaves out the serial of the issued certificate.
+	r := &vaultExportResource{client: &clientmock.Client{
+		GetCertificateByIDFunc: func(_ context.Context, id int) (*certMgr.Certificate, error) {
+			return &certMgr.Certificate{ID: id, Hostname: "tf-test.cern.ch", CertificatePEM: leafPEM + rootPEM}, nil
+		},
+	}}
+
+	model := vaultExportResourceModel{
+		CertificateID: types.Int64Value(42),
+		Address:       types.StringValue(vault.URL),
+		Mount:         types.StringValue("secret"),
+		Path:          types.StringValue("tls/tf-test"),
+		KVVersion:     types.Int64Value(2),
+	}
+	diags := r.export(ctx, &model, vaultExportResourceModel{})
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "2", model.Serial.ValueString())
+	require.Equal(t, "2", written["serial"])
+	require.Equal(t, rootPEM, written["ca_chain"])
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	plan := func(serial string) vaultExportResourceModel {
+		state := model
+		state.Serial = types.StringValue(serial)
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: schemaResp.Schema},
+			Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+		}
+		require.False(t, req.State.Set(ctx, state).HasError())
+		require.False(t, req.Plan.Set(ctx, state).HasError())
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var planned vaultExportResourceModel
+		require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+		return planned
+	}
+
+	require.Equal(t, "2", plan("2").Serial.ValueString())
+	require.True(t, plan("1").Serial.IsUnknown(), "a reissued certificate plans a new export")
+}
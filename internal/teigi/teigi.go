@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package teigi is a minimal client for Teigi, the CERN secret store from
+// which Puppet managed nodes read their secrets.
+package teigi
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultURL is the Teigi service used when no other is configured.
+const DefaultURL = "https://woger.cern.ch:8202"
+
+// ErrNotFound is returned when a secret does not exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Doer sends HTTP requests. Teigi authenticates with Kerberos, so this is
+// usually an SPNEGO client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client stores secrets for hostgroups in Teigi.
+type Client struct {
+	HTTPClient Doer
+	URL        string
+}
+
+// NewClient returns a client for the Teigi service at baseURL, or DefaultURL
+// when it is empty.
+func NewClient(baseURL string, httpClient Doer) *Client {
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+	return &Client{HTTPClient: httpClient, URL: strings.TrimSuffix(baseURL, "/")}
+}
+
+type secretPayload struct {
+	Secret   string `json:"secret"`
+	Encoding string `json:"encoding"`
+}
+
+// secretURL returns the URL of the secret key of hostgroup. Hostgroups are
+// hierarchical, so their slashes are kept.
+func (c *Client) secretURL(hostgroup, key string) string {
+	segments := strings.Split(strings.Trim(hostgroup, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return fmt.Sprintf("%s/tbag/v2/hostgroup/%s/secret/%s/", c.URL, strings.Join(segments, "/"), url.PathEscape(key))
+}
+
+// SetSecret creates or replaces the secret key of hostgroup.
+func (c *Client) SetSecret(ctx context.Context, hostgroup, key string, value []byte) error {
+	payload, err := json.Marshal(secretPayload{Secret: base64.StdEncoding.EncodeToString(value), Encoding: "b64"})
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+	_, err = c.do(ctx, http.MethodPost, c.secretURL(hostgroup, key), payload)
+	return err
+}
+
+// GetSecret returns the secret key of hostgroup, or ErrNotFound.
+func (c *Client) GetSecret(ctx context.Context, hostgroup, key string) ([]byte, error) {
+	body, err := c.do(ctx, http.MethodGet, c.secretURL(hostgroup, key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload secretPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode teigi response: %w", err)
+	}
+	if payload.Encoding != "b64" {
+		return []byte(payload.Secret), nil
+	}
+	value, err := base64.StdEncoding.DecodeString(payload.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode secret %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// DeleteSecret deletes the secret key of hostgroup. Secrets that do not
+// exist are not an error.
+func (c *Client) DeleteSecret(ctx context.Context, hostgroup, key string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.secretURL(hostgroup, key), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, url)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%s %s returned %s: %s", method, url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
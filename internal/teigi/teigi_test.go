@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package teigi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"certMgr/internal/teigi"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretRoundTrip(t *testing.T) {
+	var mu sync.Mutex
+	secrets := map[string]json.RawMessage{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			var body json.RawMessage
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			secrets[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := secrets[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(body)
+		case http.MethodDelete:
+			if _, ok := secrets[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(secrets, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	client := teigi.NewClient(server.URL, server.Client())
+
+	require.NoError(t, client.SetSecret(ctx, "it/web/frontend", "web.crt", []byte("pem")))
+	require.Contains(t, secrets, "/tbag/v2/hostgroup/it/web/frontend/secret/web.crt/")
+
+	value, err := client.GetSecret(ctx, "it/web/frontend", "web.crt")
+	require.NoError(t, err)
+	require.Equal(t, []byte("pem"), value)
+
+	require.NoError(t, client.DeleteSecret(ctx, "it/web/frontend", "web.crt"))
+	require.NoError(t, client.DeleteSecret(ctx, "it/web/frontend", "web.crt"))
+
+	_, err = client.GetSecret(ctx, "it/web/frontend", "web.crt")
+	require.ErrorIs(t, err, teigi.ErrNotFound)
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package vault is a minimal client for the key/value secrets engine of
+// HashiCorp Vault, used to export issued certificates.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound is returned when a secret does not exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Client writes secrets to the KV engines of a Vault server.
+type Client struct {
+	HTTPClient *http.Client
+	Address    string
+	Token      string
+	Namespace  string
+}
+
+// NewClient returns a client for the Vault server at address authenticating
+// with token. namespace is only needed on Vault Enterprise.
+func NewClient(address, token, namespace string) (*Client, error) {
+	if address == "" {
+		return nil, errors.New("vault address is required")
+	}
+	if token == "" {
+		return nil, errors.New("vault token is required")
+	}
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		Address:    strings.TrimSuffix(address, "/"),
+		Token:      token,
+		Namespace:  namespace,
+	}, nil
+}
+
+// secretURL returns the URL of the secret at path below mount. KV version 2
+// keeps the secret below data/ and its versions below metadata/.
+func (c *Client) secretURL(mount, path string, version int, prefix string) string {
+	mount = strings.Trim(mount, "/")
+	path = strings.Trim(path, "/")
+	if version == 2 {
+		return fmt.Sprintf("%s/v1/%s/%s/%s", c.Address, mount, prefix, path)
+	}
+	return fmt.Sprintf("%s/v1/%s/%s", c.Address, mount, path)
+}
+
+// WriteKV stores data as the secret at path of the KV engine of the given
+// version mounted at mount. For version 2 the number of the new secret
+// version is returned, for version 1 zero.
+func (c *Client) WriteKV(ctx context.Context, mount, path string, version int, data map[string]string) (int, error) {
+	var payload any = data
+	if version == 2 {
+		payload = map[string]any{"data": data}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("marshal failed: %w", err)
+	}
+
+	respBody, err := c.do(ctx, http.MethodPost, c.secretURL(mount, path, version, "data"), body)
+	if err != nil {
+		return 0, err
+	}
+	if version != 2 || len(respBody) == 0 {
+		return 0, nil
+	}
+
+	var resp struct {
+		Data struct {
+			Version int `json:"version"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return 0, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return resp.Data.Version, nil
+}
+
+// ReadKV returns the secret at path, or ErrNotFound.
+func (c *Client) ReadKV(ctx context.Context, mount, path string, version int) (map[string]string, error) {
+	respBody, err := c.do(ctx, http.MethodGet, c.secretURL(mount, path, version, "data"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 2 {
+		var resp struct {
+			Data struct {
+				Data map[string]string `json:"data"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			return nil, fmt.Errorf("failed to decode vault response: %w", err)
+		}
+		if resp.Data.Data == nil {
+			// Deleted versions are returned with null data.
+			return nil, ErrNotFound
+		}
+		return resp.Data.Data, nil
+	}
+
+	var resp struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return resp.Data, nil
+}
+
+// DeleteKV deletes the secret at path. For version 2 all its versions and
+// metadata are removed. Secrets that do not exist are not an error.
+func (c *Client) DeleteKV(ctx context.Context, mount, path string, version int) error {
+	_, err := c.do(ctx, http.MethodDelete, c.secretURL(mount, path, version, "metadata"), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", c.Token)
+	if c.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, url)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%s %s returned %s: %s", method, url, resp.Status, vaultErrors(body))
+	}
+	return body, nil
+}
+
+// vaultErrors returns the messages of a Vault error response, or the body.
+func vaultErrors(body []byte) string {
+	var resp struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err == nil && len(resp.Errors) > 0 {
+		return strings.Join(resp.Errors, "; ")
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package vault_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"certMgr/internal/vault"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newKVServer fakes a KV version 2 engine mounted at secret/.
+func newKVServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	secrets := map[string]map[string]string{}
+	versions := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+			key := strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+			var payload struct {
+				Data map[string]string `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			secrets[key] = payload.Data
+			versions[key]++
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]int{"version": versions[key]}})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+			data, ok := secrets[strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/secret/metadata/"):
+			key := strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata/")
+			if _, ok := secrets[key]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(secrets, key)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestKVRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	server := newKVServer(t)
+	client, err := vault.NewClient(server.URL+"/", "root", "")
+	require.NoError(t, err)
+
+	version, err := client.WriteKV(ctx, "secret", "tls/host.cern.ch", 2, map[string]string{"certificate": "pem"})
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	version, err = client.WriteKV(ctx, "/secret/", "tls/host.cern.ch", 2, map[string]string{"certificate": "renewed"})
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+
+	data, err := client.ReadKV(ctx, "secret", "tls/host.cern.ch", 2)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"certificate": "renewed"}, data)
+
+	require.NoError(t, client.DeleteKV(ctx, "secret", "tls/host.cern.ch", 2))
+	require.NoError(t, client.DeleteKV(ctx, "secret", "tls/host.cern.ch", 2))
+
+	_, err = client.ReadKV(ctx, "secret", "tls/host.cern.ch", 2)
+	require.ErrorIs(t, err, vault.ErrNotFound)
+}
+
+func TestKVErrors(t *testing.T) {
+	server := newKVServer(t)
+	client, err := vault.NewClient(server.URL, "wrong", "")
+	require.NoError(t, err)
+
+	_, err = client.WriteKV(context.Background(), "secret", "tls/host.cern.ch", 2, map[string]string{})
+	require.ErrorContains(t, err, "permission denied")
+
+	_, err = vault.NewClient("", "root", "")
+	require.Error(t, err)
+}
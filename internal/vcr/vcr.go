@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: 2025 CERN
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package vcr records HTTP interactions with certMgr into fixtures and
+// replays them, so that client tests see real response shapes without
+// network access or Kerberos credentials.
+package vcr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecordEnv is the environment variable that switches tests from replaying
+// fixtures to recording them against a live certMgr.
+const RecordEnv = "CERTMGR_RECORD"
+
+// Mode selects whether a Recorder talks to the network.
+type Mode int
+
+const (
+	// Replay answers requests from the fixture and never touches the network.
+	Replay Mode = iota
+	// Record forwards requests and appends every interaction to the fixture.
+	Record
+)
+
+// ModeFromEnv returns Record when RecordEnv is set, and Replay otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return Record
+	}
+	return Replay
+}
+
+// redacted replaces the values of sensitiveFields in recorded bodies.
+const redacted = "REDACTED"
+
+// sensitiveFields are JSON fields whose values never end up in fixtures.
+var sensitiveFields = map[string]bool{
+	"private_key": true,
+	"password":    true,
+	"token":       true,
+}
+
+// sensitiveHeaders are headers that are dropped from recorded interactions.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "WWW-Authenticate"}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded part of a request. URL holds only the path and
+// query, so fixtures do not depend on the host they were recorded from.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is the recorded part of a response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays a fixture file.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New returns a Recorder for the fixture at path. In Replay mode the fixture
+// is loaded immediately; in Record mode requests are forwarded to next, or
+// to http.DefaultTransport when next is nil, and the fixture is written by
+// Save.
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, next: next}
+	if mode == Record {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	r.interactions = f.Interactions
+	r.used = make([]bool, len(f.Interactions))
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == Record {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// replay answers req with the first unused interaction of the same method
+// and URL, so repeated requests are served in recorded order.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	target := req.URL.RequestURI()
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != target {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.toHTTP(req), nil
+	}
+	return nil, fmt.Errorf("vcr: no recorded interaction for %s %s in %s", req.Method, target, r.path)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(respBody))
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	// SPNEGO challenges only precede the authenticated retry and are never
+	// seen by a client replaying without Kerberos.
+	if resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "Negotiate" {
+		return resp, nil
+	}
+
+	origin := req.URL.Scheme + "://" + req.URL.Host
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Body:   sanitizeBody(reqBody, origin),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     sanitizeHeader(resp.Header),
+			Body:       sanitizeBody(respBody, origin),
+		},
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the fixture file. It does nothing
+// in Replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(fixture{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (resp Response) toHTTP(req *http.Request) *http.Response {
+	header := resp.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+// readBody reads the response body, decompressing it when the server sent it
+// gzip encoded, so fixtures stay readable.
+func readBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if gz != nil {
+			defer gz.Close()
+			body = gz
+		}
+	}
+	return io.ReadAll(body)
+}
+
+func sanitizeHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range sensitiveHeaders {
+		header.Del(name)
+	}
+	header.Del("Date")
+	return header
+}
+
+// sanitizeBody redacts sensitiveFields in JSON bodies and strips origin from
+// absolute links, so fixtures hold neither secrets nor the recording host.
+func sanitizeBody(body []byte, origin string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err == nil {
+		if data, err := json.Marshal(redact(value)); err == nil {
+			body = data
+		}
+	}
+	return strings.ReplaceAll(string(body), origin, "")
+}
+
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if sensitiveFields[key] {
+				if s, ok := field.(string); ok && s != "" {
+					v[key] = redacted
+				}
+				continue
+			}
+			v[key] = redact(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+	return value
+}